@@ -1,30 +1,435 @@
 package config
 
 import (
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
 )
 
-var (
-	Port string
-	JwtSecret string
+type Profile string
+
+const (
+	ProfileDev  Profile = "dev"
+	ProfileTest Profile = "test"
+	ProfileProd Profile = "prod"
 )
 
-func LoadConfig() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file, please check your .env file")
+func (p Profile) Valid() bool {
+	switch p {
+	case ProfileDev, ProfileTest, ProfileProd:
+		return true
+	}
+	return false
+}
+
+// MinJWTSecretLength is the shortest HS256 signing secret Validate accepts.
+const MinJWTSecretLength = 32
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+type Config struct {
+	Profile     Profile
+	Port        int
+	DatabaseDSN string
+	AutoMigrate bool
+
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+
+	LogLevel           string
+	LogFormat          string
+	LogRedactUsernames bool
+
+	// TrustProxyHeaders takes the client address from X-Forwarded-For or
+	// X-Real-IP. Only enable it behind a proxy that sets them.
+	TrustProxyHeaders bool
+
+	LoginFreeAttempts  int
+	LoginBackoffBase   time.Duration
+	LoginBackoffMax    time.Duration
+	LoginMaxFailures   int
+	LoginIPMaxFailures int
+	LoginLockout       time.Duration
+
+	PasswordMinLength int
+	// BreachedPasswordsFile is an optional newline-separated list of
+	// passwords that Register and ChangePassword refuse.
+	BreachedPasswordsFile string
+
+	PasswordResetTTL time.Duration
+	// PasswordResetURL is the frontend page reset emails link to.
+	PasswordResetURL string
+	// PasswordResetCooldown is the minimum time between reset requests for
+	// one email or from one address. PasswordResetMaxRequests and
+	// PasswordResetIPMaxRequests block them for PasswordResetWindow.
+	PasswordResetCooldown      time.Duration
+	PasswordResetMaxRequests   int
+	PasswordResetIPMaxRequests int
+	PasswordResetWindow        time.Duration
+
+	EmailChangeTTL time.Duration
+	// EmailConfirmURL is the frontend page email confirmations link to.
+	EmailConfirmURL string
+
+	// MailDriver is log, file or smtp.
+	MailDriver   string
+	MailFrom     string
+	MailFile     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	TOTPIssuer        string
+	LoginChallengeTTL time.Duration
+
+	// E1RMFormula is epley or brzycki and decides which estimate personal
+	// records are tracked with.
+	E1RMFormula string
+}
+
+// Defaults returns the settings a profile starts from before the
+// environment and flags are applied.
+func Defaults(profile Profile) *Config {
+	cfg := &Config{
+		Profile:         profile,
+		Port:            8080,
+		DatabaseDSN:     "./database.sqlite",
+		AutoMigrate:     true,
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 30 * 24 * time.Hour,
+		ReadTimeout:     10 * time.Second,
+		WriteTimeout:    15 * time.Second,
+		IdleTimeout:     60 * time.Second,
+		ShutdownTimeout: 20 * time.Second,
+		LogLevel:        "debug",
+		LogFormat:       "console",
+
+		LoginFreeAttempts:  3,
+		LoginBackoffBase:   time.Second,
+		LoginBackoffMax:    time.Minute,
+		LoginMaxFailures:   10,
+		LoginIPMaxFailures: 50,
+		LoginLockout:       15 * time.Minute,
+
+		PasswordMinLength: 8,
+		PasswordResetTTL:  time.Hour,
+
+		PasswordResetCooldown:      time.Minute,
+		PasswordResetMaxRequests:   5,
+		PasswordResetIPMaxRequests: 20,
+		PasswordResetWindow:        time.Hour,
+
+		EmailChangeTTL: 24 * time.Hour,
+
+		MailDriver: "log",
+		MailFrom:   "no-reply@localhost",
+		MailFile:   "./mail.log",
+		SMTPPort:   587,
+
+		TOTPIssuer:        "Workup Fitness",
+		LoginChallengeTTL: 5 * time.Minute,
+
+		E1RMFormula: "epley",
+	}
+
+	switch profile {
+	case ProfileTest:
+		cfg.DatabaseDSN = "file::memory:?cache=shared"
+		cfg.ShutdownTimeout = 2 * time.Second
+		cfg.LogLevel = "warn"
+	case ProfileProd:
+		// Production schema changes go through `migrate up` on purpose.
+		cfg.AutoMigrate = false
+		cfg.LogLevel = "info"
+		cfg.LogFormat = "json"
+		cfg.LogRedactUsernames = true
+		cfg.MailDriver = "smtp"
+	}
+	return cfg
+}
+
+func (c *Config) Addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// ValidateDatabase checks only what the maintenance commands need to
+// reach the database, so they run without the server secrets.
+func (c *Config) ValidateDatabase() error {
+	var errs []error
+	if !c.Profile.Valid() {
+		errs = append(errs, fmt.Errorf("unknown profile %q, expected dev, test or prod", c.Profile))
+	}
+	if c.DatabaseDSN == "" {
+		errs = append(errs, errors.New("database DSN is empty"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if !c.Profile.Valid() {
+		errs = append(errs, fmt.Errorf("unknown profile %q, expected dev, test or prod", c.Profile))
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port %d is out of range 1-65535", c.Port))
+	}
+	if c.DatabaseDSN == "" {
+		errs = append(errs, errors.New("database DSN is empty"))
+	}
+	if len(c.JWTSecret) < MinJWTSecretLength {
+		errs = append(errs, fmt.Errorf("JWT_SECRET must be at least %d bytes", MinJWTSecretLength))
+	}
+	if c.AccessTokenTTL <= 0 || c.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("token TTLs must be positive"))
+	}
+	if c.AccessTokenTTL >= c.RefreshTokenTTL {
+		errs = append(errs, errors.New("access token TTL must be shorter than refresh token TTL"))
+	}
+	if _, err := zerolog.ParseLevel(c.LogLevel); err != nil || c.LogLevel == "" {
+		errs = append(errs, fmt.Errorf("unknown log level %q", c.LogLevel))
+	}
+	if c.LogFormat != "console" && c.LogFormat != "json" {
+		errs = append(errs, fmt.Errorf("unknown log format %q, expected console or json", c.LogFormat))
+	}
+	if c.LoginFreeAttempts < 0 {
+		errs = append(errs, errors.New("login free attempts cannot be negative"))
+	}
+	if c.LoginMaxFailures <= c.LoginFreeAttempts || c.LoginIPMaxFailures <= c.LoginFreeAttempts {
+		errs = append(errs, errors.New("login lockout thresholds must be greater than the free attempts"))
+	}
+	if c.PasswordResetMaxRequests < 1 || c.PasswordResetIPMaxRequests < 1 {
+		errs = append(errs, errors.New("password reset request limits must be positive"))
+	}
+	if c.PasswordMinLength < 1 || c.PasswordMinLength > 72 {
+		errs = append(errs, fmt.Errorf("password min length %d is out of range 1-72", c.PasswordMinLength))
+	}
+	switch c.MailDriver {
+	case "log", "file":
+	case "smtp":
+		if c.SMTPHost == "" {
+			errs = append(errs, errors.New("SMTP_HOST is required for the smtp mail driver"))
+		}
+		if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+			errs = append(errs, fmt.Errorf("SMTP port %d is out of range 1-65535", c.SMTPPort))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown mail driver %q, expected log, file or smtp", c.MailDriver))
+	}
+	if c.MailDriver == "file" && c.MailFile == "" {
+		errs = append(errs, errors.New("MAIL_FILE is required for the file mail driver"))
+	}
+	if c.TOTPIssuer == "" || strings.Contains(c.TOTPIssuer, ":") {
+		errs = append(errs, errors.New("TOTP issuer must be non-empty and free of colons"))
+	}
+	if c.E1RMFormula != "epley" && c.E1RMFormula != "brzycki" {
+		errs = append(errs, fmt.Errorf("unknown e1RM formula %q, expected epley or brzycki", c.E1RMFormula))
+	}
+	if c.MailFrom == "" {
+		errs = append(errs, errors.New("MAIL_FROM is empty"))
+	}
+	if c.LoginBackoffMax < c.LoginBackoffBase {
+		errs = append(errs, errors.New("login backoff max must not be below the base delay"))
+	}
+	for name, timeout := range map[string]time.Duration{
+		"read timeout":            c.ReadTimeout,
+		"write timeout":           c.WriteTimeout,
+		"idle timeout":            c.IdleTimeout,
+		"shutdown timeout":        c.ShutdownTimeout,
+		"login backoff base":      c.LoginBackoffBase,
+		"login lockout":           c.LoginLockout,
+		"password reset TTL":      c.PasswordResetTTL,
+		"email change TTL":        c.EmailChangeTTL,
+		"password reset cooldown": c.PasswordResetCooldown,
+		"password reset window":   c.PasswordResetWindow,
+		"login challenge TTL":     c.LoginChallengeTTL,
+	} {
+		if timeout <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", name))
+		}
 	}
 
-	Port = getEnv("PORT", "8080")
-	JwtSecret = getEnv("JWT_SECRET", "")
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
 }
 
-func getEnv(key, fallback string) string {
+// maintenanceCommands only touch the database and are validated with
+// ValidateDatabase instead of Validate.
+var maintenanceCommands = map[string]bool{"migrate": true, "promote-admin": true}
+
+// Load builds the config from profile defaults, an optional .env file, the
+// process environment and command-line flags, in increasing precedence.
+// It returns the arguments left after flag parsing. For the migrate and
+// promote-admin commands only the database settings are validated.
+func Load(args []string) (*Config, []string, error) {
+	flags := flag.NewFlagSet("workup_fitness", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	profile := flags.String("profile", "", "config profile: dev, test or prod (env APP_PROFILE)")
+	envFile := flags.String("env-file", ".env", "optional dotenv file to read")
+	port := flags.Int("port", 0, "HTTP port (env PORT)")
+	dsn := flags.String("db", "", "SQLite database DSN (env DATABASE_DSN)")
+	autoMigrate := flags.Bool("auto-migrate", false, "apply pending migrations at startup (env AUTO_MIGRATE)")
+	logLevel := flags.String("log-level", "", "minimum log level (env LOG_LEVEL)")
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	dotenv, err := godotenv.Read(*envFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("%w: reading %s: %w", ErrInvalidConfig, *envFile, err)
+	}
+	env := &source{dotenv: dotenv}
+
+	if *profile == "" {
+		*profile = env.string("APP_PROFILE", string(ProfileDev))
+	}
+	cfg := Defaults(Profile(*profile))
+
+	cfg.Port = env.int("PORT", cfg.Port)
+	cfg.DatabaseDSN = env.string("DATABASE_DSN", cfg.DatabaseDSN)
+	cfg.AutoMigrate = env.bool("AUTO_MIGRATE", cfg.AutoMigrate)
+	cfg.JWTSecret = env.string("JWT_SECRET", cfg.JWTSecret)
+	cfg.AccessTokenTTL = env.duration("ACCESS_TOKEN_TTL", cfg.AccessTokenTTL)
+	cfg.RefreshTokenTTL = env.duration("REFRESH_TOKEN_TTL", cfg.RefreshTokenTTL)
+	cfg.ReadTimeout = env.duration("HTTP_READ_TIMEOUT", cfg.ReadTimeout)
+	cfg.WriteTimeout = env.duration("HTTP_WRITE_TIMEOUT", cfg.WriteTimeout)
+	cfg.IdleTimeout = env.duration("HTTP_IDLE_TIMEOUT", cfg.IdleTimeout)
+	cfg.ShutdownTimeout = env.duration("SHUTDOWN_TIMEOUT", cfg.ShutdownTimeout)
+	cfg.LogLevel = env.string("LOG_LEVEL", cfg.LogLevel)
+	cfg.LogFormat = env.string("LOG_FORMAT", cfg.LogFormat)
+	cfg.LogRedactUsernames = env.bool("LOG_REDACT_USERNAMES", cfg.LogRedactUsernames)
+	cfg.TrustProxyHeaders = env.bool("TRUST_PROXY_HEADERS", cfg.TrustProxyHeaders)
+	cfg.LoginFreeAttempts = env.int("LOGIN_FREE_ATTEMPTS", cfg.LoginFreeAttempts)
+	cfg.LoginBackoffBase = env.duration("LOGIN_BACKOFF_BASE", cfg.LoginBackoffBase)
+	cfg.LoginBackoffMax = env.duration("LOGIN_BACKOFF_MAX", cfg.LoginBackoffMax)
+	cfg.LoginMaxFailures = env.int("LOGIN_LOCKOUT_THRESHOLD", cfg.LoginMaxFailures)
+	cfg.LoginIPMaxFailures = env.int("LOGIN_IP_LOCKOUT_THRESHOLD", cfg.LoginIPMaxFailures)
+	cfg.LoginLockout = env.duration("LOGIN_LOCKOUT_DURATION", cfg.LoginLockout)
+	cfg.PasswordMinLength = env.int("PASSWORD_MIN_LENGTH", cfg.PasswordMinLength)
+	cfg.BreachedPasswordsFile = env.string("BREACHED_PASSWORDS_FILE", cfg.BreachedPasswordsFile)
+	cfg.PasswordResetTTL = env.duration("PASSWORD_RESET_TTL", cfg.PasswordResetTTL)
+	cfg.PasswordResetURL = env.string("PASSWORD_RESET_URL", cfg.PasswordResetURL)
+	cfg.PasswordResetCooldown = env.duration("PASSWORD_RESET_COOLDOWN", cfg.PasswordResetCooldown)
+	cfg.PasswordResetMaxRequests = env.int("PASSWORD_RESET_MAX_REQUESTS", cfg.PasswordResetMaxRequests)
+	cfg.PasswordResetIPMaxRequests = env.int("PASSWORD_RESET_IP_MAX_REQUESTS", cfg.PasswordResetIPMaxRequests)
+	cfg.PasswordResetWindow = env.duration("PASSWORD_RESET_WINDOW", cfg.PasswordResetWindow)
+	cfg.EmailChangeTTL = env.duration("EMAIL_CHANGE_TTL", cfg.EmailChangeTTL)
+	cfg.EmailConfirmURL = env.string("EMAIL_CONFIRM_URL", cfg.EmailConfirmURL)
+	cfg.MailDriver = env.string("MAIL_DRIVER", cfg.MailDriver)
+	cfg.MailFrom = env.string("MAIL_FROM", cfg.MailFrom)
+	cfg.MailFile = env.string("MAIL_FILE", cfg.MailFile)
+	cfg.SMTPHost = env.string("SMTP_HOST", cfg.SMTPHost)
+	cfg.SMTPPort = env.int("SMTP_PORT", cfg.SMTPPort)
+	cfg.SMTPUsername = env.string("SMTP_USERNAME", cfg.SMTPUsername)
+	cfg.SMTPPassword = env.string("SMTP_PASSWORD", cfg.SMTPPassword)
+	cfg.TOTPIssuer = env.string("TOTP_ISSUER", cfg.TOTPIssuer)
+	cfg.LoginChallengeTTL = env.duration("LOGIN_CHALLENGE_TTL", cfg.LoginChallengeTTL)
+	cfg.E1RMFormula = env.string("E1RM_FORMULA", cfg.E1RMFormula)
+	if err := errors.Join(env.errs...); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.Port = *port
+		case "db":
+			cfg.DatabaseDSN = *dsn
+		case "auto-migrate":
+			cfg.AutoMigrate = *autoMigrate
+		case "log-level":
+			cfg.LogLevel = *logLevel
+		}
+	})
+
+	validate := cfg.Validate
+	if args := flags.Args(); len(args) > 0 && maintenanceCommands[args[0]] {
+		validate = cfg.ValidateDatabase
+	}
+	if err := validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, flags.Args(), nil
+}
+
+// source looks keys up in the process environment first and the dotenv
+// file second, collecting parse errors instead of failing on the first one.
+type source struct {
+	dotenv map[string]string
+	errs   []error
+}
+
+func (s *source) lookup(key string) (string, bool) {
 	if value, ok := os.LookupEnv(key); ok {
+		return value, true
+	}
+	value, ok := s.dotenv[key]
+	return value, ok
+}
+
+func (s *source) string(key, fallback string) string {
+	if value, ok := s.lookup(key); ok {
 		return value
 	}
 	return fallback
-}
\ No newline at end of file
+}
+
+func (s *source) int(key string, fallback int) int {
+	value, ok := s.lookup(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("invalid integer in %s: %w", key, err))
+		return fallback
+	}
+	return n
+}
+
+func (s *source) bool(key string, fallback bool) bool {
+	value, ok := s.lookup(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("invalid boolean in %s: %w", key, err))
+		return fallback
+	}
+	return b
+}
+
+func (s *source) duration(key string, fallback time.Duration) time.Duration {
+	value, ok := s.lookup(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("invalid duration in %s: %w", key, err))
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,138 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/config"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func writeEnvFile(t *testing.T, lines ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	envFile := writeEnvFile(t,
+		"JWT_SECRET="+testSecret,
+		"PORT=9000",
+		"DATABASE_DSN=from-dotenv.sqlite",
+		"ACCESS_TOKEN_TTL=5m",
+	)
+	t.Setenv("DATABASE_DSN", "from-env.sqlite")
+
+	cfg, args, err := config.Load([]string{"-env-file", envFile, "-port", "9100", "migrate", "up"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"migrate", "up"}, args)
+
+	require.Equal(t, config.ProfileDev, cfg.Profile)
+	require.Equal(t, 9100, cfg.Port)
+	require.Equal(t, "from-env.sqlite", cfg.DatabaseDSN)
+	require.Equal(t, 5*time.Minute, cfg.AccessTokenTTL)
+	require.Equal(t, testSecret, cfg.JWTSecret)
+}
+
+func TestLoad_MissingEnvFile(t *testing.T) {
+	t.Setenv("JWT_SECRET", testSecret)
+
+	cfg, _, err := config.Load([]string{"-env-file", filepath.Join(t.TempDir(), "missing.env")})
+	require.NoError(t, err)
+	require.Equal(t, 8080, cfg.Port)
+}
+
+func TestLoad_Profile(t *testing.T) {
+	t.Setenv("JWT_SECRET", testSecret)
+	t.Setenv("APP_PROFILE", "prod")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	noEnvFile := filepath.Join(t.TempDir(), "missing.env")
+
+	cfg, _, err := config.Load([]string{"-env-file", noEnvFile})
+	require.NoError(t, err)
+	require.Equal(t, config.ProfileProd, cfg.Profile)
+	require.False(t, cfg.AutoMigrate)
+	require.Equal(t, "smtp", cfg.MailDriver)
+
+	cfg, _, err = config.Load([]string{"-env-file", noEnvFile, "-profile", "test"})
+	require.NoError(t, err)
+	require.Equal(t, config.ProfileTest, cfg.Profile)
+	require.True(t, cfg.AutoMigrate)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	noEnvFile := filepath.Join(t.TempDir(), "missing.env")
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+	}{
+		{name: "empty secret", env: map[string]string{"JWT_SECRET": ""}},
+		{name: "short secret", env: map[string]string{"JWT_SECRET": "short"}},
+		{name: "port out of range", env: map[string]string{"JWT_SECRET": testSecret, "PORT": "70000"}},
+		{name: "port not a number", env: map[string]string{"JWT_SECRET": testSecret, "PORT": "http"}},
+		{name: "bad duration", env: map[string]string{"JWT_SECRET": testSecret, "ACCESS_TOKEN_TTL": "soon"}},
+		{name: "password min length too long", env: map[string]string{"JWT_SECRET": testSecret, "PASSWORD_MIN_LENGTH": "100"}},
+		{name: "unknown mail driver", env: map[string]string{"JWT_SECRET": testSecret, "MAIL_DRIVER": "pigeon"}},
+		{name: "unknown e1RM formula", env: map[string]string{"JWT_SECRET": testSecret, "E1RM_FORMULA": "guess"}},
+		{name: "smtp without host", env: map[string]string{"JWT_SECRET": testSecret, "MAIL_DRIVER": "smtp"}},
+		{name: "unknown profile", env: map[string]string{"JWT_SECRET": testSecret}, args: []string{"-profile", "staging"}},
+		{name: "unknown flag", env: map[string]string{"JWT_SECRET": testSecret}, args: []string{"-verbose"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			_, _, err := config.Load(append([]string{"-env-file", noEnvFile}, tt.args...))
+			require.ErrorIs(t, err, config.ErrInvalidConfig)
+		})
+	}
+}
+
+func TestLoad_MaintenanceCommandsSkipServerSettings(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("MAIL_DRIVER", "pigeon")
+	noEnvFile := filepath.Join(t.TempDir(), "missing.env")
+
+	for _, args := range [][]string{{"migrate", "up"}, {"promote-admin", "alice"}} {
+		_, rest, err := config.Load(append([]string{"-env-file", noEnvFile}, args...))
+		require.NoError(t, err)
+		require.Equal(t, args, rest)
+	}
+
+	_, _, err := config.Load([]string{"-env-file", noEnvFile, "-db", "", "migrate", "up"})
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+
+	_, _, err = config.Load([]string{"-env-file", noEnvFile})
+	require.ErrorIs(t, err, config.ErrInvalidConfig, "serving still needs the secret")
+}
+
+func TestLoad_Logging(t *testing.T) {
+	t.Setenv("JWT_SECRET", testSecret)
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	noEnvFile := filepath.Join(t.TempDir(), "missing.env")
+
+	cfg, _, err := config.Load([]string{"-env-file", noEnvFile, "-profile", "prod"})
+	require.NoError(t, err)
+	require.Equal(t, "json", cfg.LogFormat)
+	require.True(t, cfg.LogRedactUsernames)
+
+	cfg, _, err = config.Load([]string{"-env-file", noEnvFile, "-log-level", "error"})
+	require.NoError(t, err)
+	require.Equal(t, "error", cfg.LogLevel)
+
+	t.Setenv("LOG_FORMAT", "xml")
+	_, _, err = config.Load([]string{"-env-file", noEnvFile})
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+}
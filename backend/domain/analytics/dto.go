@@ -0,0 +1,46 @@
+package analytics
+
+type RecordResponse struct {
+	ID         int     `json:"id"`
+	ExerciseID int     `json:"exercise_id"`
+	SetID      int     `json:"set_id"`
+	Kind       Kind    `json:"kind"`
+	Value      float64 `json:"value"`
+	Weight     float64 `json:"weight"`
+	Reps       int     `json:"reps"`
+	AchievedAt string  `json:"achieved_at"`
+}
+
+type RecordsResponse struct {
+	Records []RecordResponse `json:"records"`
+}
+
+// BestsResponse lists standing records. Formula is the one best_e1rm
+// values were estimated with.
+type BestsResponse struct {
+	Formula Formula          `json:"formula"`
+	Bests   []RecordResponse `json:"bests"`
+}
+
+type E1RMPointResponse struct {
+	SessionID   int     `json:"session_id"`
+	PerformedAt string  `json:"performed_at"`
+	E1RM        float64 `json:"e1rm"`
+	Weight      float64 `json:"weight"`
+	Reps        int     `json:"reps"`
+}
+
+type E1RMResponse struct {
+	ExerciseID int                 `json:"exercise_id"`
+	Formula    Formula             `json:"formula"`
+	Points     []E1RMPointResponse `json:"points"`
+}
+
+type VolumeReportResponse struct {
+	TimeZone string      `json:"time_zone"`
+	From     string      `json:"from"`
+	To       string      `json:"to"`
+	GroupBy  GroupBy     `json:"group_by"`
+	Period   Period      `json:"period"`
+	Rows     []VolumeRow `json:"rows"`
+}
@@ -0,0 +1,42 @@
+package analytics
+
+import "math"
+
+// Formula estimates a one-rep max from a set of several reps.
+type Formula string
+
+const (
+	// Epley is weight × (1 + reps/30).
+	Epley Formula = "epley"
+	// Brzycki is weight × 36 / (37 - reps). It reads a little lower than
+	// Epley below ten reps and higher above.
+	Brzycki Formula = "brzycki"
+)
+
+// maxEstimateReps is the most reps an estimate is made from. Past it the
+// formulas say little about a single rep, and Brzycki breaks down at 37.
+const maxEstimateReps = 30
+
+func (f Formula) Valid() bool {
+	return f == Epley || f == Brzycki
+}
+
+// EstimateOneRepMax returns the estimated one-rep max of weight lifted for
+// reps, rounded to two decimals. It is 0 when no estimate can be made.
+func EstimateOneRepMax(formula Formula, weight float64, reps int) float64 {
+	if weight <= 0 || reps < 1 || reps > maxEstimateReps {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+	if formula == Brzycki {
+		return round(weight * 36 / (37 - float64(reps)))
+	}
+	return round(weight * (1 + float64(reps)/30))
+}
+
+// round keeps two decimals, well below the smallest plate.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,34 @@
+package analytics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/analytics"
+)
+
+func TestEstimateOneRepMax(t *testing.T) {
+	tests := []struct {
+		formula analytics.Formula
+		weight  float64
+		reps    int
+		want    float64
+	}{
+		{analytics.Epley, 100, 1, 100},
+		{analytics.Brzycki, 100, 1, 100},
+		{analytics.Epley, 100, 5, 116.67},
+		{analytics.Brzycki, 100, 5, 112.5},
+		{analytics.Epley, 100, 10, 133.33},
+		{analytics.Brzycki, 100, 10, 133.33},
+		{analytics.Epley, 60, 20, 100},
+		{analytics.Brzycki, 60, 20, 127.06},
+		{analytics.Epley, 100, 31, 0},
+		{analytics.Brzycki, 100, 0, 0},
+		{analytics.Epley, 0, 5, 0},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, analytics.EstimateOneRepMax(tt.formula, tt.weight, tt.reps), "%s %vx%d", tt.formula, tt.weight, tt.reps)
+	}
+}
@@ -0,0 +1,20 @@
+package analytics
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrInvalidFormula = errors.New("unknown e1RM formula")
+	ErrInvalidKind    = errors.New("unknown record kind")
+	ErrInvalidReport  = errors.New("invalid report")
+)
+
+func init() {
+	httpx.RegisterError(ErrInvalidFormula, http.StatusBadRequest, "invalid_formula")
+	httpx.RegisterError(ErrInvalidKind, http.StatusBadRequest, "invalid_record_kind")
+	httpx.RegisterError(ErrInvalidReport, http.StatusBadRequest, "invalid_report")
+}
@@ -0,0 +1,243 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating analytics handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created analytics handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+// parseExerciseID reads the optional exercise_id query parameter.
+func parseExerciseID(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("exercise_id")
+	if raw == "" {
+		return 0, nil
+	}
+	exerciseID, err := strconv.Atoi(raw)
+	if err != nil || exerciseID <= 0 {
+		return 0, errors.New("exercise_id must be a positive integer")
+	}
+	return exerciseID, nil
+}
+
+func toRecordResponses(records []*Record) []RecordResponse {
+	resp := make([]RecordResponse, 0, len(records))
+	for _, record := range records {
+		resp = append(resp, RecordResponse{
+			ID:         record.ID,
+			ExerciseID: record.ExerciseID,
+			SetID:      record.SetID,
+			Kind:       record.Kind,
+			Value:      record.Value,
+			Weight:     record.Weight,
+			Reps:       record.Reps,
+			AchievedAt: record.AchievedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return resp
+}
+
+// History lists personal records as they were set, optionally only those
+// of one exercise and kind.
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := parseExerciseID(r)
+	if err != nil {
+		httpx.BadRequest(w, err.Error())
+		return
+	}
+	filter := RecordFilter{ExerciseID: exerciseID, Kind: Kind(r.URL.Query().Get("kind"))}
+
+	records, err := h.service.History(ctx, userID, filter)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := RecordsResponse{Records: toRecordResponses(records)}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+// Bests returns the standing personal records, of one exercise or all.
+func (h *Handler) Bests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := parseExerciseID(r)
+	if err != nil {
+		httpx.BadRequest(w, err.Error())
+		return
+	}
+
+	bests, err := h.service.Bests(ctx, userID, exerciseID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := BestsResponse{Formula: h.service.Formula(), Bests: toRecordResponses(bests)}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+// E1RMHistory returns the best estimated one-rep max per session of an
+// exercise, with the formula picked by the formula query parameter.
+func (h *Handler) E1RMHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	formula := Formula(r.URL.Query().Get("formula"))
+	if formula == "" {
+		formula = h.service.Formula()
+	}
+
+	points, err := h.service.E1RMHistory(ctx, userID, exerciseID, formula)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := E1RMResponse{ExerciseID: exerciseID, Formula: formula, Points: make([]E1RMPointResponse, 0, len(points))}
+	for _, point := range points {
+		resp.Points = append(resp.Points, E1RMPointResponse{
+			SessionID:   point.SessionID,
+			PerformedAt: point.PerformedAt.UTC().Format(time.RFC3339),
+			E1RM:        point.E1RM,
+			Weight:      point.Weight,
+			Reps:        point.Reps,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+// Volume reports training volume per period. from and to are calendar
+// days in the user's time zone.
+func (h *Handler) Volume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := ReportFilter{GroupBy: GroupBy(query.Get("group_by")), Period: Period(query.Get("period"))}
+	for name, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		*dst, err = time.Parse(time.DateOnly, raw)
+		if err != nil {
+			httpx.BadRequest(w, name+" must be a date like 2026-01-31")
+			return
+		}
+	}
+
+	report, err := h.service.Volume(ctx, userID, filter)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := VolumeReportResponse{
+		TimeZone: report.TimeZone,
+		From:     report.From,
+		To:       report.To,
+		GroupBy:  report.GroupBy,
+		Period:   report.Period,
+		Rows:     report.Rows,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
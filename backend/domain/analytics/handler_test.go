@@ -0,0 +1,137 @@
+package analytics_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/analytics"
+	"workup_fitness/domain/analytics/mocks"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, exerciseID string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if exerciseID != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", exerciseID)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestHistory_Filter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := analytics.NewHandler(mockService)
+
+	mockService.EXPECT().
+		History(gomock.Any(), 1, analytics.RecordFilter{ExerciseID: 2, Kind: analytics.KindBestE1RM}).
+		Return([]*analytics.Record{{ID: 4, ExerciseID: 2, Kind: analytics.KindBestE1RM, Value: 116.67, Weight: 100, Reps: 5, AchievedAt: day}}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.History(rr, newAuthedRequest(http.MethodGet, "/analytics/records?exercise_id=2&kind=best_e1rm", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp analytics.RecordsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Records, 1)
+	require.Equal(t, "2026-03-01T18:00:00Z", resp.Records[0].AchievedAt)
+
+	rr = httptest.NewRecorder()
+	handler.History(rr, newAuthedRequest(http.MethodGet, "/analytics/records?exercise_id=squat", nil, 1, ""))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHistory_InvalidKind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := analytics.NewHandler(mockService)
+
+	mockService.EXPECT().
+		History(gomock.Any(), 1, analytics.RecordFilter{Kind: "fastest"}).
+		Return(nil, analytics.ErrInvalidKind)
+
+	rr := httptest.NewRecorder()
+	handler.History(rr, newAuthedRequest(http.MethodGet, "/analytics/records?kind=fastest", nil, 1, ""))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestBests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := analytics.NewHandler(mockService)
+
+	mockService.EXPECT().Bests(gomock.Any(), 1, 0).Return([]*analytics.Record{}, nil)
+	mockService.EXPECT().Formula().Return(analytics.Brzycki)
+
+	rr := httptest.NewRecorder()
+	handler.Bests(rr, newAuthedRequest(http.MethodGet, "/analytics/bests", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"formula":"brzycki","bests":[]}`, rr.Body.String())
+}
+
+func TestE1RMHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := analytics.NewHandler(mockService)
+
+	mockService.EXPECT().
+		E1RMHistory(gomock.Any(), 1, 2, analytics.Brzycki).
+		Return([]analytics.E1RMPoint{{SessionID: 7, PerformedAt: day, E1RM: 112.5, Weight: 100, Reps: 5}}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.E1RMHistory(rr, newAuthedRequest(http.MethodGet, "/analytics/exercises/2/e1rm?formula=brzycki", nil, 1, "2"))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"exercise_id":2,"formula":"brzycki","points":[
+		{"session_id":7,"performed_at":"2026-03-01T18:00:00Z","e1rm":112.5,"weight":100,"reps":5}
+	]}`, rr.Body.String())
+}
+
+func TestVolume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := analytics.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Volume(gomock.Any(), 1, analytics.ReportFilter{
+			From:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			GroupBy: analytics.GroupByExercise,
+			Period:  analytics.PeriodMonth,
+		}).
+		Return(&analytics.VolumeReport{
+			TimeZone: "Europe/Berlin",
+			From:     "2026-03-01",
+			To:       "2026-03-31",
+			GroupBy:  analytics.GroupByExercise,
+			Period:   analytics.PeriodMonth,
+			Rows:     []analytics.VolumeRow{{PeriodStart: "2026-03-01", ExerciseID: 2, ExerciseName: "Squat", Tonnage: 1500, Sets: 3, HardSets: 3, Sessions: 1}},
+		}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Volume(rr, newAuthedRequest(http.MethodGet, "/analytics/volume?from=2026-03-01&group_by=exercise&period=month", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"time_zone":"Europe/Berlin","from":"2026-03-01","to":"2026-03-31","group_by":"exercise","period":"month","rows":[
+		{"period_start":"2026-03-01","exercise_id":2,"exercise_name":"Squat","tonnage":1500,"sets":3,"hard_sets":3,"sessions":1}
+	]}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	handler.Volume(rr, newAuthedRequest(http.MethodGet, "/analytics/volume?to=03/31/2026", nil, 1, ""))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
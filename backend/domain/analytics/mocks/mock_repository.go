@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/analytics (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/analytics Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	analytics "workup_fitness/domain/analytics"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRecords mocks base method.
+func (m *MockRepository) CreateRecords(ctx context.Context, records []*analytics.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecords", ctx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecords indicates an expected call of CreateRecords.
+func (mr *MockRepositoryMockRecorder) CreateRecords(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecords", reflect.TypeOf((*MockRepository)(nil).CreateRecords), ctx, records)
+}
+
+// ListLifts mocks base method.
+func (m *MockRepository) ListLifts(ctx context.Context, userID, exerciseID int) ([]analytics.Lift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLifts", ctx, userID, exerciseID)
+	ret0, _ := ret[0].([]analytics.Lift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLifts indicates an expected call of ListLifts.
+func (mr *MockRepositoryMockRecorder) ListLifts(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLifts", reflect.TypeOf((*MockRepository)(nil).ListLifts), ctx, userID, exerciseID)
+}
+
+// ListRecords mocks base method.
+func (m *MockRepository) ListRecords(ctx context.Context, userID int, filter analytics.RecordFilter) ([]*analytics.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecords", ctx, userID, filter)
+	ret0, _ := ret[0].([]*analytics.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecords indicates an expected call of ListRecords.
+func (mr *MockRepositoryMockRecorder) ListRecords(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecords", reflect.TypeOf((*MockRepository)(nil).ListRecords), ctx, userID, filter)
+}
+
+// ListSets mocks base method.
+func (m *MockRepository) ListSets(ctx context.Context, userID int, from, to time.Time) ([]analytics.LoggedSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSets", ctx, userID, from, to)
+	ret0, _ := ret[0].([]analytics.LoggedSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSets indicates an expected call of ListSets.
+func (mr *MockRepositoryMockRecorder) ListSets(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSets", reflect.TypeOf((*MockRepository)(nil).ListSets), ctx, userID, from, to)
+}
+
+// ReplaceRecords mocks base method.
+func (m *MockRepository) ReplaceRecords(ctx context.Context, userID, exerciseID int, records []*analytics.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecords", ctx, userID, exerciseID, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecords indicates an expected call of ReplaceRecords.
+func (mr *MockRepositoryMockRecorder) ReplaceRecords(ctx, userID, exerciseID, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecords", reflect.TypeOf((*MockRepository)(nil).ReplaceRecords), ctx, userID, exerciseID, records)
+}
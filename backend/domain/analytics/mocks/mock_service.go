@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/analytics (interfaces: Service)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/analytics Service
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	analytics "workup_fitness/domain/analytics"
+	session "workup_fitness/domain/session"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Bests mocks base method.
+func (m *MockService) Bests(ctx context.Context, userID, exerciseID int) ([]*analytics.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bests", ctx, userID, exerciseID)
+	ret0, _ := ret[0].([]*analytics.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bests indicates an expected call of Bests.
+func (mr *MockServiceMockRecorder) Bests(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bests", reflect.TypeOf((*MockService)(nil).Bests), ctx, userID, exerciseID)
+}
+
+// E1RMHistory mocks base method.
+func (m *MockService) E1RMHistory(ctx context.Context, userID, exerciseID int, formula analytics.Formula) ([]analytics.E1RMPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "E1RMHistory", ctx, userID, exerciseID, formula)
+	ret0, _ := ret[0].([]analytics.E1RMPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// E1RMHistory indicates an expected call of E1RMHistory.
+func (mr *MockServiceMockRecorder) E1RMHistory(ctx, userID, exerciseID, formula any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "E1RMHistory", reflect.TypeOf((*MockService)(nil).E1RMHistory), ctx, userID, exerciseID, formula)
+}
+
+// Formula mocks base method.
+func (m *MockService) Formula() analytics.Formula {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Formula")
+	ret0, _ := ret[0].(analytics.Formula)
+	return ret0
+}
+
+// Formula indicates an expected call of Formula.
+func (mr *MockServiceMockRecorder) Formula() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Formula", reflect.TypeOf((*MockService)(nil).Formula))
+}
+
+// History mocks base method.
+func (m *MockService) History(ctx context.Context, userID int, filter analytics.RecordFilter) ([]*analytics.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, userID, filter)
+	ret0, _ := ret[0].([]*analytics.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockServiceMockRecorder) History(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockService)(nil).History), ctx, userID, filter)
+}
+
+// RebuildRecords mocks base method.
+func (m *MockService) RebuildRecords(ctx context.Context, userID, exerciseID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildRecords", ctx, userID, exerciseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildRecords indicates an expected call of RebuildRecords.
+func (mr *MockServiceMockRecorder) RebuildRecords(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildRecords", reflect.TypeOf((*MockService)(nil).RebuildRecords), ctx, userID, exerciseID)
+}
+
+// RecordSet mocks base method.
+func (m *MockService) RecordSet(ctx context.Context, userID int, set *session.Set) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSet", ctx, userID, set)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSet indicates an expected call of RecordSet.
+func (mr *MockServiceMockRecorder) RecordSet(ctx, userID, set any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSet", reflect.TypeOf((*MockService)(nil).RecordSet), ctx, userID, set)
+}
+
+// Volume mocks base method.
+func (m *MockService) Volume(ctx context.Context, userID int, filter analytics.ReportFilter) (*analytics.VolumeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Volume", ctx, userID, filter)
+	ret0, _ := ret[0].(*analytics.VolumeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Volume indicates an expected call of Volume.
+func (mr *MockServiceMockRecorder) Volume(ctx, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Volume", reflect.TypeOf((*MockService)(nil).Volume), ctx, userID, filter)
+}
@@ -0,0 +1,63 @@
+package analytics
+
+import "time"
+
+// Kind is the category a personal record is set in.
+type Kind string
+
+const (
+	KindHeaviestWeight Kind = "heaviest_weight"
+	KindBestE1RM       Kind = "best_e1rm"
+	// KindRepsAtWeight records the most reps done with a weight. Doing
+	// more reps with a heavier weight counts for the lighter ones too.
+	KindRepsAtWeight Kind = "best_reps_at_weight"
+	// KindBestVolume records the highest weight × reps of a single set.
+	KindBestVolume Kind = "best_volume"
+)
+
+func (k Kind) Valid() bool {
+	switch k {
+	case KindHeaviestWeight, KindBestE1RM, KindRepsAtWeight, KindBestVolume:
+		return true
+	}
+	return false
+}
+
+// Record is a personal record set by one set. Value is the weight, the
+// estimated one-rep max, the reps or the volume of the set, depending on
+// Kind.
+type Record struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	ExerciseID int       `json:"exercise_id"`
+	SetID      int       `json:"set_id"`
+	Kind       Kind      `json:"kind"`
+	Value      float64   `json:"value"`
+	Weight     float64   `json:"weight"`
+	Reps       int       `json:"reps"`
+	AchievedAt time.Time `json:"achieved_at"`
+}
+
+// RecordFilter narrows a record history. Zero values match everything.
+type RecordFilter struct {
+	ExerciseID int
+	Kind       Kind
+}
+
+// Lift is a completed set of a session, as far as records are concerned.
+type Lift struct {
+	SetID       int
+	SessionID   int
+	Weight      float64
+	Reps        int
+	PerformedAt time.Time
+}
+
+// E1RMPoint is the best estimated one-rep max of one session.
+type E1RMPoint struct {
+	SessionID   int
+	PerformedAt time.Time
+	E1RM        float64
+	Weight      float64
+	Reps        int
+}
@@ -0,0 +1,126 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+)
+
+// bests are the standing records of one exercise that a new lift has to
+// beat.
+type bests struct {
+	formula  Formula
+	heaviest float64
+	e1rm     float64
+	volume   float64
+	// reps holds the best reps per weight.
+	reps map[float64]int
+}
+
+// newBests rebuilds the standing records from the record history.
+func newBests(formula Formula, records []*Record) *bests {
+	b := &bests{formula: formula, reps: map[float64]int{}}
+	for _, record := range records {
+		switch record.Kind {
+		case KindHeaviestWeight:
+			b.heaviest = max(b.heaviest, record.Value)
+		case KindBestE1RM:
+			b.e1rm = max(b.e1rm, record.Value)
+		case KindBestVolume:
+			b.volume = max(b.volume, record.Value)
+		case KindRepsAtWeight:
+			b.reps[record.Weight] = max(b.reps[record.Weight], record.Reps)
+		}
+	}
+	return b
+}
+
+// repsFrom returns the most reps done with weight or more.
+func (b *bests) repsFrom(weight float64) int {
+	best := 0
+	for w, reps := range b.reps {
+		if w >= weight {
+			best = max(best, reps)
+		}
+	}
+	return best
+}
+
+// observe returns the records lift sets, in Kind order, and keeps them as
+// the new bests. Lifts must be observed in the order they were done.
+func (b *bests) observe(lift Lift) []*Record {
+	var records []*Record
+	add := func(kind Kind, value float64) {
+		records = append(records, &Record{
+			SetID:      lift.SetID,
+			Kind:       kind,
+			Value:      value,
+			Weight:     lift.Weight,
+			Reps:       lift.Reps,
+			AchievedAt: lift.PerformedAt,
+		})
+	}
+
+	if lift.Weight > b.heaviest {
+		b.heaviest = lift.Weight
+		add(KindHeaviestWeight, lift.Weight)
+	}
+	if e1rm := EstimateOneRepMax(b.formula, lift.Weight, lift.Reps); e1rm > b.e1rm {
+		b.e1rm = e1rm
+		add(KindBestE1RM, e1rm)
+	}
+	if lift.Reps > b.repsFrom(lift.Weight) {
+		b.reps[lift.Weight] = lift.Reps
+		add(KindRepsAtWeight, float64(lift.Reps))
+	}
+	if volume := round(lift.Weight * float64(lift.Reps)); volume > b.volume {
+		b.volume = volume
+		add(KindBestVolume, volume)
+	}
+	return records
+}
+
+var kindOrder = []Kind{KindHeaviestWeight, KindBestE1RM, KindRepsAtWeight, KindBestVolume}
+
+// currentBests picks the standing records out of a history sorted oldest
+// first: the latest record of each kind and exercise, and for reps at
+// weight every weight whose reps no heavier weight matches.
+func currentBests(history []*Record) []*Record {
+	type key struct {
+		exerciseID int
+		kind       Kind
+		weight     float64
+	}
+	latest := map[key]*Record{}
+	for _, record := range history {
+		k := key{exerciseID: record.ExerciseID, kind: record.Kind}
+		if record.Kind == KindRepsAtWeight {
+			k.weight = record.Weight
+		}
+		latest[k] = record
+	}
+
+	var result, reps []*Record
+	for _, record := range latest {
+		if record.Kind == KindRepsAtWeight {
+			reps = append(reps, record)
+		} else {
+			result = append(result, record)
+		}
+	}
+	for _, record := range reps {
+		beaten := slices.ContainsFunc(reps, func(other *Record) bool {
+			return other.ExerciseID == record.ExerciseID && other.Weight > record.Weight && other.Reps >= record.Reps
+		})
+		if !beaten {
+			result = append(result, record)
+		}
+	}
+	slices.SortFunc(result, func(a, b *Record) int {
+		return cmp.Or(
+			cmp.Compare(a.ExerciseID, b.ExerciseID),
+			cmp.Compare(slices.Index(kindOrder, a.Kind), slices.Index(kindOrder, b.Kind)),
+			cmp.Compare(b.Weight, a.Weight),
+		)
+	})
+	return result
+}
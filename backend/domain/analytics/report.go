@@ -0,0 +1,176 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/guregu/null/v6"
+
+	"workup_fitness/domain/exercise"
+)
+
+// GroupBy is what a volume report adds sets up by.
+type GroupBy string
+
+const (
+	GroupByExercise    GroupBy = "exercise"
+	GroupByMuscleGroup GroupBy = "muscle_group"
+)
+
+// Period is the length of the calendar buckets of a volume report. Weeks
+// start on Monday.
+type Period string
+
+const (
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+const (
+	// secondaryShare is the part of a set credited to the secondary
+	// muscles of an exercise.
+	secondaryShare = 0.5
+	// Sets at this RPE or harder, or with this many reps in reserve or
+	// fewer, are hard sets.
+	hardSetRPE = 7
+	hardSetRIR = 3
+)
+
+// ReportFilter selects the sets of a volume report. From and To are
+// calendar days, both included, in the user's time zone.
+type ReportFilter struct {
+	From    time.Time
+	To      time.Time
+	GroupBy GroupBy
+	Period  Period
+}
+
+// LoggedSet is a set as far as volume reports are concerned.
+type LoggedSet struct {
+	SessionID   int
+	ExerciseID  int
+	Weight      float64
+	Reps        int
+	RPE         null.Float
+	RIR         null.Int
+	Completed   bool
+	PerformedAt time.Time
+}
+
+// hard reports whether the set was close to failure. Failed sets are, and
+// sets without RPE or RIR count as working sets.
+func (s LoggedSet) hard() bool {
+	switch {
+	case !s.Completed:
+		return true
+	case s.RPE.Valid:
+		return s.RPE.Float64 >= hardSetRPE
+	case s.RIR.Valid:
+		return s.RIR.Int64 <= hardSetRIR
+	}
+	return true
+}
+
+// VolumeRow adds up the sets of one exercise or muscle group in one period.
+// Sets and HardSets are fractional for muscle groups, which get part of a
+// set when they are secondary. Sessions counts the sessions with any set.
+type VolumeRow struct {
+	PeriodStart  string               `json:"period_start"`
+	ExerciseID   int                  `json:"exercise_id,omitempty"`
+	ExerciseName string               `json:"exercise_name,omitempty"`
+	MuscleGroup  exercise.MuscleGroup `json:"muscle_group,omitempty"`
+	Tonnage      float64              `json:"tonnage"`
+	Sets         float64              `json:"sets"`
+	HardSets     float64              `json:"hard_sets"`
+	Sessions     int                  `json:"sessions"`
+}
+
+type VolumeReport struct {
+	TimeZone string
+	From     string
+	To       string
+	GroupBy  GroupBy
+	Period   Period
+	Rows     []VolumeRow
+}
+
+// periodStart returns the first day of the period t falls in, in loc.
+func periodStart(t time.Time, loc *time.Location, period Period) string {
+	y, m, d := t.In(loc).Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if period == PeriodMonth {
+		day = day.AddDate(0, 0, 1-d)
+	} else {
+		day = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day.Format(time.DateOnly)
+}
+
+// aggregateVolume buckets sets by period and exercise or muscle group.
+// Sets of exercises without muscle group tags are left out of muscle group
+// reports.
+func aggregateVolume(sets []LoggedSet, exercises map[int]*exercise.Exercise, loc *time.Location, groupBy GroupBy, period Period) []VolumeRow {
+	type key struct {
+		period      string
+		exerciseID  int
+		muscleGroup exercise.MuscleGroup
+	}
+	type bucket struct {
+		row      VolumeRow
+		sessions map[int]bool
+	}
+	buckets := map[key]*bucket{}
+	add := func(k key, set LoggedSet, share float64) {
+		b, ok := buckets[k]
+		if !ok {
+			b = &bucket{row: VolumeRow{PeriodStart: k.period, ExerciseID: k.exerciseID, MuscleGroup: k.muscleGroup}, sessions: map[int]bool{}}
+			buckets[k] = b
+		}
+		b.row.Tonnage += set.Weight * float64(set.Reps) * share
+		b.row.Sets += share
+		if set.hard() {
+			b.row.HardSets += share
+		}
+		b.sessions[set.SessionID] = true
+	}
+
+	for _, set := range sets {
+		start := periodStart(set.PerformedAt, loc, period)
+		if groupBy == GroupByExercise {
+			add(key{period: start, exerciseID: set.ExerciseID}, set, 1)
+			continue
+		}
+		found, ok := exercises[set.ExerciseID]
+		if !ok {
+			continue
+		}
+		for _, group := range found.Muscles.Primary {
+			add(key{period: start, muscleGroup: group}, set, 1)
+		}
+		for _, group := range found.Muscles.Secondary {
+			add(key{period: start, muscleGroup: group}, set, secondaryShare)
+		}
+	}
+
+	rows := make([]VolumeRow, 0, len(buckets))
+	for _, b := range buckets {
+		row := b.row
+		row.Tonnage = round(row.Tonnage)
+		row.Sets = round(row.Sets)
+		row.HardSets = round(row.HardSets)
+		row.Sessions = len(b.sessions)
+		if found, ok := exercises[row.ExerciseID]; ok && groupBy == GroupByExercise {
+			row.ExerciseName = found.Name.String
+		}
+		rows = append(rows, row)
+	}
+	slices.SortFunc(rows, func(a, b VolumeRow) int {
+		return cmp.Or(
+			cmp.Compare(a.PeriodStart, b.PeriodStart),
+			cmp.Compare(a.ExerciseID, b.ExerciseID),
+			cmp.Compare(slices.Index(exercise.MuscleGroups, a.MuscleGroup), slices.Index(exercise.MuscleGroups, b.MuscleGroup)),
+		)
+	})
+	return rows
+}
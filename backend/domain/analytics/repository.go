@@ -0,0 +1,183 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/analytics Repository
+
+type Repository interface {
+	ListLifts(ctx context.Context, userID, exerciseID int) ([]Lift, error)
+	ListRecords(ctx context.Context, userID int, filter RecordFilter) ([]*Record, error)
+	CreateRecords(ctx context.Context, records []*Record) error
+	ReplaceRecords(ctx context.Context, userID, exerciseID int, records []*Record) error
+	ListSets(ctx context.Context, userID int, from, to time.Time) ([]LoggedSet, error)
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+const selectRecord = `SELECT id, user_id, exercise_id, set_id, kind, value, weight, reps, achieved_at
+	FROM personal_records`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecord(row scanner) (*Record, error) {
+	var record Record
+	err := row.Scan(&record.ID, &record.UserID, &record.ExerciseID, &record.SetID, &record.Kind, &record.Value,
+		&record.Weight, &record.Reps, &record.AchievedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertRecords(ctx context.Context, db execer, records []*Record) error {
+	for _, record := range records {
+		res, err := db.ExecContext(ctx,
+			`INSERT INTO personal_records (user_id, exercise_id, set_id, kind, value, weight, reps, achieved_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			record.UserID, record.ExerciseID, record.SetID, record.Kind, record.Value, record.Weight, record.Reps,
+			record.AchievedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		record.ID = int(id)
+	}
+	return nil
+}
+
+// ListLifts returns the completed sets of userID for exerciseID across all
+// sessions, in the order they were done.
+func (repo *sqliteRepository) ListLifts(ctx context.Context, userID, exerciseID int) ([]Lift, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT s.id, s.session_id, s.weight, s.reps, s.performed_at FROM session_sets s
+			JOIN workout_sessions ws ON ws.id = s.session_id
+			WHERE ws.user_id = ? AND s.exercise_id = ? AND s.completed = 1 AND s.reps > 0
+			ORDER BY s.performed_at, s.id`,
+		userID, exerciseID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lifts := make([]Lift, 0)
+	for rows.Next() {
+		var lift Lift
+		if err := rows.Scan(&lift.SetID, &lift.SessionID, &lift.Weight, &lift.Reps, &lift.PerformedAt); err != nil {
+			return nil, err
+		}
+		lifts = append(lifts, lift)
+	}
+	return lifts, rows.Err()
+}
+
+// ListRecords returns the records of userID matching filter, oldest first.
+func (repo *sqliteRepository) ListRecords(ctx context.Context, userID int, filter RecordFilter) ([]*Record, error) {
+	query := selectRecord + ` WHERE user_id = ?`
+	args := []any{userID}
+	if filter.ExerciseID != 0 {
+		query += ` AND exercise_id = ?`
+		args = append(args, filter.ExerciseID)
+	}
+	if filter.Kind != "" {
+		query += ` AND kind = ?`
+		args = append(args, filter.Kind)
+	}
+	query += ` ORDER BY achieved_at, id`
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*Record, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func (repo *sqliteRepository) CreateRecords(ctx context.Context, records []*Record) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertRecords(ctx, tx, records); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReplaceRecords swaps the whole record history of userID for exerciseID
+// with records.
+func (repo *sqliteRepository) ReplaceRecords(ctx context.Context, userID, exerciseID int, records []*Record) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM personal_records WHERE user_id = ? AND exercise_id = ?`, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+	if err := insertRecords(ctx, tx, records); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListSets returns every set userID logged in [from, to), oldest first.
+func (repo *sqliteRepository) ListSets(ctx context.Context, userID int, from, to time.Time) ([]LoggedSet, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT s.session_id, s.exercise_id, s.weight, s.reps, s.rpe, s.rir, s.completed, s.performed_at
+			FROM session_sets s JOIN workout_sessions ws ON ws.id = s.session_id
+			WHERE ws.user_id = ? AND s.performed_at >= ? AND s.performed_at < ?
+			ORDER BY s.performed_at, s.id`,
+		userID, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sets := make([]LoggedSet, 0)
+	for rows.Next() {
+		var set LoggedSet
+		err := rows.Scan(&set.SessionID, &set.ExerciseID, &set.Weight, &set.Reps, &set.RPE, &set.RIR, &set.Completed,
+			&set.PerformedAt)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	return sets, rows.Err()
+}
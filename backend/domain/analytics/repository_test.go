@@ -0,0 +1,130 @@
+package analytics_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/analytics"
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (analytics.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := analytics.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO exercises (name) VALUES ('Squat'), ('Bench press')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_ListLifts(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	day := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	_, err := db.Exec(`INSERT INTO workout_sessions (user_id, started_at, finished_at) VALUES (1, ?, ?), (2, ?, NULL), (1, ?, NULL)`,
+		day, day.Add(time.Hour), day, day.Add(48*time.Hour))
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO session_sets (session_id, exercise_id, set_index, weight, reps, completed, performed_at) VALUES
+		(3, 1, 1, 110, 3, 1, ?),
+		(1, 1, 1, 100, 5, 1, ?),
+		(1, 1, 2, 105, 2, 0, ?),
+		(1, 2, 1, 80, 8, 1, ?),
+		(2, 1, 1, 200, 1, 1, ?)`,
+		day.Add(48*time.Hour), day, day.Add(5*time.Minute), day.Add(10*time.Minute), day)
+	require.NoError(t, err)
+
+	lifts, err := repo.ListLifts(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, lifts, 2, "failed sets, other exercises and other users are left out")
+	require.Equal(t, 1, lifts[0].SessionID)
+	require.Equal(t, 100.0, lifts[0].Weight)
+	require.Equal(t, 3, lifts[1].SessionID)
+	require.True(t, lifts[1].PerformedAt.Equal(day.Add(48*time.Hour)))
+}
+
+func TestRepository_Records(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	day := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	_, err := db.Exec(`INSERT INTO workout_sessions (user_id, started_at, finished_at) VALUES (1, ?, ?), (2, ?, ?)`,
+		day, day.Add(time.Hour), day, day.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO session_sets (session_id, exercise_id, set_index, weight, reps, completed, performed_at) VALUES
+		(1, 1, 1, 100, 5, 1, ?),
+		(1, 2, 1, 80, 8, 1, ?),
+		(2, 1, 1, 200, 1, 1, ?),
+		(1, 1, 2, 90, 5, 1, ?)`,
+		day, day, day, day)
+	require.NoError(t, err)
+
+	first := &analytics.Record{UserID: 1, ExerciseID: 1, SetID: 1, Kind: analytics.KindHeaviestWeight, Value: 100, Weight: 100, Reps: 5, AchievedAt: day}
+	require.NoError(t, repo.CreateRecords(ctx, []*analytics.Record{
+		first,
+		{UserID: 1, ExerciseID: 1, SetID: 1, Kind: analytics.KindBestVolume, Value: 500, Weight: 100, Reps: 5, AchievedAt: day},
+		{UserID: 1, ExerciseID: 2, SetID: 2, Kind: analytics.KindHeaviestWeight, Value: 80, Weight: 80, Reps: 8, AchievedAt: day},
+		{UserID: 2, ExerciseID: 1, SetID: 3, Kind: analytics.KindHeaviestWeight, Value: 200, Weight: 200, Reps: 1, AchievedAt: day},
+	}))
+	require.NotZero(t, first.ID)
+
+	records, err := repo.ListRecords(ctx, 1, analytics.RecordFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	records, err = repo.ListRecords(ctx, 1, analytics.RecordFilter{ExerciseID: 1, Kind: analytics.KindHeaviestWeight})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, 100.0, records[0].Value)
+
+	require.NoError(t, repo.ReplaceRecords(ctx, 1, 1, []*analytics.Record{
+		{UserID: 1, ExerciseID: 1, SetID: 4, Kind: analytics.KindHeaviestWeight, Value: 90, Weight: 90, Reps: 5, AchievedAt: day},
+	}))
+
+	records, err = repo.ListRecords(ctx, 1, analytics.RecordFilter{ExerciseID: 1})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, 4, records[0].SetID)
+
+	records, err = repo.ListRecords(ctx, 2, analytics.RecordFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1, "other users keep their records")
+	records, err = repo.ListRecords(ctx, 1, analytics.RecordFilter{ExerciseID: 2})
+	require.NoError(t, err)
+	require.Len(t, records, 1, "other exercises keep their records")
+}
+
+func TestRepository_ListSets(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	day := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	_, err := db.Exec(`INSERT INTO workout_sessions (user_id, started_at, finished_at) VALUES (1, ?, ?), (2, ?, NULL)`,
+		day, day.Add(time.Hour), day)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO session_sets (session_id, exercise_id, set_index, weight, reps, rpe, completed, performed_at) VALUES
+		(1, 1, 1, 100, 5, 8, 1, ?),
+		(1, 1, 2, 105, 2, NULL, 0, ?),
+		(1, 2, 1, 80, 8, NULL, 1, ?),
+		(2, 1, 1, 200, 1, NULL, 1, ?)`,
+		day, day.Add(5*time.Minute), day.Add(10*time.Minute), day)
+	require.NoError(t, err)
+
+	sets, err := repo.ListSets(ctx, 1, day, day.Add(10*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, sets, 2, "the range excludes its end, and other users are left out")
+	require.Equal(t, 8.0, sets[0].RPE.Float64)
+	require.True(t, sets[0].Completed)
+	require.False(t, sets[1].RPE.Valid)
+	require.False(t, sets[1].Completed)
+}
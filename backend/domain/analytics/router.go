@@ -0,0 +1,20 @@
+package analytics
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("workouts"))
+		r.Get("/analytics/records", h.History)
+		r.Get("/analytics/bests", h.Bests)
+		r.Get("/analytics/exercises/{id}/e1rm", h.E1RMHistory)
+		r.Get("/analytics/volume", h.Volume)
+	})
+}
@@ -0,0 +1,253 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/session"
+	"workup_fitness/domain/user"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/analytics Service
+
+const (
+	defaultReportDays = 12 * 7
+	maxReportDays     = 3 * 366
+)
+
+type ExerciseService interface {
+	List(ctx context.Context) ([]*exercise.Exercise, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type Service interface {
+	// RecordSet checks a newly logged set for personal records, stores
+	// them and returns their kinds.
+	RecordSet(ctx context.Context, userID int, set *session.Set) ([]string, error)
+	// RebuildRecords recomputes the record history of an exercise from
+	// its sets, after a set was corrected or deleted.
+	RebuildRecords(ctx context.Context, userID, exerciseID int) error
+	History(ctx context.Context, userID int, filter RecordFilter) ([]*Record, error)
+	Bests(ctx context.Context, userID, exerciseID int) ([]*Record, error)
+	E1RMHistory(ctx context.Context, userID, exerciseID int, formula Formula) ([]E1RMPoint, error)
+	Formula() Formula
+	Volume(ctx context.Context, userID int, filter ReportFilter) (*VolumeReport, error)
+}
+
+type serviceImpl struct {
+	repo            Repository
+	exerciseService ExerciseService
+	userService     UserService
+	formula         Formula
+	now             func() time.Time
+}
+
+// NewService tracks e1RM records with formula, Epley if it is empty.
+func NewService(repo Repository, exerciseService ExerciseService, userService UserService, formula Formula) *serviceImpl {
+	logger.Default().Debug().Msg("Creating analytics service...")
+	if formula == "" {
+		formula = Epley
+	}
+	res := &serviceImpl{
+		repo:            repo,
+		exerciseService: exerciseService,
+		userService:     userService,
+		formula:         formula,
+		now:             time.Now,
+	}
+	logger.Default().Debug().Msg("Created analytics service")
+	return res
+}
+
+func (s *serviceImpl) Formula() Formula {
+	return s.formula
+}
+
+func (s *serviceImpl) RecordSet(ctx context.Context, userID int, set *session.Set) ([]string, error) {
+	if !set.Completed || set.Reps < 1 {
+		return nil, nil
+	}
+
+	history, err := s.repo.ListRecords(ctx, userID, RecordFilter{ExerciseID: set.ExerciseID})
+	if err != nil {
+		return nil, err
+	}
+	records := newBests(s.formula, history).observe(Lift{
+		SetID:       set.ID,
+		SessionID:   set.SessionID,
+		Weight:      set.Weight,
+		Reps:        set.Reps,
+		PerformedAt: set.PerformedAt,
+	})
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	kinds := make([]string, len(records))
+	for i, record := range records {
+		record.UserID = userID
+		record.ExerciseID = set.ExerciseID
+		kinds[i] = string(record.Kind)
+	}
+	if err := s.repo.CreateRecords(ctx, records); err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("set_id", set.ID).Strs("kinds", kinds).Msg("Set personal records")
+	return kinds, nil
+}
+
+func (s *serviceImpl) RebuildRecords(ctx context.Context, userID, exerciseID int) error {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Rebuilding personal records")
+
+	lifts, err := s.repo.ListLifts(ctx, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+	b := newBests(s.formula, nil)
+	records := make([]*Record, 0)
+	for _, lift := range lifts {
+		for _, record := range b.observe(lift) {
+			record.UserID = userID
+			record.ExerciseID = exerciseID
+			records = append(records, record)
+		}
+	}
+	if err := s.repo.ReplaceRecords(ctx, userID, exerciseID, records); err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Int("count", len(records)).Msg("Rebuilt personal records")
+	return nil
+}
+
+func (s *serviceImpl) History(ctx context.Context, userID int, filter RecordFilter) ([]*Record, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", filter.ExerciseID).Msg("Listing personal records")
+	if filter.Kind != "" && !filter.Kind.Valid() {
+		return nil, ErrInvalidKind
+	}
+	records, err := s.repo.ListRecords(ctx, userID, filter)
+	logger.Ctx(ctx).Info().Int("count", len(records)).Int("user_id", userID).Msg("Listed personal records")
+	return records, err
+}
+
+// Bests returns the standing records of userID for exerciseID, or for all
+// exercises if exerciseID is 0.
+func (s *serviceImpl) Bests(ctx context.Context, userID, exerciseID int) ([]*Record, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Getting current bests")
+	history, err := s.repo.ListRecords(ctx, userID, RecordFilter{ExerciseID: exerciseID})
+	if err != nil {
+		return nil, err
+	}
+	bests := currentBests(history)
+	logger.Ctx(ctx).Info().Int("count", len(bests)).Int("user_id", userID).Msg("Got current bests")
+	return bests, nil
+}
+
+// E1RMHistory returns the best estimated one-rep max of every session in
+// which userID did exerciseID, oldest first. An empty formula means the
+// one records are tracked with.
+func (s *serviceImpl) E1RMHistory(ctx context.Context, userID, exerciseID int, formula Formula) ([]E1RMPoint, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Getting e1RM history")
+	if formula == "" {
+		formula = s.formula
+	}
+	if !formula.Valid() {
+		return nil, ErrInvalidFormula
+	}
+
+	lifts, err := s.repo.ListLifts(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]E1RMPoint, 0)
+	for _, lift := range lifts {
+		e1rm := EstimateOneRepMax(formula, lift.Weight, lift.Reps)
+		if e1rm == 0 {
+			continue
+		}
+		point := E1RMPoint{SessionID: lift.SessionID, PerformedAt: lift.PerformedAt, E1RM: e1rm, Weight: lift.Weight, Reps: lift.Reps}
+		// Lifts come in order, and sessions do not overlap.
+		last := len(points) - 1
+		switch {
+		case last < 0 || points[last].SessionID != lift.SessionID:
+			points = append(points, point)
+		case e1rm > points[last].E1RM:
+			points[last] = point
+		}
+	}
+
+	logger.Ctx(ctx).Info().Int("count", len(points)).Int("user_id", userID).Msg("Got e1RM history")
+	return points, nil
+}
+
+// Volume reports tonnage, sets, hard sets and training frequency per week
+// or month, bucketed by the calendar days of the user's time zone. It
+// covers the last twelve weeks unless filter says otherwise.
+func (s *serviceImpl) Volume(ctx context.Context, userID int, filter ReportFilter) (*VolumeReport, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Computing volume report")
+
+	if filter.GroupBy == "" {
+		filter.GroupBy = GroupByMuscleGroup
+	}
+	if filter.Period == "" {
+		filter.Period = PeriodWeek
+	}
+	if filter.GroupBy != GroupByExercise && filter.GroupBy != GroupByMuscleGroup {
+		return nil, fmt.Errorf("%w: group_by must be exercise or muscle_group", ErrInvalidReport)
+	}
+	if filter.Period != PeriodWeek && filter.Period != PeriodMonth {
+		return nil, fmt.Errorf("%w: period must be week or month", ErrInvalidReport)
+	}
+
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := found.Location()
+
+	if filter.To.IsZero() {
+		filter.To = s.now().In(loc)
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, 1-defaultReportDays)
+	}
+	from := time.Date(filter.From.Year(), filter.From.Month(), filter.From.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(filter.To.Year(), filter.To.Month(), filter.To.Day()+1, 0, 0, 0, 0, loc)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidReport)
+	}
+	if to.Sub(from) > maxReportDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: reports cover at most %d days", ErrInvalidReport, maxReportDays)
+	}
+
+	sets, err := s.repo.ListSets(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := s.exerciseService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*exercise.Exercise, len(exercises))
+	for _, e := range exercises {
+		byID[e.ID] = e
+	}
+
+	report := &VolumeReport{
+		TimeZone: loc.String(),
+		From:     from.Format(time.DateOnly),
+		To:       to.AddDate(0, 0, -1).Format(time.DateOnly),
+		GroupBy:  filter.GroupBy,
+		Period:   filter.Period,
+		Rows:     aggregateVolume(sets, byID, loc, filter.GroupBy, filter.Period),
+	}
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("sets", len(sets)).Int("rows", len(report.Rows)).Msg("Computed volume report")
+	return report, nil
+}
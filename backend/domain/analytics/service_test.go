@@ -0,0 +1,273 @@
+package analytics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/analytics"
+	"workup_fitness/domain/analytics/mocks"
+	"workup_fitness/domain/exercise"
+	exerciseMocks "workup_fitness/domain/exercise/mocks"
+	"workup_fitness/domain/session"
+	"workup_fitness/domain/user"
+	userMocks "workup_fitness/domain/user/mocks"
+)
+
+type testService struct {
+	analytics.Service
+	repo            *mocks.MockRepository
+	exerciseService *exerciseMocks.MockService
+	userService     *userMocks.MockService
+}
+
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	exerciseService := exerciseMocks.NewMockService(ctrl)
+	userService := userMocks.NewMockService(ctrl)
+	return testService{
+		Service:         analytics.NewService(repo, exerciseService, userService, analytics.Epley),
+		repo:            repo,
+		exerciseService: exerciseService,
+		userService:     userService,
+	}
+}
+
+var day = time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+
+func TestService_RecordSet(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	// The standing records after a single 100 kg × 5.
+	history := []*analytics.Record{
+		{Kind: analytics.KindHeaviestWeight, Value: 100, Weight: 100, Reps: 5},
+		{Kind: analytics.KindBestE1RM, Value: 116.67, Weight: 100, Reps: 5},
+		{Kind: analytics.KindRepsAtWeight, Value: 5, Weight: 100, Reps: 5},
+		{Kind: analytics.KindBestVolume, Value: 500, Weight: 100, Reps: 5},
+	}
+	svc.repo.EXPECT().
+		ListRecords(ctx, 3, analytics.RecordFilter{ExerciseID: 1}).
+		Return(history, nil).
+		Times(2)
+
+	svc.repo.EXPECT().
+		CreateRecords(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, records []*analytics.Record) error {
+			require.Len(t, records, 2)
+			require.Equal(t, &analytics.Record{
+				UserID: 3, ExerciseID: 1, SetID: 21, Kind: analytics.KindRepsAtWeight,
+				Value: 8, Weight: 90, Reps: 8, AchievedAt: day,
+			}, records[0])
+			require.Equal(t, 720.0, records[1].Value)
+			return nil
+		})
+
+	kinds, err := svc.RecordSet(ctx, 3, &session.Set{ID: 21, ExerciseID: 1, Weight: 90, Reps: 8, Completed: true, PerformedAt: day})
+	require.NoError(t, err)
+	require.Equal(t, []string{"best_reps_at_weight", "best_volume"}, kinds)
+
+	kinds, err = svc.RecordSet(ctx, 3, &session.Set{ID: 22, ExerciseID: 1, Weight: 90, Reps: 5, Completed: true, PerformedAt: day})
+	require.NoError(t, err)
+	require.Empty(t, kinds)
+
+	kinds, err = svc.RecordSet(ctx, 3, &session.Set{ID: 23, ExerciseID: 1, Weight: 120, Reps: 4, Completed: false, PerformedAt: day})
+	require.NoError(t, err)
+	require.Empty(t, kinds, "failed sets do not count")
+}
+
+func TestService_RebuildAndBests(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		ListLifts(ctx, 3, 1).
+		Return([]analytics.Lift{
+			{SetID: 1, SessionID: 1, Weight: 100, Reps: 5, PerformedAt: day},
+			{SetID: 2, SessionID: 2, Weight: 110, Reps: 3, PerformedAt: day.AddDate(0, 0, 2)},
+			{SetID: 3, SessionID: 3, Weight: 100, Reps: 6, PerformedAt: day.AddDate(0, 0, 4)},
+		}, nil)
+
+	var rebuilt []*analytics.Record
+	svc.repo.EXPECT().
+		ReplaceRecords(ctx, 3, 1, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ int, records []*analytics.Record) error {
+			rebuilt = records
+			return nil
+		})
+
+	require.NoError(t, svc.RebuildRecords(ctx, 3, 1))
+	// 100×5 sets all four, 110×3 beats weight, e1RM and has the most reps
+	// at 110, and 100×6 beats reps at 100 and volume.
+	require.Len(t, rebuilt, 9)
+
+	svc.repo.EXPECT().
+		ListRecords(ctx, 3, analytics.RecordFilter{ExerciseID: 1}).
+		Return(rebuilt, nil)
+
+	bests, err := svc.Bests(ctx, 3, 1)
+	require.NoError(t, err)
+
+	type best struct {
+		kind  analytics.Kind
+		value float64
+		setID int
+	}
+	got := make([]best, len(bests))
+	for i, b := range bests {
+		got[i] = best{b.Kind, b.Value, b.SetID}
+	}
+	require.Equal(t, []best{
+		{analytics.KindHeaviestWeight, 110, 2},
+		{analytics.KindBestE1RM, 121, 2},
+		{analytics.KindRepsAtWeight, 3, 2},
+		{analytics.KindRepsAtWeight, 6, 3},
+		{analytics.KindBestVolume, 600, 3},
+	}, got)
+}
+
+func TestService_Bests_DropsBeatenReps(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		ListRecords(ctx, 3, analytics.RecordFilter{}).
+		Return([]*analytics.Record{
+			{ExerciseID: 1, SetID: 1, Kind: analytics.KindRepsAtWeight, Value: 5, Weight: 100, Reps: 5},
+			{ExerciseID: 2, SetID: 2, Kind: analytics.KindRepsAtWeight, Value: 8, Weight: 60, Reps: 8},
+			{ExerciseID: 1, SetID: 3, Kind: analytics.KindRepsAtWeight, Value: 5, Weight: 105, Reps: 5},
+		}, nil)
+
+	bests, err := svc.Bests(ctx, 3, 0)
+	require.NoError(t, err)
+	require.Len(t, bests, 2)
+	require.Equal(t, 3, bests[0].SetID, "105×5 also counts as 100×5")
+	require.Equal(t, 2, bests[1].SetID)
+}
+
+func TestService_E1RMHistory(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		ListLifts(ctx, 3, 1).
+		Return([]analytics.Lift{
+			{SetID: 1, SessionID: 1, Weight: 100, Reps: 5, PerformedAt: day},
+			{SetID: 2, SessionID: 1, Weight: 100, Reps: 10, PerformedAt: day.Add(5 * time.Minute)},
+			{SetID: 3, SessionID: 1, Weight: 90, Reps: 8, PerformedAt: day.Add(10 * time.Minute)},
+			{SetID: 4, SessionID: 2, Weight: 120, Reps: 1, PerformedAt: day.AddDate(0, 0, 3)},
+		}, nil).
+		Times(2)
+
+	points, err := svc.E1RMHistory(ctx, 3, 1, analytics.Brzycki)
+	require.NoError(t, err)
+	require.Equal(t, []analytics.E1RMPoint{
+		{SessionID: 1, PerformedAt: day.Add(5 * time.Minute), E1RM: 133.33, Weight: 100, Reps: 10},
+		{SessionID: 2, PerformedAt: day.AddDate(0, 0, 3), E1RM: 120, Weight: 120, Reps: 1},
+	}, points)
+
+	points, err = svc.E1RMHistory(ctx, 3, 1, "")
+	require.NoError(t, err)
+	require.Equal(t, 133.33, points[0].E1RM)
+
+	_, err = svc.E1RMHistory(ctx, 3, 1, "lombardi")
+	require.ErrorIs(t, err, analytics.ErrInvalidFormula)
+}
+
+func TestService_Volume(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	svc.userService.EXPECT().
+		GetByID(ctx, 3).
+		Return(&user.User{ID: 3, TimeZone: zero.StringFrom("America/New_York")}, nil).
+		Times(2)
+	svc.exerciseService.EXPECT().
+		List(ctx).
+		Return([]*exercise.Exercise{
+			{ID: 1, Name: zero.StringFrom("Squat"), Muscles: exercise.Muscles{
+				Primary:   []exercise.MuscleGroup{exercise.MuscleGlutes, exercise.MuscleQuads},
+				Secondary: []exercise.MuscleGroup{exercise.MuscleAdductors},
+			}},
+			{ID: 2, Name: zero.StringFrom("Bench press"), Muscles: exercise.Muscles{
+				Primary:   []exercise.MuscleGroup{exercise.MuscleChest},
+				Secondary: []exercise.MuscleGroup{exercise.MuscleTriceps},
+			}},
+			{ID: 3, Name: zero.StringFrom("Farmer carry")},
+		}, nil).
+		Times(2)
+
+	sets := []analytics.LoggedSet{
+		{SessionID: 1, ExerciseID: 1, Weight: 100, Reps: 5, RPE: null.FloatFrom(8), Completed: true, PerformedAt: time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)},
+		{SessionID: 1, ExerciseID: 1, Weight: 100, Reps: 5, RPE: null.FloatFrom(6), Completed: true, PerformedAt: time.Date(2026, 3, 2, 23, 40, 0, 0, time.UTC)},
+		// Sunday night in New York, but already Monday in UTC.
+		{SessionID: 2, ExerciseID: 2, Weight: 80, Reps: 8, Completed: true, PerformedAt: time.Date(2026, 3, 9, 3, 0, 0, 0, time.UTC)},
+		{SessionID: 3, ExerciseID: 1, Weight: 110, Reps: 3, Completed: false, PerformedAt: time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC)},
+		{SessionID: 3, ExerciseID: 3, Weight: 20, Reps: 10, RIR: null.IntFrom(5), Completed: true, PerformedAt: time.Date(2026, 3, 10, 22, 30, 0, 0, time.UTC)},
+	}
+	svc.repo.EXPECT().
+		ListSets(ctx, 3, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, from, to time.Time) ([]analytics.LoggedSet, error) {
+			require.True(t, from.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, newYork)))
+			require.True(t, to.Equal(time.Date(2026, 3, 16, 0, 0, 0, 0, newYork)))
+			return sets, nil
+		}).
+		Times(2)
+
+	filter := analytics.ReportFilter{From: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)}
+	report, err := svc.Volume(ctx, 3, filter)
+	require.NoError(t, err)
+	require.Equal(t, "America/New_York", report.TimeZone)
+	require.Equal(t, "2026-03-15", report.To)
+	require.Equal(t, analytics.GroupByMuscleGroup, report.GroupBy)
+	require.Equal(t, []analytics.VolumeRow{
+		{PeriodStart: "2026-03-02", MuscleGroup: exercise.MuscleChest, Tonnage: 640, Sets: 1, HardSets: 1, Sessions: 1},
+		{PeriodStart: "2026-03-02", MuscleGroup: exercise.MuscleTriceps, Tonnage: 320, Sets: 0.5, HardSets: 0.5, Sessions: 1},
+		{PeriodStart: "2026-03-02", MuscleGroup: exercise.MuscleGlutes, Tonnage: 1000, Sets: 2, HardSets: 1, Sessions: 1},
+		{PeriodStart: "2026-03-02", MuscleGroup: exercise.MuscleQuads, Tonnage: 1000, Sets: 2, HardSets: 1, Sessions: 1},
+		{PeriodStart: "2026-03-02", MuscleGroup: exercise.MuscleAdductors, Tonnage: 500, Sets: 1, HardSets: 0.5, Sessions: 1},
+		{PeriodStart: "2026-03-09", MuscleGroup: exercise.MuscleGlutes, Tonnage: 330, Sets: 1, HardSets: 1, Sessions: 1},
+		{PeriodStart: "2026-03-09", MuscleGroup: exercise.MuscleQuads, Tonnage: 330, Sets: 1, HardSets: 1, Sessions: 1},
+		{PeriodStart: "2026-03-09", MuscleGroup: exercise.MuscleAdductors, Tonnage: 165, Sets: 0.5, HardSets: 0.5, Sessions: 1},
+	}, report.Rows)
+
+	filter.GroupBy = analytics.GroupByExercise
+	filter.Period = analytics.PeriodMonth
+	report, err = svc.Volume(ctx, 3, filter)
+	require.NoError(t, err)
+	require.Equal(t, []analytics.VolumeRow{
+		{PeriodStart: "2026-03-01", ExerciseID: 1, ExerciseName: "Squat", Tonnage: 1330, Sets: 3, HardSets: 2, Sessions: 2},
+		{PeriodStart: "2026-03-01", ExerciseID: 2, ExerciseName: "Bench press", Tonnage: 640, Sets: 1, HardSets: 1, Sessions: 1},
+		{PeriodStart: "2026-03-01", ExerciseID: 3, ExerciseName: "Farmer carry", Tonnage: 200, Sets: 1, HardSets: 0, Sessions: 1},
+	}, report.Rows)
+}
+
+func TestService_Volume_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.userService.EXPECT().
+		GetByID(ctx, 3).
+		Return(&user.User{ID: 3}, nil).
+		AnyTimes()
+
+	for name, filter := range map[string]analytics.ReportFilter{
+		"group by":       {GroupBy: "equipment"},
+		"period":         {Period: "day"},
+		"from after to":  {From: day, To: day.AddDate(0, 0, -1)},
+		"range too long": {From: day.AddDate(-4, 0, 0), To: day},
+	} {
+		_, err := svc.Volume(ctx, 3, filter)
+		require.ErrorIs(t, err, analytics.ErrInvalidReport, name)
+	}
+}
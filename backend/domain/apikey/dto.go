@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"github.com/guregu/null/v6"
+)
+
+type CreateRequest struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt null.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  null.Time `json:"expires_at"`
+	LastUsedAt null.Time `json:"last_used_at"`
+	RevokedAt  null.Time `json:"revoked_at"`
+	CreatedAt  string    `json:"created_at"`
+}
+
+// CreateResponse is the only response that carries the key itself.
+type CreateResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type ListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
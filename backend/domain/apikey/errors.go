@@ -0,0 +1,24 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrMissingField   = errors.New("missing field")
+	ErrInvalidScope   = errors.New("invalid scope")
+	ErrInvalidExpiry  = errors.New("expiry must be in the future")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+)
+
+func init() {
+	httpx.RegisterError(ErrAPIKeyNotFound, http.StatusNotFound, "api_key_not_found")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidScope, http.StatusUnprocessableEntity, "invalid_scope")
+	httpx.RegisterError(ErrInvalidExpiry, http.StatusUnprocessableEntity, "invalid_expiry")
+	httpx.RegisterError(ErrInvalidAPIKey, http.StatusUnauthorized, "invalid_api_key")
+}
@@ -0,0 +1,146 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating API key handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created API key handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+func toAPIKeyResponse(key *APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	key, raw, err := h.service.Create(ctx, userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := CreateResponse{APIKeyResponse: toAPIKeyResponse(key), Key: raw}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	keys, err := h.service.List(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ListResponse{APIKeys: make([]APIKeyResponse, 0, len(keys))}
+	for _, key := range keys {
+		resp.APIKeys = append(resp.APIKeys, toAPIKeyResponse(key))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	keyID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid API key id")
+		return
+	}
+
+	if err := h.service.Revoke(ctx, userID, keyID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
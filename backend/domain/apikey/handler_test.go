@@ -0,0 +1,116 @@
+package apikey_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/apikey"
+	"workup_fitness/domain/apikey/mocks"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, keyID string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if keyID != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", keyID)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := apikey.NewHandler(mockService)
+	createdAt := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, "sync", []string{"workouts:read"}, null.Time{}).
+		Return(&apikey.APIKey{ID: 4, UserID: 1, Name: "sync", Prefix: "wf_abcdefgh", Scopes: []string{"workouts:read"}, CreatedAt: createdAt}, "wf_abcdefghsecret", nil)
+
+	body, _ := json.Marshal(apikey.CreateRequest{Name: "sync", Scopes: []string{"workouts:read"}})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/api-keys", body, 1, ""))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+
+	var resp apikey.CreateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 4, resp.ID)
+	require.Equal(t, "wf_abcdefghsecret", resp.Key)
+	require.Equal(t, "2026-10-17T12:00:00Z", resp.CreatedAt)
+}
+
+func TestCreate_InvalidScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := apikey.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, "sync", []string{"everything"}, null.Time{}).
+		Return(nil, "", apikey.ErrInvalidScope)
+
+	body, _ := json.Marshal(apikey.CreateRequest{Name: "sync", Scopes: []string{"everything"}})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/api-keys", body, 1, ""))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestList_HidesKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := apikey.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any(), 1).
+		Return([]*apikey.APIKey{{ID: 4, Name: "sync", Prefix: "wf_abcdefgh", KeyHash: "secret-hash", Scopes: []string{"workouts:read"}}}, nil)
+
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, newAuthedRequest(http.MethodGet, "/api-keys", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NotContains(t, rr.Body.String(), "secret-hash")
+
+	var resp apikey.ListResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.APIKeys, 1)
+	require.Equal(t, "wf_abcdefgh", resp.APIKeys[0].Prefix)
+}
+
+func TestRevoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := apikey.NewHandler(mockService)
+
+	mockService.EXPECT().Revoke(gomock.Any(), 1, 4).Return(nil)
+	mockService.EXPECT().Revoke(gomock.Any(), 1, 5).Return(apikey.ErrAPIKeyNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.Revoke(rr, newAuthedRequest(http.MethodDelete, "/api-keys/4", nil, 1, "4"))
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Revoke(rr, newAuthedRequest(http.MethodDelete, "/api-keys/5", nil, 1, "5"))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Revoke(rr, newAuthedRequest(http.MethodDelete, "/api-keys/x", nil, 1, "x"))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,33 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// APIKey is a personal key for scripts that call the API on behalf of a
+// user. Only the SHA-256 hash of the key is kept; Prefix is the start of the
+// key so users can tell their keys apart.
+type APIKey struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	KeyHash    string    `json:"-"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  null.Time `json:"expires_at"`
+	LastUsedAt null.Time `json:"last_used_at"`
+	RevokedAt  null.Time `json:"revoked_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Scopes an API key can be granted. Each resource has a read scope for GET
+// requests and a write scope for everything else.
+var Scopes = []string{
+	"workouts:read", "workouts:write",
+	"exercises:read", "exercises:write",
+	"simulators:read", "simulators:write",
+	"profile:read", "profile:write",
+	"body_metrics:read", "body_metrics:write",
+}
@@ -0,0 +1,120 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/apikey Repository
+
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) (int, error)
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	ListByUserID(ctx context.Context, userID int) ([]*APIKey, error)
+	Revoke(ctx context.Context, userID, id int) error
+	TouchLastUsed(ctx context.Context, id int, usedAt time.Time) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+const selectAPIKey = `SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row scanner) (*APIKey, error) {
+	var key APIKey
+	var scopes string
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, &scopes,
+		&key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	key.Scopes = strings.Fields(scopes)
+	return &key, nil
+}
+
+func (repo *sqliteRepository) Create(ctx context.Context, key *APIKey) (int, error) {
+	var expiresAt any
+	if key.ExpiresAt.Valid {
+		expiresAt = key.ExpiresAt.Time.UTC()
+	}
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		key.UserID, key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, " "), expiresAt,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrMissingField, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	key, err := scanAPIKey(repo.db.QueryRowContext(ctx, selectAPIKey+` WHERE key_hash = ?`, keyHash))
+	if err := dbutil.ProcessRowError(err, ErrInvalidAPIKey); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (repo *sqliteRepository) ListByUserID(ctx context.Context, userID int) ([]*APIKey, error) {
+	rows, err := repo.db.QueryContext(ctx, selectAPIKey+` WHERE user_id = ? ORDER BY id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]*APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// Revoke revokes key id of userID. Keys of other users and keys that were
+// revoked already are reported as not found.
+func (repo *sqliteRepository) Revoke(ctx context.Context, userID, id int) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL`,
+		time.Now().UTC(), id, userID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) TouchLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	_, err := repo.db.ExecContext(ctx,
+		`UPDATE api_keys SET last_used_at = ? WHERE id = ?`,
+		usedAt.UTC(), id,
+	)
+	return err
+}
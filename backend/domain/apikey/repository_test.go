@@ -0,0 +1,77 @@
+package apikey_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/apikey"
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (apikey.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := apikey.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_CreateAndGetByHash(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	id, err := repo.Create(ctx, &apikey.APIKey{
+		UserID:    1,
+		Name:      "backup script",
+		Prefix:    "wf_abcdefgh",
+		KeyHash:   "hash-1",
+		Scopes:    []string{"workouts:read", "workouts:write"},
+		ExpiresAt: null.TimeFrom(expiresAt),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+
+	found, err := repo.GetByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.Equal(t, "backup script", found.Name)
+	require.Equal(t, []string{"workouts:read", "workouts:write"}, found.Scopes)
+	require.True(t, expiresAt.Equal(found.ExpiresAt.Time))
+	require.False(t, found.LastUsedAt.Valid)
+
+	_, err = repo.GetByHash(ctx, "unknown")
+	require.ErrorIs(t, err, apikey.ErrInvalidAPIKey)
+}
+
+func TestRepository_ListRevokeAndTouch(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &apikey.APIKey{UserID: 1, Name: "a", Prefix: "wf_a", KeyHash: "hash-a", Scopes: []string{"profile:read"}})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &apikey.APIKey{UserID: 2, Name: "b", Prefix: "wf_b", KeyHash: "hash-b", Scopes: []string{"profile:read"}})
+	require.NoError(t, err)
+
+	usedAt := time.Date(2029, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.TouchLastUsed(ctx, id, usedAt))
+
+	require.ErrorIs(t, repo.Revoke(ctx, 2, id), apikey.ErrAPIKeyNotFound, "keys of other users cannot be revoked")
+	require.NoError(t, repo.Revoke(ctx, 1, id))
+	require.ErrorIs(t, repo.Revoke(ctx, 1, id), apikey.ErrAPIKeyNotFound)
+
+	keys, err := repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.True(t, keys[0].RevokedAt.Valid)
+	require.True(t, usedAt.Equal(keys[0].LastUsedAt.Time))
+}
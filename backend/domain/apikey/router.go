@@ -0,0 +1,21 @@
+package apikey
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes mounts key management. It needs a session token, so a
+// leaked key cannot mint new keys.
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireSession)
+		r.Get("/api-keys", h.List)
+		r.Post("/api-keys", h.Create)
+		r.Delete("/api-keys/{id}", h.Revoke)
+	})
+}
@@ -0,0 +1,167 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/guregu/null/v6"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/apikey Service
+
+const (
+	// keyPrefix marks API keys so they are easy to spot in leaked configs.
+	keyPrefix = "wf_"
+	// displayPrefixLen is how much of the key is kept in clear text.
+	displayPrefixLen = len(keyPrefix) + 8
+	// touchInterval limits how often a busy key rewrites last_used_at.
+	touchInterval = time.Minute
+)
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type Service interface {
+	Create(ctx context.Context, userID int, name string, scopes []string, expiresAt null.Time) (*APIKey, string, error)
+	List(ctx context.Context, userID int) ([]*APIKey, error)
+	Revoke(ctx context.Context, userID, id int) error
+	VerifyAPIKey(ctx context.Context, key string) (*middleware.APIKeyPrincipal, error)
+}
+
+type serviceImpl struct {
+	repo        Repository
+	userService UserService
+	now         func() time.Time
+}
+
+func NewService(repo Repository, userService UserService) *serviceImpl {
+	logger.Default().Debug().Msg("Creating API key service...")
+	res := &serviceImpl{repo: repo, userService: userService, now: time.Now}
+	logger.Default().Debug().Msg("Created API key service")
+	return res
+}
+
+// Create issues a new key for userID and returns it with the raw key. Only
+// its hash is stored, so this is the one time the key can be shown.
+func (s *serviceImpl) Create(ctx context.Context, userID int, name string, scopes []string, expiresAt null.Time) (*APIKey, string, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating API key")
+
+	name = strings.TrimSpace(name)
+	if name == "" || len(scopes) == 0 {
+		return nil, "", ErrMissingField
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return nil, "", ErrInvalidScope
+		}
+	}
+	if expiresAt.Valid && !expiresAt.Time.After(s.now()) {
+		return nil, "", ErrInvalidExpiry
+	}
+
+	raw, err := newKey()
+	if err != nil {
+		return nil, "", err
+	}
+	scopes = slices.Clone(scopes)
+	slices.Sort(scopes)
+	key := &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    raw[:displayPrefixLen],
+		KeyHash:   hashKey(raw),
+		Scopes:    slices.Compact(scopes),
+		ExpiresAt: expiresAt,
+		CreatedAt: s.now().UTC(),
+	}
+	key.ID, err = s.repo.Create(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("api_key_id", key.ID).Msg("Created API key")
+	return key, raw, nil
+}
+
+func (s *serviceImpl) List(ctx context.Context, userID int) ([]*APIKey, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing API keys")
+	keys, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("count", len(keys)).Msg("Listed API keys")
+	return keys, nil
+}
+
+func (s *serviceImpl) Revoke(ctx context.Context, userID, id int) error {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("api_key_id", id).Msg("Revoking API key")
+	if err := s.repo.Revoke(ctx, userID, id); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("api_key_id", id).Msg("Revoked API key")
+	return nil
+}
+
+// VerifyAPIKey resolves raw to the user it acts for. Unknown, revoked and
+// expired keys all fail with ErrInvalidAPIKey. The role is looked up on
+// every call, so a demoted user's keys lose their privileges at once.
+func (s *serviceImpl) VerifyAPIKey(ctx context.Context, raw string) (*middleware.APIKeyPrincipal, error) {
+	if !strings.HasPrefix(raw, keyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	key, err := s.repo.GetByHash(ctx, hashKey(raw))
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if key.RevokedAt.Valid || (key.ExpiresAt.Valid && !now.Before(key.ExpiresAt.Time)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	found, err := s.userService.GetByID(ctx, key.UserID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to record the use must not fail the request.
+	if !key.LastUsedAt.Valid || now.Sub(key.LastUsedAt.Time) >= touchInterval {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			logger.Ctx(ctx).Error().Err(err).Int("api_key_id", key.ID).Msg("Failed to record API key use")
+		}
+	}
+
+	return &middleware.APIKeyPrincipal{
+		KeyID:  key.ID,
+		UserID: key.UserID,
+		Role:   string(found.Role),
+		Scopes: key.Scopes,
+	}, nil
+}
+
+func newKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
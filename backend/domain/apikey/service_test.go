@@ -0,0 +1,106 @@
+package apikey_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/apikey"
+	"workup_fitness/domain/user"
+	userMocks "workup_fitness/domain/user/mocks"
+)
+
+type testService struct {
+	apikey.Service
+	users *userMocks.MockService
+}
+
+// newTestService runs the service against a real database, so keys created
+// through it can be verified the way the middleware does.
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	repo, db, _ := newTestRepository(t)
+	t.Cleanup(func() { db.Close() })
+	users := userMocks.NewMockService(gomock.NewController(t))
+	return testService{Service: apikey.NewService(repo, users), users: users}
+}
+
+func TestService_Create_Validation(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		keyName   string
+		scopes    []string
+		expiresAt null.Time
+		err       error
+	}{
+		"no name":       {"  ", []string{"workouts:read"}, null.Time{}, apikey.ErrMissingField},
+		"no scopes":     {"script", nil, null.Time{}, apikey.ErrMissingField},
+		"unknown scope": {"script", []string{"workouts:admin"}, null.Time{}, apikey.ErrInvalidScope},
+		"past expiry":   {"script", []string{"workouts:read"}, null.TimeFrom(time.Now().Add(-time.Hour)), apikey.ErrInvalidExpiry},
+	} {
+		_, _, err := svc.Create(ctx, 1, tc.keyName, tc.scopes, tc.expiresAt)
+		require.ErrorIs(t, err, tc.err, name)
+	}
+}
+
+func TestService_CreateAndVerify(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.users.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1, Role: user.RoleTrainer}, nil).
+		Times(2)
+
+	created, raw, err := svc.Create(ctx, 1, "sync", []string{"workouts:write", "workouts:read", "workouts:read"}, null.Time{})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(raw, created.Prefix))
+	require.Equal(t, []string{"workouts:read", "workouts:write"}, created.Scopes)
+
+	principal, err := svc.VerifyAPIKey(ctx, raw)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, principal.KeyID)
+	require.Equal(t, 1, principal.UserID)
+	require.Equal(t, string(user.RoleTrainer), principal.Role)
+	require.Equal(t, created.Scopes, principal.Scopes)
+
+	keys, err := svc.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.True(t, keys[0].LastUsedAt.Valid)
+
+	_, err = svc.VerifyAPIKey(ctx, raw+"x")
+	require.ErrorIs(t, err, apikey.ErrInvalidAPIKey)
+	_, err = svc.VerifyAPIKey(ctx, "not-a-key")
+	require.ErrorIs(t, err, apikey.ErrInvalidAPIKey)
+
+	_, err = svc.VerifyAPIKey(ctx, raw)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Revoke(ctx, 1, created.ID))
+	_, err = svc.VerifyAPIKey(ctx, raw)
+	require.ErrorIs(t, err, apikey.ErrInvalidAPIKey)
+}
+
+func TestService_VerifyDeletedUser(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.users.EXPECT().
+		GetByID(gomock.Any(), 2).
+		Return(nil, user.ErrUserNotFound)
+
+	_, raw, err := svc.Create(ctx, 2, "sync", []string{"profile:read"}, null.TimeFrom(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	_, err = svc.VerifyAPIKey(ctx, raw)
+	require.ErrorIs(t, err, apikey.ErrInvalidAPIKey)
+}
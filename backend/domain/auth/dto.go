@@ -19,6 +19,70 @@ type UserResponse struct {
 }
 
 type AuthResponse struct {
-	Token string        `json:"token"`
-	User  *UserResponse `json:"user"`
+	Token        string        `json:"token"`
+	ExpiresIn    int           `json:"expires_in"`
+	RefreshToken string        `json:"refresh_token"`
+	User         *UserResponse `json:"user"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword zero.String `json:"current_password"`
+	NewPassword     zero.String `json:"new_password"`
+}
+
+type ForgotPasswordRequest struct {
+	Email zero.String `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       zero.String `json:"token"`
+	NewPassword zero.String `json:"new_password"`
+}
+
+type ChangeEmailRequest struct {
+	Email           zero.String `json:"email"`
+	CurrentPassword zero.String `json:"current_password"`
+}
+
+type ConfirmEmailRequest struct {
+	Token zero.String `json:"token"`
+}
+
+// LoginChallengeResponse is returned by login instead of AuthResponse when
+// the account needs a second factor.
+type LoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
+
+type CompleteLoginRequest struct {
+	ChallengeToken zero.String `json:"challenge_token"`
+	Code           zero.String `json:"code"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type ConfirmTOTPRequest struct {
+	Code zero.String `json:"code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type DisableTOTPRequest struct {
+	Password zero.String `json:"password"`
+	Code     zero.String `json:"code"`
 }
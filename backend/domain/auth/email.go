@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/pkg/logger"
+	"workup_fitness/pkg/mailer"
+)
+
+// RequestEmailChange checks the current password of userID and mails a
+// confirmation link to email. The address only replaces the current one
+// once ConfirmEmailChange is called with the token from that link.
+func (s *serviceImpl) RequestEmailChange(ctx context.Context, userID int, currentPassword, email string) error {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Requesting email change")
+
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(found.PasswordHash.String), []byte(currentPassword))
+	if err != nil {
+		logger.Ctx(ctx).Warn().Int("user_id", userID).Msg("Wrong current password")
+		return ErrWrongPassword
+	}
+
+	if email == "" {
+		return errors.Join(ErrMissingField, errors.New("email is required"))
+	}
+	email, err = user.ValidateEmail(email)
+	if err != nil {
+		return err
+	}
+	owner, err := s.userService.GetByEmail(ctx, email)
+	switch {
+	case err == nil && owner.ID != userID:
+		return ErrEmailTaken
+	case err == nil:
+		return fmt.Errorf("%w: %q is already your email", ErrEmailTaken, email)
+	case !errors.Is(err, user.ErrUserNotFound):
+		return err
+	}
+
+	raw, err := randomString(32)
+	if err != nil {
+		return err
+	}
+	token := &EmailChangeToken{
+		UserID:    userID,
+		Email:     email,
+		TokenHash: hashToken(raw),
+		ExpiresAt: s.now().Add(s.emailTokenTTL),
+	}
+	if _, err := s.repo.CreateEmailToken(ctx, token); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, s.emailChangeMessage(found, email, raw)); err != nil {
+		return fmt.Errorf("sending email confirmation: %w", err)
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Sent email confirmation")
+	return nil
+}
+
+func (s *serviceImpl) emailChangeMessage(u *user.User, email, token string) mailer.Message {
+	action := "Use this token to confirm the address: " + token
+	if s.emailURL != "" {
+		action = "Open this link to confirm the address: " + s.emailURL + "?token=" + url.QueryEscape(token)
+	}
+	return mailer.Message{
+		To:      email,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to use this address for your account.\n\n%s\n\n"+
+				"It works once and expires in %s. If you did not ask for this, ignore this email.\n",
+			u.Username.String, action, s.emailTokenTTL,
+		),
+	}
+}
+
+// ConfirmEmailChange applies the address from a RequestEmailChange token.
+// The token and all other outstanding email tokens of the user are spent.
+func (s *serviceImpl) ConfirmEmailChange(ctx context.Context, token string) error {
+	stored, err := s.repo.GetEmailToken(ctx, hashToken(token))
+	if err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", stored.UserID).Msg("Confirming email change")
+
+	if stored.UsedAt.Valid || !s.now().Before(stored.ExpiresAt) {
+		return ErrInvalidEmailToken
+	}
+	if err := s.repo.UseEmailToken(ctx, stored); err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", stored.UserID).Msg("Changed email")
+	return nil
+}
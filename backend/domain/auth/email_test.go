@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/domain/user/mocks"
+)
+
+func newEmailTestService(t *testing.T) (*serviceImpl, *mocks.MockService, *recordingMailer, Repository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUserService := mocks.NewMockService(ctrl)
+	repo, db, _ := newTestRepository(t)
+	t.Cleanup(func() { db.Close() })
+
+	mail := &recordingMailer{}
+	authService := NewService(mockUserService, repo, Options{
+		RefreshTokenTTL: time.Hour,
+		Mailer:          mail,
+		EmailTokenTTL:   time.Hour,
+		EmailURL:        "https://app.example.com/confirm-email",
+	})
+	return authService, mockUserService, mail, repo
+}
+
+func emailTestUser(t *testing.T) *user.User {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	return &user.User{
+		ID:           1,
+		Username:     zero.StringFrom("testuser"),
+		Email:        zero.StringFrom("old@example.com"),
+		PasswordHash: zero.StringFrom(string(hash)),
+	}
+}
+
+// requestEmailToken runs RequestEmailChange and pulls the raw token out of
+// the emailed link.
+func requestEmailToken(t *testing.T, authService *serviceImpl, mockUserService *mocks.MockService, mail *recordingMailer) string {
+	t.Helper()
+
+	mockUserService.EXPECT().GetByID(gomock.Any(), 1).Return(emailTestUser(t), nil)
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "new@example.com").Return(nil, user.ErrUserNotFound)
+	require.NoError(t, authService.RequestEmailChange(context.Background(), 1, "password", " New@Example.com "))
+	require.NotEmpty(t, mail.sent)
+
+	msg := mail.sent[len(mail.sent)-1]
+	require.Equal(t, "new@example.com", msg.To, "the link goes to the new address")
+	_, link, ok := strings.Cut(msg.Body, "https://app.example.com/confirm-email?token=")
+	require.True(t, ok, msg.Body)
+	token, _, _ := strings.Cut(link, "\n")
+	return token
+}
+
+func TestRequestEmailChange_WrongPassword(t *testing.T) {
+	authService, mockUserService, mail, _ := newEmailTestService(t)
+
+	mockUserService.EXPECT().GetByID(gomock.Any(), 1).Return(emailTestUser(t), nil)
+
+	err := authService.RequestEmailChange(context.Background(), 1, "wrong", "new@example.com")
+	require.ErrorIs(t, err, ErrWrongPassword)
+	require.Empty(t, mail.sent)
+}
+
+func TestRequestEmailChange_InvalidAndTaken(t *testing.T) {
+	authService, mockUserService, mail, _ := newEmailTestService(t)
+	ctx := context.Background()
+
+	mockUserService.EXPECT().GetByID(gomock.Any(), 1).Return(emailTestUser(t), nil).Times(3)
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "taken@example.com").Return(&user.User{ID: 2}, nil)
+
+	require.ErrorIs(t, authService.RequestEmailChange(ctx, 1, "password", ""), ErrMissingField)
+	require.ErrorIs(t, authService.RequestEmailChange(ctx, 1, "password", "not an email"), user.ErrInvalidEmail)
+	require.ErrorIs(t, authService.RequestEmailChange(ctx, 1, "password", "taken@example.com"), ErrEmailTaken)
+	require.Empty(t, mail.sent)
+}
+
+func TestRequestEmailChange_MailerError(t *testing.T) {
+	authService, mockUserService, mail, _ := newEmailTestService(t)
+	mail.err = errors.New("connection refused")
+
+	mockUserService.EXPECT().GetByID(gomock.Any(), 1).Return(emailTestUser(t), nil)
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "new@example.com").Return(nil, user.ErrUserNotFound)
+
+	require.Error(t, authService.RequestEmailChange(context.Background(), 1, "password", "new@example.com"))
+}
+
+func TestConfirmEmailChange_Success(t *testing.T) {
+	authService, mockUserService, mail, _ := newEmailTestService(t)
+	ctx := context.Background()
+
+	first := requestEmailToken(t, authService, mockUserService, mail)
+	second := requestEmailToken(t, authService, mockUserService, mail)
+
+	require.NoError(t, authService.ConfirmEmailChange(ctx, second))
+
+	require.ErrorIs(t, authService.ConfirmEmailChange(ctx, second), ErrInvalidEmailToken)
+	require.ErrorIs(t, authService.ConfirmEmailChange(ctx, first), ErrInvalidEmailToken)
+	require.ErrorIs(t, authService.ConfirmEmailChange(ctx, "unknown"), ErrInvalidEmailToken)
+}
+
+func TestConfirmEmailChange_Expired(t *testing.T) {
+	authService, mockUserService, mail, _ := newEmailTestService(t)
+
+	token := requestEmailToken(t, authService, mockUserService, mail)
+	authService.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	require.ErrorIs(t, authService.ConfirmEmailChange(context.Background(), token), ErrInvalidEmailToken)
+}
@@ -1,9 +1,46 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
 
 var (
-	ErrInvalidCreds  = errors.New("invalid username or password")
-	ErrAlreadyExists = errors.New("user already exists")
-	ErrMissingField  = errors.New("missing field")
+	ErrInvalidCreds        = errors.New("invalid username or password")
+	ErrAlreadyExists       = errors.New("user already exists")
+	ErrMissingField        = errors.New("missing field")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrTooManyAttempts     = errors.New("too many failed login attempts")
+	ErrWeakPassword        = errors.New("password does not meet the policy")
+	ErrWrongPassword       = errors.New("current password is incorrect")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+	ErrTooManyResets       = errors.New("too many password reset requests")
+	ErrInvalidEmailToken   = errors.New("invalid or expired email confirmation token")
+	ErrEmailTaken          = errors.New("email address is already in use")
+	ErrTOTPAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTOTPCode     = errors.New("invalid two-factor code")
+	ErrInvalidChallenge    = errors.New("invalid or expired login challenge")
 )
+
+func init() {
+	httpx.RegisterError(ErrInvalidCreds, http.StatusUnauthorized, "invalid_credentials")
+	httpx.RegisterError(ErrAlreadyExists, http.StatusConflict, "user_already_exists")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidRefreshToken, http.StatusUnauthorized, "invalid_refresh_token")
+	httpx.RegisterError(ErrRefreshTokenReused, http.StatusUnauthorized, "refresh_token_reused")
+	httpx.RegisterError(ErrTooManyAttempts, http.StatusTooManyRequests, "too_many_login_attempts")
+	httpx.RegisterError(ErrWeakPassword, http.StatusUnprocessableEntity, "weak_password")
+	httpx.RegisterError(ErrWrongPassword, http.StatusForbidden, "wrong_password")
+	httpx.RegisterError(ErrInvalidResetToken, http.StatusBadRequest, "invalid_reset_token")
+	httpx.RegisterError(ErrTooManyResets, http.StatusTooManyRequests, "too_many_reset_requests")
+	httpx.RegisterError(ErrInvalidEmailToken, http.StatusBadRequest, "invalid_email_token")
+	httpx.RegisterError(ErrEmailTaken, http.StatusConflict, "email_taken")
+	httpx.RegisterError(ErrTOTPAlreadyEnabled, http.StatusConflict, "totp_already_enabled")
+	httpx.RegisterError(ErrTOTPNotEnabled, http.StatusConflict, "totp_not_enabled")
+	httpx.RegisterError(ErrInvalidTOTPCode, http.StatusUnauthorized, "invalid_totp_code")
+	httpx.RegisterError(ErrInvalidChallenge, http.StatusUnauthorized, "invalid_login_challenge")
+}
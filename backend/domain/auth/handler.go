@@ -2,37 +2,52 @@ package auth
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/rs/zerolog/log"
 
 	"workup_fitness/domain/user"
+	"workup_fitness/middleware"
 	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
 )
 
 type Handler struct {
-	service Service
-	secret  string
+	service        Service
+	secret         string
+	accessTokenTTL time.Duration
+	challengeTTL   time.Duration
 }
 
-func NewHandler(service Service, secret string) *Handler {
-	log.Info().Msg("Creating auth handler...")
-	defer log.Info().Msg("Created auth handler")
-	return &Handler{service: service, secret: secret}
+func NewHandler(service Service, secret string, accessTokenTTL, challengeTTL time.Duration) *Handler {
+	logger.Default().Debug().Msg("Creating auth handler...")
+	defer logger.Default().Debug().Msg("Created auth handler")
+	return &Handler{service: service, secret: secret, accessTokenTTL: accessTokenTTL, challengeTTL: challengeTTL}
 }
 
-func prepareAuthReponse(user *user.User, secret string) (AuthResponse, error) {
+func prepareAuthReponse(user *user.User, secret string, ttl time.Duration) (AuthResponse, error) {
+	jti, err := randomString(16)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+
+	now := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"userID": user.ID,
+		"role":   user.Role,
+		"iat":    now.Unix(),
+		"exp":    now.Add(ttl).Unix(),
+		"jti":    jti,
 	})
 	tokenString, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return AuthResponse{}, err
 	}
 	resp := AuthResponse{
-		Token: tokenString,
+		Token:     tokenString,
+		ExpiresIn: int(ttl.Seconds()),
 		User: &UserResponse{
 			ID:        user.ID,
 			Username:  user.Username,
@@ -42,6 +57,19 @@ func prepareAuthReponse(user *user.User, secret string) (AuthResponse, error) {
 	return resp, nil
 }
 
+// prepareSession signs an access token and starts a refresh token family.
+func (h *Handler) prepareSession(r *http.Request, user *user.User) (AuthResponse, error) {
+	resp, err := prepareAuthReponse(user, h.secret, h.accessTokenTTL)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	resp.RefreshToken, err = h.service.IssueRefreshToken(r.Context(), user.ID)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	return resp, nil
+}
+
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		httpx.MethodNotAllowed(w)
@@ -57,13 +85,13 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.Register(r.Context(), req.Username.String, req.Password.String)
 	if err != nil {
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
-	log.Info().Msgf("Registered user with username %s", req.Username.String)
+	logger.Ctx(r.Context()).Info().Int("user_id", user.ID).Msg("Registered user")
 
-	resp, err := prepareAuthReponse(user, h.secret)
+	resp, err := h.prepareSession(r, user)
 	if err != nil {
 		httpx.InternalServerError(w, err)
 		return
@@ -75,7 +103,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Registered user with username %s", req.Username.String)
+	logger.Ctx(r.Context()).Info().Int("user_id", user.ID).Msg("Registered user")
 }
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -91,15 +119,265 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.service.Login(r.Context(), req.Username.String, req.Password.String)
+	user, err := h.service.Login(r.Context(), req.Username.String, req.Password.String, clientIP(r))
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	challenge, err := h.service.StartLoginChallenge(r.Context(), user.ID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+	if challenge != "" {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(LoginChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+			ExpiresIn:         int(h.challengeTTL.Seconds()),
+		})
+		if err != nil {
+			httpx.InternalServerError(w, err)
+		}
+		return
+	}
+
+	resp, err := h.prepareSession(r, user)
 	if err != nil {
 		httpx.InternalServerError(w, err)
 		return
 	}
 
-	log.Info().Msgf("Logged in user with username %s", req.Username.String)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(r.Context()).Info().Int("user_id", user.ID).Msg("Logged in user")
+}
+
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	var req RefreshRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, refreshToken, err := h.service.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp, err := prepareAuthReponse(user, h.secret, h.accessTokenTTL)
+	if err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+	resp.RefreshToken = refreshToken
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(r.Context()).Info().Int("user_id", user.ID).Msg("Refreshed tokens")
+}
+
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	var req LogoutRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), req.RefreshToken); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	logger.Ctx(r.Context()).Info().Msg("Logged out session")
+}
+
+// ChangePassword sets a new password for the authenticated user. All other
+// sessions are revoked, and the caller gets a fresh one in the response.
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req ChangePasswordRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, err := h.service.ChangePassword(r.Context(), userID, req.CurrentPassword.String, req.NewPassword.String)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp, err := h.prepareSession(r, user)
+	if err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(r.Context()).Info().Int("user_id", user.ID).Msg("Changed password")
+}
+
+// ForgotPassword always answers 202 so that callers cannot probe which
+// email addresses are registered.
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	var req ForgotPasswordRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email.String == "" {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(r.Context(), req.Email.String, clientIP(r)); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	var req ResetPasswordRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token.String == "" {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), req.Token.String, req.NewPassword.String); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	logger.Ctx(r.Context()).Info().Msg("Reset password")
+}
+
+// ChangeEmail mails a confirmation link to the requested address. The
+// email of the account stays the same until the link is used.
+func (h *Handler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req ChangeEmailRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.service.RequestEmailChange(r.Context(), userID, req.CurrentPassword.String, req.Email.String); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
 
-	resp, err := prepareAuthReponse(user, h.secret)
+func (h *Handler) ConfirmEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	var req ConfirmEmailRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token.String == "" {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.service.ConfirmEmailChange(r.Context(), req.Token.String); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	logger.Ctx(r.Context()).Info().Msg("Confirmed email change")
+}
+
+// CompleteLogin is the second login step for accounts with two-factor
+// login: it trades the challenge token and a code for a session.
+func (h *Handler) CompleteLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	var req CompleteLoginRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken.String == "" {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	user, err := h.service.CompleteLogin(r.Context(), req.ChallengeToken.String, req.Code.String, clientIP(r))
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp, err := h.prepareSession(r, user)
 	if err != nil {
 		httpx.InternalServerError(w, err)
 		return
@@ -111,5 +389,101 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Logged in user with username %s", req.Username.String)
+	logger.Ctx(r.Context()).Info().Int("user_id", user.ID).Msg("Logged in user")
+}
+
+func (h *Handler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	secret, uri, err := h.service.EnrollTOTP(r.Context(), userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(EnrollTOTPResponse{Secret: secret, OTPAuthURI: uri}); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req ConfirmTOTPRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(r.Context(), userID, req.Code.String)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(RecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req DisableTOTPRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), userID, req.Password.String, req.Code.String); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clientIP returns the host part of r.RemoteAddr. Behind a trusted proxy the
+// RealIP middleware has already replaced it with the forwarded address.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 
 	"workup_fitness/domain/auth/mocks"
 	"workup_fitness/domain/user"
+	"workup_fitness/middleware"
 )
 
 func TestRegisterHandler_Success(t *testing.T) {
@@ -23,7 +25,7 @@ func TestRegisterHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	expectedUser := &user.User{
 		ID:        1,
@@ -34,6 +36,9 @@ func TestRegisterHandler_Success(t *testing.T) {
 	mockService.EXPECT().
 		Register(gomock.Any(), "testuser", "password123").
 		Return(expectedUser, nil)
+	mockService.EXPECT().
+		IssueRefreshToken(gomock.Any(), 1).
+		Return("refresh-token", nil)
 
 	reqBody := RegisterRequest{
 		Username: zero.StringFrom("testuser"),
@@ -53,6 +58,7 @@ func TestRegisterHandler_Success(t *testing.T) {
 	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
 	require.Equal(t, "testuser", resp.User.Username.String)
 	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "refresh-token", resp.RefreshToken)
 
 	token, err := jwt.Parse(resp.Token, func(token *jwt.Token) (interface{}, error) {
 		return []byte("test-secret"), nil
@@ -66,7 +72,7 @@ func TestRegisterHandler_InvalidJSON(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -81,7 +87,7 @@ func TestRegisterHandler_ServiceError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	mockService.EXPECT().
 		Register(gomock.Any(), "testuser", "password123").
@@ -105,7 +111,7 @@ func TestRegisterHandler_MethodNotAllowed(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	req := httptest.NewRequest(http.MethodGet, "/register", nil)
 	rr := httptest.NewRecorder()
@@ -119,7 +125,7 @@ func TestLoginHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	expectedUser := &user.User{
 		ID:        1,
@@ -128,8 +134,14 @@ func TestLoginHandler_Success(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		Login(gomock.Any(), "testuser", "password123").
+		Login(gomock.Any(), "testuser", "password123", gomock.Any()).
 		Return(expectedUser, nil)
+	mockService.EXPECT().
+		StartLoginChallenge(gomock.Any(), 1).
+		Return("", nil)
+	mockService.EXPECT().
+		IssueRefreshToken(gomock.Any(), 1).
+		Return("refresh-token", nil)
 
 	reqBody := LoginRequest{
 		Username: zero.StringFrom("testuser"),
@@ -149,6 +161,7 @@ func TestLoginHandler_Success(t *testing.T) {
 	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
 	require.Equal(t, "testuser", resp.User.Username.String)
 	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "refresh-token", resp.RefreshToken)
 
 	token, err := jwt.Parse(resp.Token, func(token *jwt.Token) (interface{}, error) {
 		return []byte("test-secret"), nil
@@ -157,12 +170,90 @@ func TestLoginHandler_Success(t *testing.T) {
 	require.True(t, token.Valid)
 }
 
+func TestLoginHandler_TwoFactorChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, 5*time.Minute)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), "testuser", "password123", gomock.Any()).
+		Return(&user.User{ID: 1}, nil)
+	mockService.EXPECT().
+		StartLoginChallenge(gomock.Any(), 1).
+		Return("challenge", nil)
+
+	body := []byte(`{"username":"testuser","password":"password123"}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/login", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp LoginChallengeResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, LoginChallengeResponse{TwoFactorRequired: true, ChallengeToken: "challenge", ExpiresIn: 300}, resp)
+}
+
+func TestCompleteLoginHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		CompleteLogin(gomock.Any(), "challenge", "123456", "192.0.2.1").
+		Return(&user.User{ID: 1, Username: zero.StringFrom("testuser")}, nil)
+	mockService.EXPECT().
+		IssueRefreshToken(gomock.Any(), 1).
+		Return("refresh-token", nil)
+	mockService.EXPECT().
+		CompleteLogin(gomock.Any(), "challenge", "000000", "192.0.2.1").
+		Return(nil, ErrInvalidTOTPCode)
+
+	for code, want := range map[string]int{"123456": http.StatusOK, "000000": http.StatusUnauthorized} {
+		body := []byte(`{"challenge_token":"challenge","code":"` + code + `"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/login/2fa", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.CompleteLogin(rr, req)
+
+		require.Equal(t, want, rr.Code, code)
+	}
+}
+
+func TestEnrollTOTPHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		EnrollTOTP(gomock.Any(), 1).
+		Return("SECRET", "otpauth://totp/x", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/2fa/enroll", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, 1))
+	rr := httptest.NewRecorder()
+
+	handler.EnrollTOTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	var resp EnrollTOTPResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, EnrollTOTPResponse{Secret: "SECRET", OTPAuthURI: "otpauth://totp/x"}, resp)
+}
+
 func TestLoginHandler_InvalidJSON(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -177,10 +268,10 @@ func TestLoginHandler_ServiceError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	mockService.EXPECT().
-		Login(gomock.Any(), "testuser", "wrongpassword").
+		Login(gomock.Any(), "testuser", "wrongpassword", gomock.Any()).
 		Return(nil, ErrInvalidCreds)
 
 	reqBody := LoginRequest{
@@ -194,7 +285,32 @@ func TestLoginHandler_ServiceError(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	handler.Login(rr, req)
-	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestLoginHandler_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), "testuser", "password123", "192.0.2.1").
+		Return(nil, &LoginThrottledError{Wait: 90 * time.Second})
+
+	body, _ := json.Marshal(LoginRequest{
+		Username: zero.StringFrom("testuser"),
+		Password: zero.StringFrom("password123"),
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	require.Equal(t, "90", rr.Header().Get("Retry-After"))
 }
 
 func TestLoginHandler_MethodNotAllowed(t *testing.T) {
@@ -202,7 +318,7 @@ func TestLoginHandler_MethodNotAllowed(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockService(ctrl)
-	handler := NewHandler(mockService, "test-secret")
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
 
 	req := httptest.NewRequest(http.MethodGet, "/login", nil)
 	rr := httptest.NewRecorder()
@@ -215,12 +331,14 @@ func TestPrepareAuthResponse_Success(t *testing.T) {
 	testUser := &user.User{
 		ID:        1,
 		Username:  zero.StringFrom("testuser"),
+		Role:      user.RoleAdmin,
 		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 
-	resp, err := prepareAuthReponse(testUser, "test-secret")
+	resp, err := prepareAuthReponse(testUser, "test-secret", 15*time.Minute)
 
 	require.NoError(t, err)
+	require.Equal(t, 900, resp.ExpiresIn)
 	require.Equal(t, 1, resp.User.ID)
 	require.Equal(t, "testuser", resp.User.Username.String)
 	require.NotEmpty(t, resp.Token)
@@ -234,4 +352,196 @@ func TestPrepareAuthResponse_Success(t *testing.T) {
 	claims, ok := token.Claims.(jwt.MapClaims)
 	require.True(t, ok)
 	require.Equal(t, 1, int(claims["userID"].(float64)))
+	require.Equal(t, "admin", claims["role"])
+	require.NotEmpty(t, claims["jti"])
+
+	exp, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	iat, err := claims.GetIssuedAt()
+	require.NoError(t, err)
+	require.Equal(t, 15*time.Minute, exp.Sub(iat.Time))
+}
+
+func TestRefreshHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		Refresh(gomock.Any(), "old-token").
+		Return(&user.User{ID: 1, Username: zero.StringFrom("testuser")}, "new-token", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/refresh", bytes.NewReader([]byte(`{"refresh_token":"old-token"}`)))
+	rr := httptest.NewRecorder()
+
+	handler.Refresh(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp AuthResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "new-token", resp.RefreshToken)
+}
+
+func TestRefreshHandler_Reused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		Refresh(gomock.Any(), "old-token").
+		Return(nil, "", ErrRefreshTokenReused)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/refresh", bytes.NewReader([]byte(`{"refresh_token":"old-token"}`)))
+	rr := httptest.NewRecorder()
+
+	handler.Refresh(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestRefreshHandler_MissingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/refresh", bytes.NewReader([]byte(`{}`)))
+	rr := httptest.NewRecorder()
+
+	handler.Refresh(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestLogoutHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		Logout(gomock.Any(), "token").
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/logout", bytes.NewReader([]byte(`{"refresh_token":"token"}`)))
+	rr := httptest.NewRecorder()
+
+	handler.Logout(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestChangePasswordHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		ChangePassword(gomock.Any(), 1, "password123", "correct horse").
+		Return(&user.User{ID: 1, Username: zero.StringFrom("testuser")}, nil)
+	mockService.EXPECT().
+		IssueRefreshToken(gomock.Any(), 1).
+		Return("refresh", nil)
+
+	body := []byte(`{"current_password":"password123","new_password":"correct horse"}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/password", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, 1))
+	rr := httptest.NewRecorder()
+
+	handler.ChangePassword(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp AuthResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "refresh", resp.RefreshToken)
+}
+
+func TestChangePasswordHandler_WeakPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		ChangePassword(gomock.Any(), 1, "password123", "short").
+		Return(nil, ErrWeakPassword)
+
+	body := []byte(`{"current_password":"password123","new_password":"short"}`)
+	req := httptest.NewRequest(http.MethodPost, "/users/password", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, 1))
+	rr := httptest.NewRecorder()
+
+	handler.ChangePassword(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestChangePasswordHandler_Unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewHandler(mocks.NewMockService(ctrl), "test-secret", time.Minute, time.Minute)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/password", bytes.NewReader([]byte(`{}`)))
+	rr := httptest.NewRecorder()
+
+	handler.ChangePassword(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestForgotPasswordHandler_Accepted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		RequestPasswordReset(gomock.Any(), "test@example.com", gomock.Any()).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/password/forgot", bytes.NewReader([]byte(`{"email":"test@example.com"}`)))
+	rr := httptest.NewRecorder()
+
+	handler.ForgotPassword(rr, req)
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestResetPasswordHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService, "test-secret", time.Minute, time.Minute)
+
+	mockService.EXPECT().
+		ResetPassword(gomock.Any(), "good", "correct horse").
+		Return(nil)
+	mockService.EXPECT().
+		ResetPassword(gomock.Any(), "spent", "correct horse").
+		Return(ErrInvalidResetToken)
+
+	for token, want := range map[string]int{"good": http.StatusNoContent, "spent": http.StatusBadRequest} {
+		body := []byte(`{"token":"` + token + `","new_password":"correct horse"}`)
+		req := httptest.NewRequest(http.MethodPost, "/users/password/reset", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.ResetPassword(rr, req)
+
+		require.Equal(t, want, rr.Code, token)
+	}
 }
@@ -0,0 +1,15 @@
+package auth
+
+import "workup_fitness/pkg/metrics"
+
+var (
+	registrationsTotal = metrics.Default.NewCounterVec(
+		"workup_registrations_total",
+		"Total number of successful user registrations.",
+	)
+	loginsTotal = metrics.Default.NewCounterVec(
+		"workup_logins_total",
+		"Total number of password logins by result (success or failure).",
+		"result",
+	)
+)
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// RefreshToken is a stored refresh token. Only the SHA-256 hash of the token
+// is kept; tokens rotated from the same login share a FamilyID.
+type RefreshToken struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	FamilyID  string    `json:"family_id"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt null.Time `json:"revoked_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PasswordResetToken is a stored single-use password reset token. Like
+// refresh tokens, only the SHA-256 hash is kept.
+type PasswordResetToken struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UsedAt    null.Time `json:"used_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailChangeToken is a stored single-use token that confirms Email
+// belongs to the user before it replaces their current address.
+type EmailChangeToken struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Email     string    `json:"email"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UsedAt    null.Time `json:"used_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TOTP is the authenticator secret of a user. Two-factor login is enabled
+// once ConfirmedAt is set. LastCounter is the last accepted time step, so a
+// code cannot be used twice.
+type TOTP struct {
+	UserID      int       `json:"user_id"`
+	Secret      string    `json:"-"`
+	ConfirmedAt null.Time `json:"confirmed_at"`
+	LastCounter int64     `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LoginChallenge is the short-lived token issued after a correct password
+// when the user still has to enter a second factor.
+type LoginChallenge struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts"`
+	UsedAt    null.Time `json:"used_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	LoginFailureUnknownUser   = "unknown_user"
+	LoginFailureWrongPassword = "wrong_password"
+	LoginFailureThrottled     = "throttled"
+	LoginFailureWrongCode     = "wrong_code"
+)
+
+// LoginFailure is an audit record of a rejected password login.
+type LoginFailure struct {
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	IP        string    `json:"ip"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxPasswordBytes is the bcrypt input limit; longer passwords are rejected
+// instead of being silently truncated.
+const MaxPasswordBytes = 72
+
+// PasswordPolicy decides which new passwords Register and ChangePassword
+// accept. The zero value only rejects empty and over-long passwords.
+type PasswordPolicy struct {
+	// MinLength counts characters, not bytes.
+	MinLength int
+	// Breached holds lowercased passwords known from public breaches.
+	Breached map[string]struct{}
+}
+
+// LoadBreachedPasswords reads a newline-separated password list, skipping
+// blank lines and lines starting with #.
+func LoadBreachedPasswords(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	breached := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		breached[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return breached, nil
+}
+
+// Check returns an error matching ErrWeakPassword if password may not be
+// used by username.
+func (p PasswordPolicy) Check(username, password string) error {
+	if password == "" {
+		return errors.Join(ErrMissingField, errors.New("password is required"))
+	}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, p.MinLength)
+	}
+	if len(password) > MaxPasswordBytes {
+		return fmt.Errorf("%w: must be at most %d bytes", ErrWeakPassword, MaxPasswordBytes)
+	}
+	if strings.EqualFold(password, username) {
+		return fmt.Errorf("%w: must not match the username", ErrWeakPassword)
+	}
+	if _, ok := p.Breached[strings.ToLower(password)]; ok {
+		return fmt.Errorf("%w: appears in a list of breached passwords", ErrWeakPassword)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBreachedPasswords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# top passwords\nPassword1\n\n  qwerty123  \n"), 0o600))
+
+	breached, err := LoadBreachedPasswords(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"password1": {}, "qwerty123": {}}, breached)
+
+	_, err = LoadBreachedPasswords(filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8, Breached: map[string]struct{}{"password1": {}}}
+
+	require.NoError(t, policy.Check("alice", "correct horse"))
+	require.NoError(t, policy.Check("alice", "пароль12"), "length counts characters")
+	require.ErrorIs(t, policy.Check("alice", "PASSWORD1"), ErrWeakPassword)
+	require.ErrorIs(t, policy.Check("alice12345", "ALICE12345"), ErrWeakPassword)
+	require.ErrorIs(t, policy.Check("alice", ""), ErrMissingField)
+}
@@ -0,0 +1,461 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"workup_fitness/internal/dbutil"
+)
+
+type Repository interface {
+	Create(ctx context.Context, token *RefreshToken) (int, error)
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	Rotate(ctx context.Context, oldID int, next *RefreshToken) (int, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeUser(ctx context.Context, userID int) error
+	RecordLoginFailure(ctx context.Context, failure *LoginFailure) error
+	CreateResetToken(ctx context.Context, token *PasswordResetToken) (int, error)
+	GetResetToken(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	UseResetToken(ctx context.Context, token *PasswordResetToken, passwordHash string) error
+	CreateEmailToken(ctx context.Context, token *EmailChangeToken) (int, error)
+	GetEmailToken(ctx context.Context, tokenHash string) (*EmailChangeToken, error)
+	UseEmailToken(ctx context.Context, token *EmailChangeToken) error
+	GetTOTP(ctx context.Context, userID int) (*TOTP, error)
+	SaveTOTP(ctx context.Context, totp *TOTP) error
+	ConfirmTOTP(ctx context.Context, userID int, recoveryCodeHashes []string) error
+	UseTOTPCounter(ctx context.Context, userID int, counter int64) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) error
+	DeleteTOTP(ctx context.Context, userID int) error
+	CreateChallenge(ctx context.Context, challenge *LoginChallenge) (int, error)
+	GetChallenge(ctx context.Context, tokenHash string) (*LoginChallenge, error)
+	FailChallenge(ctx context.Context, id int) error
+	UseChallenge(ctx context.Context, id int) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+func (repo *sqliteRepository) Create(ctx context.Context, token *RefreshToken) (int, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES (?, ?, ?, ?)`,
+		token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrInvalidRefreshToken, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	var token RefreshToken
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = ?`,
+		tokenHash,
+	)
+	err := row.Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt, &token.RevokedAt, &token.CreatedAt)
+	if err := dbutil.ProcessRowError(err, ErrInvalidRefreshToken); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate revokes the token oldID and stores next in one transaction. It fails
+// with ErrRefreshTokenReused when oldID has already been revoked, which also
+// covers two concurrent refreshes with the same token.
+func (repo *sqliteRepository) Rotate(ctx context.Context, oldID int, next *RefreshToken) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`,
+		time.Now().UTC(), oldID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, ErrRefreshTokenReused
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES (?, ?, ?, ?)`,
+		next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrInvalidRefreshToken, ErrMissingField); err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := repo.db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`,
+		time.Now().UTC(), familyID,
+	)
+	return err
+}
+
+// RevokeUser revokes every live refresh token of userID, ending all of its
+// sessions once their access tokens expire.
+func (repo *sqliteRepository) RevokeUser(ctx context.Context, userID int) error {
+	_, err := repo.db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`,
+		time.Now().UTC(), userID,
+	)
+	return err
+}
+
+func (repo *sqliteRepository) RecordLoginFailure(ctx context.Context, failure *LoginFailure) error {
+	_, err := repo.db.ExecContext(ctx,
+		`INSERT INTO login_failures (username, ip, reason) VALUES (?, ?, ?)`,
+		failure.Username, failure.IP, failure.Reason,
+	)
+	return err
+}
+
+func (repo *sqliteRepository) CreateResetToken(ctx context.Context, token *PasswordResetToken) (int, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES (?, ?, ?)`,
+		token.UserID, token.TokenHash, token.ExpiresAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrInvalidResetToken, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetResetToken(ctx context.Context, tokenHash string) (*PasswordResetToken, error) {
+	var token PasswordResetToken
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_reset_tokens WHERE token_hash = ?`,
+		tokenHash,
+	)
+	err := row.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt)
+	if err := dbutil.ProcessRowError(err, ErrInvalidResetToken); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// UseResetToken sets the password hash of the token's user and marks token
+// used along with every other unused reset token of that user, all in one
+// transaction. It fails with ErrInvalidResetToken if token was used
+// already, so two concurrent resets cannot both succeed.
+func (repo *sqliteRepository) UseResetToken(ctx context.Context, token *PasswordResetToken, passwordHash string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		now, token.ID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidResetToken
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL`,
+		now, token.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err = tx.ExecContext(ctx, `UPDATE users SET password_hash = ? WHERE id = ?`, passwordHash, token.UserID)
+	if err != nil {
+		return err
+	}
+	affected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidResetToken
+	}
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) CreateEmailToken(ctx context.Context, token *EmailChangeToken) (int, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO email_change_tokens (user_id, email, token_hash, expires_at) VALUES (?, ?, ?, ?)`,
+		token.UserID, token.Email, token.TokenHash, token.ExpiresAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrInvalidEmailToken, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetEmailToken(ctx context.Context, tokenHash string) (*EmailChangeToken, error) {
+	var token EmailChangeToken
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT id, user_id, email, token_hash, expires_at, used_at, created_at FROM email_change_tokens WHERE token_hash = ?`,
+		tokenHash,
+	)
+	err := row.Scan(&token.ID, &token.UserID, &token.Email, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt)
+	if err := dbutil.ProcessRowError(err, ErrInvalidEmailToken); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// UseEmailToken sets the email of the token's user and spends the token
+// along with every other unused email token of that user, all in one
+// transaction. It fails with ErrInvalidEmailToken if token was used
+// already and with ErrEmailTaken if another account has the address.
+func (repo *sqliteRepository) UseEmailToken(ctx context.Context, token *EmailChangeToken) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx,
+		`UPDATE email_change_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		now, token.ID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidEmailToken
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE email_change_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL`,
+		now, token.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users SET email = ? WHERE id = ?`, token.Email, token.UserID)
+	if err := dbutil.ProcessInsertError(err, ErrEmailTaken, ErrMissingField); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) GetTOTP(ctx context.Context, userID int) (*TOTP, error) {
+	var totp TOTP
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT user_id, secret, confirmed_at, last_counter, created_at FROM user_totp WHERE user_id = ?`,
+		userID,
+	)
+	err := row.Scan(&totp.UserID, &totp.Secret, &totp.ConfirmedAt, &totp.LastCounter, &totp.CreatedAt)
+	if err := dbutil.ProcessRowError(err, ErrTOTPNotEnabled); err != nil {
+		return nil, err
+	}
+	return &totp, nil
+}
+
+// SaveTOTP stores a new unconfirmed secret, replacing any earlier one.
+func (repo *sqliteRepository) SaveTOTP(ctx context.Context, totp *TOTP) error {
+	_, err := repo.db.ExecContext(ctx,
+		`INSERT INTO user_totp (user_id, secret) VALUES (?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET secret = excluded.secret, confirmed_at = NULL, last_counter = 0, created_at = CURRENT_TIMESTAMP`,
+		totp.UserID, totp.Secret,
+	)
+	return dbutil.ProcessInsertError(err, ErrTOTPAlreadyEnabled, ErrMissingField)
+}
+
+// ConfirmTOTP enables two-factor login and replaces the recovery codes in
+// one transaction.
+func (repo *sqliteRepository) ConfirmTOTP(ctx context.Context, userID int, recoveryCodeHashes []string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE user_totp SET confirmed_at = ? WHERE user_id = ? AND confirmed_at IS NULL`,
+		time.Now().UTC(), userID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryCodeHashes {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO totp_recovery_codes (user_id, code_hash) VALUES (?, ?)`,
+			userID, hash,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// UseTOTPCounter records counter as the last accepted time step. It fails
+// with ErrInvalidTOTPCode unless counter is newer than the stored one.
+func (repo *sqliteRepository) UseTOTPCounter(ctx context.Context, userID int, counter int64) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE user_totp SET last_counter = ? WHERE user_id = ? AND last_counter < ?`,
+		counter, userID, counter,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE totp_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`,
+		time.Now().UTC(), userID, codeHash,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) DeleteTOTP(ctx context.Context, userID int) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) CreateChallenge(ctx context.Context, challenge *LoginChallenge) (int, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO login_challenges (user_id, token_hash, expires_at) VALUES (?, ?, ?)`,
+		challenge.UserID, challenge.TokenHash, challenge.ExpiresAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrInvalidChallenge, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetChallenge(ctx context.Context, tokenHash string) (*LoginChallenge, error) {
+	var challenge LoginChallenge
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT id, user_id, token_hash, expires_at, attempts, used_at, created_at FROM login_challenges WHERE token_hash = ?`,
+		tokenHash,
+	)
+	err := row.Scan(&challenge.ID, &challenge.UserID, &challenge.TokenHash, &challenge.ExpiresAt,
+		&challenge.Attempts, &challenge.UsedAt, &challenge.CreatedAt)
+	if err := dbutil.ProcessRowError(err, ErrInvalidChallenge); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func (repo *sqliteRepository) FailChallenge(ctx context.Context, id int) error {
+	_, err := repo.db.ExecContext(ctx,
+		`UPDATE login_challenges SET attempts = attempts + 1 WHERE id = ?`,
+		id,
+	)
+	return err
+}
+
+func (repo *sqliteRepository) UseChallenge(ctx context.Context, id int) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE login_challenges SET used_at = ? WHERE id = ? AND used_at IS NULL`,
+		time.Now().UTC(), id,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrInvalidChallenge
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('testuser', 'hash')`)
+	require.NoError(t, err)
+
+	return NewSQLiteRepository(db), db, context.Background()
+}
+
+func TestRepository_CreateAndGetByHash(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	id, err := repo.Create(ctx, &RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "hash", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	found, err := repo.GetByHash(ctx, "hash")
+	require.NoError(t, err)
+	require.Equal(t, id, found.ID)
+	require.Equal(t, "family", found.FamilyID)
+	require.True(t, expiresAt.Equal(found.ExpiresAt))
+	require.False(t, found.RevokedAt.Valid)
+
+	_, err = repo.GetByHash(ctx, "unknown")
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRepository_Rotate(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	oldID, err := repo.Create(ctx, &RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "first", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	_, err = repo.Rotate(ctx, oldID, &RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "second", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	old, err := repo.GetByHash(ctx, "first")
+	require.NoError(t, err)
+	require.True(t, old.RevokedAt.Valid)
+
+	_, err = repo.Rotate(ctx, oldID, &RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "third", ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, err = repo.GetByHash(ctx, "third")
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRepository_RevokeFamily(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	for _, token := range []*RefreshToken{
+		{UserID: 1, FamilyID: "family", TokenHash: "first", ExpiresAt: expiresAt},
+		{UserID: 1, FamilyID: "family", TokenHash: "second", ExpiresAt: expiresAt},
+		{UserID: 1, FamilyID: "other", TokenHash: "third", ExpiresAt: expiresAt},
+	} {
+		_, err := repo.Create(ctx, token)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, repo.RevokeFamily(ctx, "family"))
+
+	for hash, revoked := range map[string]bool{"first": true, "second": true, "third": false} {
+		found, err := repo.GetByHash(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, revoked, found.RevokedAt.Valid, hash)
+	}
+}
+
+func TestRepository_RevokeUser(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('otheruser', 'hash')`)
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	for _, token := range []*RefreshToken{
+		{UserID: 1, FamilyID: "first", TokenHash: "first", ExpiresAt: expiresAt},
+		{UserID: 1, FamilyID: "second", TokenHash: "second", ExpiresAt: expiresAt},
+		{UserID: 2, FamilyID: "other", TokenHash: "other", ExpiresAt: expiresAt},
+	} {
+		_, err := repo.Create(ctx, token)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, repo.RevokeUser(ctx, 1))
+
+	for hash, revoked := range map[string]bool{"first": true, "second": true, "other": false} {
+		found, err := repo.GetByHash(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, revoked, found.RevokedAt.Valid, hash)
+	}
+}
+
+func TestRepository_ResetTokens(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	for _, hash := range []string{"first", "second"} {
+		_, err := repo.CreateResetToken(ctx, &PasswordResetToken{UserID: 1, TokenHash: hash, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+	}
+
+	second, err := repo.GetResetToken(ctx, "second")
+	require.NoError(t, err)
+	require.Equal(t, 1, second.UserID)
+	require.False(t, second.UsedAt.Valid)
+
+	require.NoError(t, repo.UseResetToken(ctx, second, "new hash"))
+	require.ErrorIs(t, repo.UseResetToken(ctx, second, "other hash"), ErrInvalidResetToken)
+
+	var passwordHash string
+	require.NoError(t, db.QueryRow(`SELECT password_hash FROM users WHERE id = 1`).Scan(&passwordHash))
+	require.Equal(t, "new hash", passwordHash)
+
+	first, err := repo.GetResetToken(ctx, "first")
+	require.NoError(t, err)
+	require.True(t, first.UsedAt.Valid, "other tokens of the user are spent too")
+
+	_, err = repo.GetResetToken(ctx, "unknown")
+	require.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestRepository_EmailTokens(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash, email) VALUES ('other', 'hash', 'taken@example.com')`)
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	for hash, email := range map[string]string{"first": "new@example.com", "second": "new@example.com", "taken": "taken@example.com"} {
+		_, err := repo.CreateEmailToken(ctx, &EmailChangeToken{UserID: 1, Email: email, TokenHash: hash, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+	}
+
+	taken, err := repo.GetEmailToken(ctx, "taken")
+	require.NoError(t, err)
+	require.ErrorIs(t, repo.UseEmailToken(ctx, taken), ErrEmailTaken)
+
+	second, err := repo.GetEmailToken(ctx, "second")
+	require.NoError(t, err)
+	require.Equal(t, "new@example.com", second.Email)
+	require.False(t, second.UsedAt.Valid)
+
+	require.NoError(t, repo.UseEmailToken(ctx, second))
+	require.ErrorIs(t, repo.UseEmailToken(ctx, second), ErrInvalidEmailToken)
+
+	var email string
+	require.NoError(t, db.QueryRow(`SELECT email FROM users WHERE id = 1`).Scan(&email))
+	require.Equal(t, "new@example.com", email)
+
+	first, err := repo.GetEmailToken(ctx, "first")
+	require.NoError(t, err)
+	require.True(t, first.UsedAt.Valid, "other tokens of the user are spent too")
+
+	_, err = repo.GetEmailToken(ctx, "unknown")
+	require.ErrorIs(t, err, ErrInvalidEmailToken)
+}
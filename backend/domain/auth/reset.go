@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/pkg/logger"
+	"workup_fitness/pkg/mailer"
+)
+
+// RequestPasswordReset emails a reset link to the user registered with
+// email. Unknown addresses and delivery failures are only logged, so the
+// response does not reveal which addresses have an account. Requests are
+// throttled per email and per ip whether or not the address is known.
+func (s *serviceImpl) RequestPasswordReset(ctx context.Context, email, ip string) error {
+	logger.Ctx(ctx).Info().Str("ip", ip).Msg("Requesting password reset")
+
+	if s.resetLimiter != nil {
+		key := normalizeResetEmail(email)
+		if wait := s.resetLimiter.Wait(key, ip); wait > 0 {
+			logger.Ctx(ctx).Warn().Str("ip", ip).Msg("Throttled password reset")
+			return &LoginThrottledError{Wait: wait, Err: ErrTooManyResets}
+		}
+		s.resetLimiter.Fail(key, ip)
+	}
+
+	found, err := s.userService.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			logger.Ctx(ctx).Info().Msg("No user for password reset email")
+			return nil
+		}
+		return err
+	}
+
+	raw, err := randomString(32)
+	if err != nil {
+		return err
+	}
+	token := &PasswordResetToken{
+		UserID:    found.ID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: s.now().Add(s.resetTokenTTL),
+	}
+	if _, err := s.repo.CreateResetToken(ctx, token); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, s.resetMessage(found, raw)); err != nil {
+		logger.Ctx(ctx).Error().Err(err).Int("user_id", found.ID).Msg("Failed to send password reset email")
+		return nil
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", found.ID).Msg("Sent password reset email")
+	return nil
+}
+
+// normalizeResetEmail keys the reset limiter so that case and spacing
+// variants of one address share a counter.
+func normalizeResetEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *serviceImpl) resetMessage(u *user.User, token string) mailer.Message {
+	action := "Use this token to choose a new password: " + token
+	if s.resetURL != "" {
+		action = "Open this link to choose a new password: " + s.resetURL + "?token=" + url.QueryEscape(token)
+	}
+	return mailer.Message{
+		To:      u.Email.String,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your account.\n\n%s\n\n"+
+				"It works once and expires in %s. If you did not ask for this, ignore this email.\n",
+			u.Username.String, action, s.resetTokenTTL,
+		),
+	}
+}
+
+// ResetPassword sets a new password using a token from
+// RequestPasswordReset. The token and all other outstanding reset tokens
+// of the user are spent, and existing sessions are revoked.
+func (s *serviceImpl) ResetPassword(ctx context.Context, token, newPassword string) error {
+	stored, err := s.repo.GetResetToken(ctx, hashToken(token))
+	if err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", stored.UserID).Msg("Resetting password")
+
+	if stored.UsedAt.Valid || !s.now().Before(stored.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	found, err := s.userService.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.Check(found.Username.String, newPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UseResetToken(ctx, stored, string(hashedPassword)); err != nil {
+		return err
+	}
+	if err := s.repo.RevokeUser(ctx, found.ID); err != nil {
+		return err
+	}
+	if s.limiter != nil {
+		s.limiter.Succeed(found.Username.String)
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", found.ID).Msg("Reset password")
+	return nil
+}
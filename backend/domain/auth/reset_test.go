@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/domain/user/mocks"
+	"workup_fitness/pkg/mailer"
+)
+
+type recordingMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+func newResetTestService(t *testing.T) (*serviceImpl, *mocks.MockService, *recordingMailer, Repository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUserService := mocks.NewMockService(ctrl)
+	repo, db, _ := newTestRepository(t)
+	t.Cleanup(func() { db.Close() })
+
+	mail := &recordingMailer{}
+	authService := NewService(mockUserService, repo, Options{
+		RefreshTokenTTL: time.Hour,
+		PasswordPolicy:  PasswordPolicy{MinLength: 8},
+		Mailer:          mail,
+		ResetTokenTTL:   time.Hour,
+		ResetURL:        "https://app.example.com/reset",
+	})
+	return authService, mockUserService, mail, repo
+}
+
+var resetTestUser = &user.User{ID: 1, Username: zero.StringFrom("testuser"), Email: zero.StringFrom("test@example.com")}
+
+// requestResetToken runs RequestPasswordReset and pulls the raw token out of
+// the emailed link.
+func requestResetToken(t *testing.T, authService *serviceImpl, mockUserService *mocks.MockService, mail *recordingMailer) string {
+	t.Helper()
+
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(resetTestUser, nil)
+	require.NoError(t, authService.RequestPasswordReset(context.Background(), "test@example.com", "10.0.0.1"))
+	require.NotEmpty(t, mail.sent)
+
+	msg := mail.sent[len(mail.sent)-1]
+	require.Equal(t, "test@example.com", msg.To)
+	_, link, ok := strings.Cut(msg.Body, "https://app.example.com/reset?token=")
+	require.True(t, ok, msg.Body)
+	token, _, _ := strings.Cut(link, "\n")
+	return token
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	authService, mockUserService, mail, _ := newResetTestService(t)
+
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "nobody@example.com").Return(nil, user.ErrUserNotFound)
+
+	require.NoError(t, authService.RequestPasswordReset(context.Background(), "nobody@example.com", "10.0.0.1"))
+	require.Empty(t, mail.sent)
+}
+
+func TestRequestPasswordReset_MailerErrorIsHidden(t *testing.T) {
+	authService, mockUserService, mail, _ := newResetTestService(t)
+	mail.err = errors.New("connection refused")
+
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(resetTestUser, nil)
+
+	require.NoError(t, authService.RequestPasswordReset(context.Background(), "test@example.com", "10.0.0.1"))
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	authService, mockUserService, mail, repo := newResetTestService(t)
+	ctx := context.Background()
+
+	refreshToken, err := authService.IssueRefreshToken(ctx, 1)
+	require.NoError(t, err)
+	first := requestResetToken(t, authService, mockUserService, mail)
+	second := requestResetToken(t, authService, mockUserService, mail)
+
+	mockUserService.EXPECT().GetByID(gomock.Any(), 1).Return(resetTestUser, nil)
+
+	require.NoError(t, authService.ResetPassword(ctx, second, "correct horse"))
+
+	var passwordHash string
+	require.NoError(t, repo.(*sqliteRepository).db.QueryRow(`SELECT password_hash FROM users WHERE id = 1`).Scan(&passwordHash))
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte("correct horse")))
+
+	stored, err := repo.GetByHash(ctx, hashToken(refreshToken))
+	require.NoError(t, err)
+	require.True(t, stored.RevokedAt.Valid)
+
+	require.ErrorIs(t, authService.ResetPassword(ctx, second, "correct horse"), ErrInvalidResetToken)
+	require.ErrorIs(t, authService.ResetPassword(ctx, first, "correct horse"), ErrInvalidResetToken)
+}
+
+func TestResetPassword_Expired(t *testing.T) {
+	authService, mockUserService, mail, _ := newResetTestService(t)
+
+	token := requestResetToken(t, authService, mockUserService, mail)
+	authService.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	require.ErrorIs(t, authService.ResetPassword(context.Background(), token, "correct horse"), ErrInvalidResetToken)
+}
+
+func TestResetPassword_WeakPasswordKeepsToken(t *testing.T) {
+	authService, mockUserService, mail, _ := newResetTestService(t)
+	ctx := context.Background()
+
+	token := requestResetToken(t, authService, mockUserService, mail)
+
+	mockUserService.EXPECT().GetByID(gomock.Any(), 1).Return(resetTestUser, nil).Times(2)
+
+	require.ErrorIs(t, authService.ResetPassword(ctx, token, "short"), ErrWeakPassword)
+	require.NoError(t, authService.ResetPassword(ctx, token, "correct horse"))
+}
+
+func TestResetPassword_UnknownToken(t *testing.T) {
+	authService, _, _, _ := newResetTestService(t)
+
+	require.ErrorIs(t, authService.ResetPassword(context.Background(), "unknown", "correct horse"), ErrInvalidResetToken)
+}
+
+func TestRequestPasswordReset_Throttled(t *testing.T) {
+	authService, mockUserService, mail, _ := newResetTestService(t)
+	authService.resetLimiter = NewLoginLimiter(LoginLimits{
+		BaseDelay:       time.Minute,
+		MaxDelay:        time.Minute,
+		MaxFailures:     5,
+		IPMaxFailures:   20,
+		LockoutDuration: time.Hour,
+	})
+	ctx := context.Background()
+
+	mockUserService.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(resetTestUser, nil)
+
+	require.NoError(t, authService.RequestPasswordReset(ctx, "test@example.com", "10.0.0.1"))
+
+	err := authService.RequestPasswordReset(ctx, " Test@Example.com", "10.0.0.2")
+	require.ErrorIs(t, err, ErrTooManyResets)
+	require.NotErrorIs(t, err, ErrTooManyAttempts)
+	var throttled *LoginThrottledError
+	require.ErrorAs(t, err, &throttled)
+	require.Equal(t, time.Minute, throttled.RetryAfter())
+
+	err = authService.RequestPasswordReset(ctx, "nobody@example.com", "10.0.0.1")
+	require.ErrorIs(t, err, ErrTooManyResets, "the address is throttled too")
+	require.Len(t, mail.sent, 1)
+}
@@ -1,10 +1,29 @@
 package auth
 
 import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
 	"github.com/go-chi/chi/v5"
 )
 
-func RegisterRoutes(r chi.Router, h *Handler) {
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
 	r.Post("/users/register", h.Register)
 	r.Post("/users/login", h.Login)
+	r.Post("/users/login/2fa", h.CompleteLogin)
+	r.Post("/users/refresh", h.Refresh)
+	r.Post("/users/logout", h.Logout)
+	r.Post("/users/password/forgot", h.ForgotPassword)
+	r.Post("/users/password/reset", h.ResetPassword)
+	r.Post("/users/email/confirm", h.ConfirmEmail)
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireSession)
+		r.Post("/users/password", h.ChangePassword)
+		r.Post("/users/email", h.ChangeEmail)
+		r.Post("/users/2fa/enroll", h.EnrollTOTP)
+		r.Post("/users/2fa/confirm", h.ConfirmTOTP)
+		r.Post("/users/2fa/disable", h.DisableTOTP)
+	})
 }
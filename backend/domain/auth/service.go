@@ -3,43 +3,133 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
-	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 
 	"workup_fitness/domain/user"
+	"workup_fitness/pkg/logger"
+	"workup_fitness/pkg/mailer"
 )
 
 //go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/auth Service
 
 type UserService interface {
 	Create(ctx context.Context, username, passwordHash string) (*user.User, error)
+	GetByID(ctx context.Context, id int) (*user.User, error)
 	GetByUsername(ctx context.Context, username string) (*user.User, error)
+	GetByEmail(ctx context.Context, email string) (*user.User, error)
+	UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error
 }
 
 type Service interface {
 	Register(ctx context.Context, username, password string) (*user.User, error)
-	Login(ctx context.Context, username, password string) (*user.User, error)
+	Login(ctx context.Context, username, password, ip string) (*user.User, error)
+	IssueRefreshToken(ctx context.Context, userID int) (string, error)
+	Refresh(ctx context.Context, refreshToken string) (*user.User, string, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) (*user.User, error)
+	RequestPasswordReset(ctx context.Context, email, ip string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	RequestEmailChange(ctx context.Context, userID int, currentPassword, email string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
+	EnrollTOTP(ctx context.Context, userID int) (secret, uri string, err error)
+	ConfirmTOTP(ctx context.Context, userID int, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID int, password, code string) error
+	StartLoginChallenge(ctx context.Context, userID int) (string, error)
+	CompleteLogin(ctx context.Context, challengeToken, code, ip string) (*user.User, error)
+}
+
+// Options configures the auth service.
+type Options struct {
+	RefreshTokenTTL time.Duration
+	// LoginLimiter throttles failed logins. Nil disables throttling.
+	LoginLimiter   *LoginLimiter
+	PasswordPolicy PasswordPolicy
+
+	// Mailer delivers password reset and email confirmation messages and
+	// defaults to logging them.
+	Mailer        mailer.Mailer
+	ResetTokenTTL time.Duration
+	// ResetLimiter throttles reset requests by email and client address.
+	// Every request counts as a failure. Nil disables throttling.
+	ResetLimiter *LoginLimiter
+	// ResetURL is the page that takes a reset token in its token query
+	// parameter. Without it the email contains the bare token.
+	ResetURL string
+	// EmailTokenTTL is how long a new address may wait for confirmation.
+	EmailTokenTTL time.Duration
+	// EmailURL is the page that takes an email confirmation token in its
+	// token query parameter, like ResetURL.
+	EmailURL string
+
+	// TOTPIssuer names the service in authenticator apps.
+	TOTPIssuer string
+	// ChallengeTTL is how long the second login step may take.
+	ChallengeTTL time.Duration
 }
 
 type serviceImpl struct {
-	userService UserService
+	userService     UserService
+	repo            Repository
+	refreshTokenTTL time.Duration
+	limiter         *LoginLimiter
+	policy          PasswordPolicy
+	mailer          mailer.Mailer
+	resetTokenTTL   time.Duration
+	resetLimiter    *LoginLimiter
+	resetURL        string
+	emailTokenTTL   time.Duration
+	emailURL        string
+	totpIssuer      string
+	challengeTTL    time.Duration
+	now             func() time.Time
 }
 
-func NewService(service UserService) *serviceImpl {
-	log.Info().Msg("Creating auth service...")
-	defer log.Info().Msg("Created auth service")
-	return &serviceImpl{userService: service}
+func NewService(service UserService, repo Repository, opts Options) *serviceImpl {
+	logger.Default().Debug().Msg("Creating auth service...")
+	defer logger.Default().Debug().Msg("Created auth service")
+	if opts.Mailer == nil {
+		opts.Mailer = mailer.LogMailer{}
+	}
+	return &serviceImpl{
+		userService:     service,
+		repo:            repo,
+		refreshTokenTTL: opts.RefreshTokenTTL,
+		limiter:         opts.LoginLimiter,
+		policy:          opts.PasswordPolicy,
+		mailer:          opts.Mailer,
+		resetTokenTTL:   opts.ResetTokenTTL,
+		resetLimiter:    opts.ResetLimiter,
+		resetURL:        opts.ResetURL,
+		emailTokenTTL:   opts.EmailTokenTTL,
+		emailURL:        opts.EmailURL,
+		totpIssuer:      opts.TOTPIssuer,
+		challengeTTL:    opts.ChallengeTTL,
+		now:             time.Now,
+	}
 }
 
+// dummyHash is compared against when a login names an unknown user. It is
+// built lazily with the same cost as real password hashes.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("workup-dummy-password"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
 func (s *serviceImpl) Register(ctx context.Context, username, password string) (*user.User, error) {
-	log.Info().Msgf("Register user with username %s", username)
+	logger.Ctx(ctx).Info().Str("username", username).Msg("Registering user")
 
 	if username == "" {
 		return nil, errors.Join(ErrMissingField, errors.New("username is required"))
 	}
-	if password == "" {
-		return nil, errors.Join(ErrMissingField, errors.New("password is required"))
+	if err := s.policy.Check(username, password); err != nil {
+		return nil, err
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -52,25 +142,208 @@ func (s *serviceImpl) Register(ctx context.Context, username, password string) (
 		return nil, err
 	}
 
-	log.Info().Msgf("Registered user with username %s", username)
+	registrationsTotal.Inc()
+	logger.Ctx(ctx).Info().Int("user_id", user.ID).Msg("Registered user")
 
 	return user, nil
 }
 
-func (s *serviceImpl) Login(ctx context.Context, username, password string) (*user.User, error) {
-	log.Info().Msgf("Logging in user with username %s", username)
+// Login checks username and password. Attempts from ip are throttled and
+// every rejected one is written to the login failure audit trail. Users
+// with two-factor login enabled still need StartLoginChallenge and
+// CompleteLogin before they get tokens.
+func (s *serviceImpl) Login(ctx context.Context, username, password, ip string) (*user.User, error) {
+	logger.Ctx(ctx).Info().Str("username", username).Str("ip", ip).Msg("Logging in user")
 
-	user, err := s.userService.GetByUsername(ctx, username)
+	if s.limiter != nil {
+		if wait := s.limiter.Wait(username, ip); wait > 0 {
+			loginsTotal.Inc("throttled")
+			s.recordLoginFailure(ctx, username, ip, LoginFailureThrottled)
+			return nil, &LoginThrottledError{Wait: wait}
+		}
+	}
+
+	found, err := s.userService.GetByUsername(ctx, username)
 	if err != nil {
+		if !errors.Is(err, user.ErrUserNotFound) {
+			return nil, err
+		}
+		// Pay for a hash comparison anyway so response times do not
+		// reveal which usernames exist.
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		s.loginFailed(ctx, username, ip, LoginFailureUnknownUser)
 		return nil, ErrInvalidCreds
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash.String), []byte(password))
+	err = bcrypt.CompareHashAndPassword([]byte(found.PasswordHash.String), []byte(password))
 	if err != nil {
+		s.loginFailed(ctx, username, ip, LoginFailureWrongPassword)
 		return nil, ErrInvalidCreds
 	}
 
-	log.Info().Msgf("Logged in user with username %s", username)
+	// With two-factor login the counter is only reset once the code is
+	// right, so a leaked password does not buy unlimited code guesses.
+	if s.limiter != nil {
+		enabled, err := s.twoFactorEnabled(ctx, found.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			s.limiter.Succeed(username)
+		}
+	}
+	loginsTotal.Inc("success")
+	logger.Ctx(ctx).Info().Int("user_id", found.ID).Msg("Logged in user")
 
-	return user, nil
+	return found, nil
+}
+
+func (s *serviceImpl) loginFailed(ctx context.Context, username, ip, reason string) {
+	loginsTotal.Inc("failure")
+	if s.limiter != nil {
+		s.limiter.Fail(username, ip)
+	}
+	s.recordLoginFailure(ctx, username, ip, reason)
+}
+
+// recordLoginFailure writes the audit record. A failing audit write is
+// logged but does not change the outcome of the login.
+func (s *serviceImpl) recordLoginFailure(ctx context.Context, username, ip, reason string) {
+	logger.Ctx(ctx).Warn().Str("username", username).Str("ip", ip).Str("reason", reason).Msg("Login failed")
+	err := s.repo.RecordLoginFailure(ctx, &LoginFailure{Username: username, IP: ip, Reason: reason})
+	if err != nil {
+		logger.Ctx(ctx).Error().Err(err).Msg("Failed to record login failure")
+	}
+}
+
+func (s *serviceImpl) newRefreshToken(userID int, familyID string) (string, *RefreshToken, error) {
+	raw, err := randomString(32)
+	if err != nil {
+		return "", nil, err
+	}
+	token := &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: s.now().Add(s.refreshTokenTTL),
+	}
+	return raw, token, nil
+}
+
+// IssueRefreshToken starts a new refresh token family for userID.
+func (s *serviceImpl) IssueRefreshToken(ctx context.Context, userID int) (string, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Issuing refresh token")
+
+	familyID, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+	raw, token, err := s.newRefreshToken(userID, familyID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.repo.Create(ctx, token); err != nil {
+		return "", err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Issued refresh token")
+	return raw, nil
+}
+
+// Refresh exchanges a refresh token for a new one from the same family.
+// Presenting an already rotated token revokes the whole family, since either
+// the client or an attacker holds a stolen copy.
+func (s *serviceImpl) Refresh(ctx context.Context, refreshToken string) (*user.User, string, error) {
+	token, err := s.repo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", token.UserID).Msg("Refreshing tokens")
+
+	if token.RevokedAt.Valid {
+		return nil, "", s.revokeReusedFamily(ctx, token)
+	}
+	if !s.now().Before(token.ExpiresAt) {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	user, err := s.userService.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	raw, next, err := s.newRefreshToken(token.UserID, token.FamilyID)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := s.repo.Rotate(ctx, token.ID, next); err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) {
+			return nil, "", s.revokeReusedFamily(ctx, token)
+		}
+		return nil, "", err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", token.UserID).Msg("Refreshed tokens")
+	return user, raw, nil
+}
+
+func (s *serviceImpl) revokeReusedFamily(ctx context.Context, token *RefreshToken) error {
+	logger.Ctx(ctx).Warn().Int("user_id", token.UserID).Msg("Refresh token reuse detected, revoking family")
+	if err := s.repo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (s *serviceImpl) Logout(ctx context.Context, refreshToken string) error {
+	token, err := s.repo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", token.UserID).Msg("Logging out user")
+	if err := s.repo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("user_id", token.UserID).Msg("Logged out user")
+	return nil
+}
+
+// ChangePassword replaces the password of userID after checking the current
+// one, then revokes every refresh token so other sessions have to log in
+// again.
+func (s *serviceImpl) ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) (*user.User, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Changing password")
+
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(found.PasswordHash.String), []byte(currentPassword))
+	if err != nil {
+		logger.Ctx(ctx).Warn().Int("user_id", userID).Msg("Wrong current password")
+		return nil, ErrWrongPassword
+	}
+	if newPassword == currentPassword {
+		return nil, fmt.Errorf("%w: must differ from the current password", ErrWeakPassword)
+	}
+	if err := s.policy.Check(found.Username.String, newPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userService.UpdatePasswordHash(ctx, userID, string(hashedPassword)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RevokeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Changed password")
+	return found, nil
 }
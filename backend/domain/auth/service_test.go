@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,12 +33,12 @@ func TestRegister_Success(t *testing.T) {
 			}, nil
 		})
 
-	authService := NewService(mockUserService)
+	authService := NewService(mockUserService, nil, Options{RefreshTokenTTL: time.Hour})
 
 	result, err := authService.Register(context.Background(), "testuser", "password123")
 
 	require.NoError(t, err)
-	require.Equal(t, "testuser", result.Username)
+	require.Equal(t, "testuser", result.Username.String)
 	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(result.PasswordHash.String), []byte("password123")))
 }
 
@@ -46,7 +47,7 @@ func TestRegister_EmptyUsername(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserService := mocks.NewMockService(ctrl)
-	authService := NewService(mockUserService)
+	authService := NewService(mockUserService, nil, Options{RefreshTokenTTL: time.Hour})
 
 	result, err := authService.Register(context.Background(), "", "password123")
 
@@ -60,7 +61,7 @@ func TestRegister_EmptyPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserService := mocks.NewMockService(ctrl)
-	authService := NewService(mockUserService)
+	authService := NewService(mockUserService, nil, Options{RefreshTokenTTL: time.Hour})
 
 	result, err := authService.Register(context.Background(), "testuser", "")
 
@@ -79,7 +80,7 @@ func TestRegister_UserServiceError(t *testing.T) {
 		Create(gomock.Any(), "testuser", gomock.Any()).
 		Return(nil, user.ErrAlreadyExists)
 
-	authService := NewService(mockUserService)
+	authService := NewService(mockUserService, nil, Options{RefreshTokenTTL: time.Hour})
 
 	result, err := authService.Register(context.Background(), "testuser", "password123")
 
@@ -106,13 +107,15 @@ func TestLogin_Success(t *testing.T) {
 		GetByUsername(gomock.Any(), "testuser").
 		Return(expectedUser, nil)
 
-	authService := NewService(mockUserService)
+	authService := NewService(mockUserService, nil, Options{RefreshTokenTTL: time.Hour})
+	successes := loginsTotal.Value("success")
 
-	result, err := authService.Login(context.Background(), "testuser", "password123")
+	result, err := authService.Login(context.Background(), "testuser", "password123", "10.0.0.1")
 
 	require.NoError(t, err)
 	require.Equal(t, expectedUser.Username, result.Username)
 	require.Equal(t, expectedUser.ID, result.ID)
+	require.Equal(t, successes+1, loginsTotal.Value("success"))
 }
 
 func TestLogin_UserNotFound(t *testing.T) {
@@ -125,15 +128,23 @@ func TestLogin_UserNotFound(t *testing.T) {
 		GetByUsername(gomock.Any(), "nonexistent").
 		Return(nil, user.ErrUserNotFound)
 
-	authService := NewService(mockUserService)
+	repo, db, _ := newTestRepository(t)
+	defer db.Close()
+	authService := NewService(mockUserService, repo, Options{RefreshTokenTTL: time.Hour})
 
-	result, err := authService.Login(context.Background(), "nonexistent", "password123")
+	result, err := authService.Login(context.Background(), "nonexistent", "password123", "10.0.0.1")
 
 	require.Error(t, err)
 	require.Nil(t, result)
 	require.ErrorIs(t, err, ErrInvalidCreds)
 }
 
+func TestDummyHash_MatchesRealCost(t *testing.T) {
+	cost, err := bcrypt.Cost(dummyHash())
+	require.NoError(t, err)
+	require.Equal(t, bcrypt.DefaultCost, cost, "unknown usernames must cost as much as real ones")
+}
+
 func TestLogin_WrongPassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -150,13 +161,17 @@ func TestLogin_WrongPassword(t *testing.T) {
 			CreatedAt:    time.Now(),
 		}, nil)
 
-	authService := NewService(mockUserService)
+	repo, db, _ := newTestRepository(t)
+	defer db.Close()
+	authService := NewService(mockUserService, repo, Options{RefreshTokenTTL: time.Hour})
+	failures := loginsTotal.Value("failure")
 
-	result, err := authService.Login(context.Background(), "testuser", "wrongpassword")
+	result, err := authService.Login(context.Background(), "testuser", "wrongpassword", "10.0.0.1")
 
 	require.Error(t, err)
 	require.Nil(t, result)
 	require.ErrorIs(t, err, ErrInvalidCreds)
+	require.Equal(t, failures+1, loginsTotal.Value("failure"))
 }
 
 func TestLogin_ServiceError(t *testing.T) {
@@ -169,11 +184,212 @@ func TestLogin_ServiceError(t *testing.T) {
 		GetByUsername(gomock.Any(), "testuser").
 		Return(nil, errors.New("database connection error"))
 
-	authService := NewService(mockUserService)
+	authService := NewService(mockUserService, nil, Options{RefreshTokenTTL: time.Hour})
 
-	result, err := authService.Login(context.Background(), "testuser", "password123")
+	result, err := authService.Login(context.Background(), "testuser", "password123", "10.0.0.1")
 
 	require.Error(t, err)
 	require.Nil(t, result)
-	require.ErrorIs(t, err, ErrInvalidCreds)
+	require.NotErrorIs(t, err, ErrInvalidCreds)
+}
+
+func TestLogin_ThrottledAfterFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockService(ctrl)
+	mockUserService.EXPECT().
+		GetByUsername(gomock.Any(), "nonexistent").
+		Return(nil, user.ErrUserNotFound).
+		Times(2)
+
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	limiter := NewLoginLimiter(LoginLimits{
+		FreeAttempts:    1,
+		BaseDelay:       time.Minute,
+		MaxDelay:        time.Hour,
+		MaxFailures:     10,
+		IPMaxFailures:   10,
+		LockoutDuration: time.Hour,
+	})
+	authService := NewService(mockUserService, repo, Options{RefreshTokenTTL: time.Hour, LoginLimiter: limiter})
+
+	for range 2 {
+		_, err := authService.Login(ctx, "nonexistent", "password123", "10.0.0.1")
+		require.ErrorIs(t, err, ErrInvalidCreds)
+	}
+
+	_, err := authService.Login(ctx, "nonexistent", "password123", "10.0.0.1")
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+	var throttled *LoginThrottledError
+	require.ErrorAs(t, err, &throttled)
+	require.Equal(t, time.Minute, throttled.RetryAfter())
+
+	var reasons []string
+	rows, err := db.Query(`SELECT reason FROM login_failures WHERE username = 'nonexistent' ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var reason string
+		require.NoError(t, rows.Scan(&reason))
+		reasons = append(reasons, reason)
+	}
+	require.Equal(t, []string{LoginFailureUnknownUser, LoginFailureUnknownUser, LoginFailureThrottled}, reasons)
+}
+
+func newRefreshTestService(t *testing.T) (*serviceImpl, *mocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUserService := mocks.NewMockService(ctrl)
+	repo, db, _ := newTestRepository(t)
+	t.Cleanup(func() { db.Close() })
+
+	return NewService(mockUserService, repo, Options{RefreshTokenTTL: time.Hour}), mockUserService
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	authService, mockUserService := newRefreshTestService(t)
+	ctx := context.Background()
+
+	mockUserService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1, Username: zero.StringFrom("testuser")}, nil)
+
+	first, err := authService.IssueRefreshToken(ctx, 1)
+	require.NoError(t, err)
+
+	result, second, err := authService.Refresh(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.ID)
+	require.NotEqual(t, first, second)
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	authService, mockUserService := newRefreshTestService(t)
+	ctx := context.Background()
+
+	mockUserService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1}, nil)
+
+	first, err := authService.IssueRefreshToken(ctx, 1)
+	require.NoError(t, err)
+
+	_, second, err := authService.Refresh(ctx, first)
+	require.NoError(t, err)
+
+	_, _, err = authService.Refresh(ctx, first)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, _, err = authService.Refresh(ctx, second)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+}
+
+func TestRefresh_Expired(t *testing.T) {
+	authService, _ := newRefreshTestService(t)
+	ctx := context.Background()
+
+	token, err := authService.IssueRefreshToken(ctx, 1)
+	require.NoError(t, err)
+
+	authService.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, _, err = authService.Refresh(ctx, token)
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	authService, _ := newRefreshTestService(t)
+
+	_, _, err := authService.Refresh(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestLogout_RevokesFamily(t *testing.T) {
+	authService, _ := newRefreshTestService(t)
+	ctx := context.Background()
+
+	token, err := authService.IssueRefreshToken(ctx, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, authService.Logout(ctx, token))
+
+	_, _, err = authService.Refresh(ctx, token)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+}
+
+func newChangePasswordTestService(t *testing.T) (*serviceImpl, *mocks.MockService, Repository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUserService := mocks.NewMockService(ctrl)
+	repo, db, _ := newTestRepository(t)
+	t.Cleanup(func() { db.Close() })
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	mockUserService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1, Username: zero.StringFrom("testuser"), PasswordHash: zero.StringFrom(string(hashedPassword))}, nil)
+
+	policy := PasswordPolicy{MinLength: 8, Breached: map[string]struct{}{"letmein123": {}}}
+	return NewService(mockUserService, repo, Options{RefreshTokenTTL: time.Hour, PasswordPolicy: policy}), mockUserService, repo
+}
+
+func TestChangePassword_Success(t *testing.T) {
+	authService, mockUserService, repo := newChangePasswordTestService(t)
+	ctx := context.Background()
+
+	token, err := authService.IssueRefreshToken(ctx, 1)
+	require.NoError(t, err)
+
+	var storedHash string
+	mockUserService.EXPECT().
+		UpdatePasswordHash(gomock.Any(), 1, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int, passwordHash string) error {
+			storedHash = passwordHash
+			return nil
+		})
+
+	result, err := authService.ChangePassword(ctx, 1, "password123", "correct horse")
+	require.NoError(t, err)
+	require.Equal(t, 1, result.ID)
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(storedHash), []byte("correct horse")))
+
+	found, err := repo.GetByHash(ctx, hashToken(token))
+	require.NoError(t, err)
+	require.True(t, found.RevokedAt.Valid)
+}
+
+func TestChangePassword_WrongCurrentPassword(t *testing.T) {
+	authService, _, _ := newChangePasswordTestService(t)
+
+	_, err := authService.ChangePassword(context.Background(), 1, "password124", "correct horse")
+	require.ErrorIs(t, err, ErrWrongPassword)
+}
+
+func TestChangePassword_PolicyViolations(t *testing.T) {
+	tests := []struct {
+		name        string
+		newPassword string
+		want        error
+	}{
+		{name: "empty", newPassword: "", want: ErrMissingField},
+		{name: "too short", newPassword: "short", want: ErrWeakPassword},
+		{name: "over bcrypt limit", newPassword: strings.Repeat("x", MaxPasswordBytes+1), want: ErrWeakPassword},
+		{name: "same as current", newPassword: "password123", want: ErrWeakPassword},
+		{name: "same as username", newPassword: "TestUser", want: ErrWeakPassword},
+		{name: "breached", newPassword: "LetMeIn123", want: ErrWeakPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService, _, _ := newChangePasswordTestService(t)
+
+			_, err := authService.ChangePassword(context.Background(), 1, "password123", tt.newPassword)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
 }
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// LoginLimits configures brute-force protection for password logins.
+type LoginLimits struct {
+	// FreeAttempts is how many failures are allowed before backoff starts.
+	FreeAttempts int
+	// BaseDelay is the first backoff; it doubles with every further failure
+	// up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxFailures locks a username out for LockoutDuration. IPMaxFailures
+	// does the same for a client address and is usually higher, since many
+	// users can share one address.
+	MaxFailures     int
+	IPMaxFailures   int
+	LockoutDuration time.Duration
+}
+
+// LoginThrottledError is returned while a username or address is backing
+// off or locked out. It matches Err, which defaults to ErrTooManyAttempts.
+type LoginThrottledError struct {
+	Wait time.Duration
+	Err  error
+}
+
+func (e *LoginThrottledError) sentinel() error {
+	if e.Err == nil {
+		return ErrTooManyAttempts
+	}
+	return e.Err
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry in %d seconds", e.sentinel(), int(e.RetryAfter().Seconds()))
+}
+
+func (e *LoginThrottledError) Is(target error) bool {
+	return target == e.sentinel()
+}
+
+// RetryAfter is picked up by httpx.Error to set the Retry-After header.
+func (e *LoginThrottledError) RetryAfter() time.Duration {
+	return time.Duration(math.Ceil(e.Wait.Seconds())) * time.Second
+}
+
+type loginAttempts struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// LoginLimiter tracks consecutive failed logins in memory, keyed by username
+// and by client address. Counters are forgotten LockoutDuration after the
+// last failure.
+type LoginLimiter struct {
+	limits  LoginLimits
+	mu      sync.Mutex
+	entries map[string]*loginAttempts
+	now     func() time.Time
+}
+
+func NewLoginLimiter(limits LoginLimits) *LoginLimiter {
+	return &LoginLimiter{limits: limits, entries: make(map[string]*loginAttempts), now: time.Now}
+}
+
+func usernameKey(username string) string { return "user:" + username }
+func ipKey(ip string) string             { return "ip:" + ip }
+
+// Wait returns how long the caller must wait before trying username from ip
+// again, or zero if the attempt may proceed.
+func (l *LoginLimiter) Wait(username, ip string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	return max(l.wait(usernameKey(username), now), l.wait(ipKey(ip), now))
+}
+
+func (l *LoginLimiter) wait(key string, now time.Time) time.Duration {
+	entry, ok := l.entries[key]
+	if !ok {
+		return 0
+	}
+	if l.expired(entry, now) {
+		delete(l.entries, key)
+		return 0
+	}
+	if now.Before(entry.blockedUntil) {
+		return entry.blockedUntil.Sub(now)
+	}
+	return 0
+}
+
+func (l *LoginLimiter) expired(entry *loginAttempts, now time.Time) bool {
+	return !now.Before(entry.blockedUntil) && now.Sub(entry.lastFailure) >= l.limits.LockoutDuration
+}
+
+// Fail records a failed attempt against both username and ip.
+func (l *LoginLimiter) Fail(username, ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.fail(usernameKey(username), l.limits.MaxFailures, now)
+	l.fail(ipKey(ip), l.limits.IPMaxFailures, now)
+	l.prune(now)
+}
+
+func (l *LoginLimiter) fail(key string, maxFailures int, now time.Time) {
+	entry, ok := l.entries[key]
+	if !ok || l.expired(entry, now) {
+		entry = &loginAttempts{}
+		l.entries[key] = entry
+	}
+	entry.failures++
+	entry.lastFailure = now
+
+	switch {
+	case entry.failures >= maxFailures:
+		entry.blockedUntil = now.Add(l.limits.LockoutDuration)
+	case entry.failures > l.limits.FreeAttempts:
+		entry.blockedUntil = now.Add(l.backoff(entry.failures - l.limits.FreeAttempts))
+	}
+}
+
+// backoff returns BaseDelay doubled n-1 times, capped at MaxDelay.
+func (l *LoginLimiter) backoff(n int) time.Duration {
+	delay := l.limits.BaseDelay
+	for i := 1; i < n && delay < l.limits.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, l.limits.MaxDelay)
+}
+
+// Succeed clears the failures of username. The address counter is kept so
+// that logging into one's own account does not reset a spraying attacker.
+func (l *LoginLimiter) Succeed(username string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.entries, usernameKey(username))
+}
+
+// pruneThreshold bounds how many entries may pile up before expired ones
+// are swept on the next failure.
+const pruneThreshold = 10000
+
+func (l *LoginLimiter) prune(now time.Time) {
+	if len(l.entries) < pruneThreshold {
+		return
+	}
+	for key, entry := range l.entries {
+		if l.expired(entry, now) {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter() (*LoginLimiter, *time.Time) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewLoginLimiter(LoginLimits{
+		FreeAttempts:    2,
+		BaseDelay:       time.Second,
+		MaxDelay:        4 * time.Second,
+		MaxFailures:     6,
+		IPMaxFailures:   8,
+		LockoutDuration: 10 * time.Minute,
+	})
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestLoginLimiter_BackoffDoublesUpToMax(t *testing.T) {
+	limiter, _ := newTestLimiter()
+
+	var waits []time.Duration
+	for range 5 {
+		limiter.Fail("alice", "10.0.0.1")
+		waits = append(waits, limiter.Wait("alice", "10.0.0.2"))
+	}
+
+	require.Equal(t, []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second}, waits)
+}
+
+func TestLoginLimiter_LocksOutUsername(t *testing.T) {
+	limiter, now := newTestLimiter()
+
+	for range 6 {
+		limiter.Fail("alice", "10.0.0.1")
+	}
+	require.Equal(t, 10*time.Minute, limiter.Wait("alice", "10.0.0.2"))
+	require.Zero(t, limiter.Wait("bob", "10.0.0.2"))
+
+	*now = now.Add(10 * time.Minute)
+	require.Zero(t, limiter.Wait("alice", "10.0.0.2"))
+
+	limiter.Fail("alice", "10.0.0.1")
+	require.Zero(t, limiter.Wait("alice", "10.0.0.2"), "counter restarts after the lockout expires")
+}
+
+func TestLoginLimiter_LocksOutAddress(t *testing.T) {
+	limiter, _ := newTestLimiter()
+
+	for i := range 8 {
+		limiter.Fail(string(rune('a'+i)), "10.0.0.1")
+	}
+	require.Equal(t, 10*time.Minute, limiter.Wait("zed", "10.0.0.1"))
+	require.Zero(t, limiter.Wait("zed", "10.0.0.2"))
+}
+
+func TestLoginLimiter_SucceedKeepsAddressCounter(t *testing.T) {
+	limiter, _ := newTestLimiter()
+
+	for range 3 {
+		limiter.Fail("alice", "10.0.0.1")
+	}
+	limiter.Succeed("alice")
+	require.Zero(t, limiter.Wait("alice", "10.0.0.2"))
+	require.Equal(t, time.Second, limiter.Wait("alice", "10.0.0.1"))
+}
+
+func TestLoginThrottledError_RoundsRetryAfterUp(t *testing.T) {
+	err := &LoginThrottledError{Wait: 1500 * time.Millisecond}
+
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+	require.Equal(t, 2*time.Second, err.RetryAfter())
+}
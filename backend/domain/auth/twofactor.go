@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/pkg/logger"
+	"workup_fitness/pkg/totp"
+)
+
+const (
+	recoveryCodeCount = 10
+	// maxChallengeAttempts is how many wrong codes a login challenge takes
+	// before it is spent and the password has to be entered again.
+	maxChallengeAttempts = 5
+)
+
+// EnrollTOTP generates a new secret for userID and returns it with the
+// otpauth URI for authenticator apps. Two-factor login stays off until
+// ConfirmTOTP proves the authenticator produces matching codes.
+func (s *serviceImpl) EnrollTOTP(ctx context.Context, userID int) (string, string, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Enrolling TOTP")
+
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if enabled {
+		return "", "", ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.repo.SaveTOTP(ctx, &TOTP{UserID: userID, Secret: secret}); err != nil {
+		return "", "", err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Enrolled TOTP")
+	return secret, totp.URI(s.totpIssuer, found.Username.String, secret), nil
+}
+
+// ConfirmTOTP enables two-factor login once code matches the enrolled
+// secret, and returns fresh recovery codes. They are only stored hashed,
+// so this is the one time they can be shown.
+func (s *serviceImpl) ConfirmTOTP(ctx context.Context, userID int, code string) ([]string, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Confirming TOTP")
+
+	stored, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.ConfirmedAt.Valid {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	counter, ok := totp.Validate(stored.Secret, code, s.now())
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+	if err := s.repo.UseTOTPCounter(ctx, userID, counter); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	if err := s.repo.ConfirmTOTP(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Confirmed TOTP")
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor login off. It asks for both the password and
+// a current code or recovery code, so a stolen access token alone is not
+// enough.
+func (s *serviceImpl) DisableTOTP(ctx context.Context, userID int, password, code string) error {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Disabling TOTP")
+
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(found.PasswordHash.String), []byte(password))
+	if err != nil {
+		return ErrWrongPassword
+	}
+
+	stored, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !stored.ConfirmedAt.Valid {
+		return ErrTOTPNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, stored, code); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTOTP(ctx, userID); err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Disabled TOTP")
+	return nil
+}
+
+// StartLoginChallenge returns a challenge token if userID has two-factor
+// login enabled, or an empty string if the password alone is enough.
+func (s *serviceImpl) StartLoginChallenge(ctx context.Context, userID int) (string, error) {
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil || !enabled {
+		return "", err
+	}
+
+	raw, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+	challenge := &LoginChallenge{
+		UserID:    userID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: s.now().Add(s.challengeTTL),
+	}
+	if _, err := s.repo.CreateChallenge(ctx, challenge); err != nil {
+		return "", err
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Started login challenge")
+	return raw, nil
+}
+
+// CompleteLogin exchanges a challenge token and a TOTP or recovery code for
+// the user, finishing a login started with a password. Wrong codes count
+// as failed logins for throttling.
+func (s *serviceImpl) CompleteLogin(ctx context.Context, challengeToken, code, ip string) (*user.User, error) {
+	challenge, err := s.repo.GetChallenge(ctx, hashToken(challengeToken))
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UsedAt.Valid || challenge.Attempts >= maxChallengeAttempts || !s.now().Before(challenge.ExpiresAt) {
+		return nil, ErrInvalidChallenge
+	}
+
+	found, err := s.userService.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	username := found.Username.String
+
+	if s.limiter != nil {
+		if wait := s.limiter.Wait(username, ip); wait > 0 {
+			loginsTotal.Inc("throttled")
+			s.recordLoginFailure(ctx, username, ip, LoginFailureThrottled)
+			return nil, &LoginThrottledError{Wait: wait}
+		}
+	}
+
+	stored, err := s.repo.GetTOTP(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifySecondFactor(ctx, stored, code); err != nil {
+		if !errors.Is(err, ErrInvalidTOTPCode) {
+			return nil, err
+		}
+		if err := s.repo.FailChallenge(ctx, challenge.ID); err != nil {
+			return nil, err
+		}
+		s.loginFailed(ctx, username, ip, LoginFailureWrongCode)
+		return nil, err
+	}
+	if err := s.repo.UseChallenge(ctx, challenge.ID); err != nil {
+		return nil, err
+	}
+
+	if s.limiter != nil {
+		s.limiter.Succeed(username)
+	}
+	logger.Ctx(ctx).Info().Int("user_id", found.ID).Msg("Completed two-factor login")
+	return found, nil
+}
+
+func (s *serviceImpl) twoFactorEnabled(ctx context.Context, userID int) (bool, error) {
+	stored, err := s.repo.GetTOTP(ctx, userID)
+	if errors.Is(err, ErrTOTPNotEnabled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return stored.ConfirmedAt.Valid, nil
+}
+
+// verifySecondFactor accepts a current TOTP code that has not been used yet
+// or an unused recovery code, and spends it.
+func (s *serviceImpl) verifySecondFactor(ctx context.Context, stored *TOTP, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		counter, ok := totp.Validate(stored.Secret, code, s.now())
+		if !ok {
+			return ErrInvalidTOTPCode
+		}
+		return s.repo.UseTOTPCounter(ctx, stored.UserID, counter)
+	}
+
+	err := s.repo.UseRecoveryCode(ctx, stored.UserID, hashToken(normalizeRecoveryCode(code)))
+	if err == nil {
+		logger.Ctx(ctx).Warn().Int("user_id", stored.UserID).Msg("Used recovery code")
+	}
+	return err
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCode returns 80 random bits as xxxx-xxxx-xxxx-xxxx.
+func newRecoveryCode() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryEncoding.EncodeToString(buf))
+	return code[0:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/domain/user/mocks"
+	"workup_fitness/pkg/totp"
+)
+
+type twoFactorTest struct {
+	service *serviceImpl
+	users   *mocks.MockService
+	now     time.Time
+	secret  string
+}
+
+func newTwoFactorTest(t *testing.T) *twoFactorTest {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockUserService := mocks.NewMockService(ctrl)
+	repo, db, _ := newTestRepository(t)
+	t.Cleanup(func() { db.Close() })
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	mockUserService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1, Username: zero.StringFrom("testuser"), PasswordHash: zero.StringFrom(string(hashedPassword))}, nil).
+		AnyTimes()
+
+	tt := &twoFactorTest{users: mockUserService, now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tt.service = NewService(mockUserService, repo, Options{
+		RefreshTokenTTL: time.Hour,
+		TOTPIssuer:      "Workup Fitness",
+		ChallengeTTL:    5 * time.Minute,
+	})
+	tt.service.now = func() time.Time { return tt.now }
+	return tt
+}
+
+// code returns the TOTP code for the next time step, so every call yields
+// a code that has not been used yet.
+func (tt *twoFactorTest) code(t *testing.T) string {
+	t.Helper()
+
+	tt.now = tt.now.Add(totp.Period)
+	code, err := totp.CodeAt(tt.secret, totp.Counter(tt.now))
+	require.NoError(t, err)
+	return code
+}
+
+func (tt *twoFactorTest) enable(t *testing.T) []string {
+	t.Helper()
+
+	secret, uri, err := tt.service.EnrollTOTP(context.Background(), 1)
+	require.NoError(t, err)
+	require.Contains(t, uri, "secret="+secret)
+	tt.secret = secret
+
+	codes, err := tt.service.ConfirmTOTP(context.Background(), 1, tt.code(t))
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	return codes
+}
+
+func TestTwoFactor_EnrollRequiresConfirmation(t *testing.T) {
+	tt := newTwoFactorTest(t)
+	ctx := context.Background()
+
+	secret, _, err := tt.service.EnrollTOTP(ctx, 1)
+	require.NoError(t, err)
+	tt.secret = secret
+
+	challenge, err := tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, challenge, "unconfirmed secrets do not enable two-factor login")
+
+	_, err = tt.service.ConfirmTOTP(ctx, 1, "000000")
+	require.ErrorIs(t, err, ErrInvalidTOTPCode)
+
+	_, err = tt.service.ConfirmTOTP(ctx, 1, tt.code(t))
+	require.NoError(t, err)
+
+	_, _, err = tt.service.EnrollTOTP(ctx, 1)
+	require.ErrorIs(t, err, ErrTOTPAlreadyEnabled)
+}
+
+func TestTwoFactor_CompleteLogin(t *testing.T) {
+	tt := newTwoFactorTest(t)
+	ctx := context.Background()
+	tt.enable(t)
+
+	challenge, err := tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, challenge)
+
+	code := tt.code(t)
+	found, err := tt.service.CompleteLogin(ctx, challenge, code, "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, 1, found.ID)
+
+	_, err = tt.service.CompleteLogin(ctx, challenge, code, "10.0.0.1")
+	require.ErrorIs(t, err, ErrInvalidChallenge, "challenges are single-use")
+
+	challenge, err = tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	_, err = tt.service.CompleteLogin(ctx, challenge, code, "10.0.0.1")
+	require.ErrorIs(t, err, ErrInvalidTOTPCode, "codes are single-use")
+}
+
+func TestTwoFactor_RecoveryCodeWorksOnce(t *testing.T) {
+	tt := newTwoFactorTest(t)
+	ctx := context.Background()
+	codes := tt.enable(t)
+
+	challenge, err := tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	_, err = tt.service.CompleteLogin(ctx, challenge, "  "+codes[0]+" ", "10.0.0.1")
+	require.NoError(t, err)
+
+	challenge, err = tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	_, err = tt.service.CompleteLogin(ctx, challenge, codes[0], "10.0.0.1")
+	require.ErrorIs(t, err, ErrInvalidTOTPCode)
+}
+
+func TestTwoFactor_ChallengeLimits(t *testing.T) {
+	tt := newTwoFactorTest(t)
+	ctx := context.Background()
+	tt.enable(t)
+
+	challenge, err := tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	for range maxChallengeAttempts {
+		_, err := tt.service.CompleteLogin(ctx, challenge, "not-a-code", "10.0.0.1")
+		require.ErrorIs(t, err, ErrInvalidTOTPCode)
+	}
+	_, err = tt.service.CompleteLogin(ctx, challenge, tt.code(t), "10.0.0.1")
+	require.ErrorIs(t, err, ErrInvalidChallenge)
+
+	challenge, err = tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	tt.now = tt.now.Add(10 * time.Minute)
+	_, err = tt.service.CompleteLogin(ctx, challenge, tt.code(t), "10.0.0.1")
+	require.ErrorIs(t, err, ErrInvalidChallenge)
+}
+
+func TestTwoFactor_Disable(t *testing.T) {
+	tt := newTwoFactorTest(t)
+	ctx := context.Background()
+	tt.enable(t)
+
+	require.ErrorIs(t, tt.service.DisableTOTP(ctx, 1, "wrong", tt.code(t)), ErrWrongPassword)
+	require.ErrorIs(t, tt.service.DisableTOTP(ctx, 1, "password123", "000000"), ErrInvalidTOTPCode)
+	require.NoError(t, tt.service.DisableTOTP(ctx, 1, "password123", tt.code(t)))
+
+	challenge, err := tt.service.StartLoginChallenge(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, challenge)
+
+	require.ErrorIs(t, tt.service.DisableTOTP(ctx, 1, "password123", tt.code(t)), ErrTOTPNotEnabled)
+}
@@ -0,0 +1,47 @@
+package bodymetric
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
+
+// EntryRequest creates or replaces an entry. MeasuredAt defaults to now.
+type EntryRequest struct {
+	MeasuredAt time.Time   `json:"measured_at"`
+	WeightKg   null.Float  `json:"weight_kg"`
+	BodyFatPct null.Float  `json:"body_fat_pct"`
+	NeckCm     null.Float  `json:"neck_cm"`
+	ChestCm    null.Float  `json:"chest_cm"`
+	WaistCm    null.Float  `json:"waist_cm"`
+	HipsCm     null.Float  `json:"hips_cm"`
+	ArmCm      null.Float  `json:"arm_cm"`
+	ThighCm    null.Float  `json:"thigh_cm"`
+	CalfCm     null.Float  `json:"calf_cm"`
+	Note       zero.String `json:"note"`
+}
+
+type EntryResponse struct {
+	ID         int         `json:"id"`
+	MeasuredAt string      `json:"measured_at"`
+	WeightKg   null.Float  `json:"weight_kg"`
+	BodyFatPct null.Float  `json:"body_fat_pct"`
+	NeckCm     null.Float  `json:"neck_cm"`
+	ChestCm    null.Float  `json:"chest_cm"`
+	WaistCm    null.Float  `json:"waist_cm"`
+	HipsCm     null.Float  `json:"hips_cm"`
+	ArmCm      null.Float  `json:"arm_cm"`
+	ThighCm    null.Float  `json:"thigh_cm"`
+	CalfCm     null.Float  `json:"calf_cm"`
+	Note       zero.String `json:"note"`
+}
+
+type ListResponse struct {
+	Entries []EntryResponse `json:"entries"`
+}
+
+type TrendResponse struct {
+	TimeZone string       `json:"time_zone"`
+	Points   []TrendPoint `json:"points"`
+}
@@ -0,0 +1,22 @@
+package bodymetric
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrEntryNotFound      = errors.New("body metric entry not found")
+	ErrMissingField       = errors.New("missing field")
+	ErrInvalidPermissions = errors.New("invalid permissions")
+	ErrInvalidEntry       = errors.New("invalid body metric entry")
+)
+
+func init() {
+	httpx.RegisterError(ErrEntryNotFound, http.StatusNotFound, "body_metric_not_found")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPermissions, http.StatusForbidden, "forbidden")
+	httpx.RegisterError(ErrInvalidEntry, http.StatusUnprocessableEntity, "invalid_body_metric")
+}
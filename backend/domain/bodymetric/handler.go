@@ -0,0 +1,317 @@
+package bodymetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating body metric handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created body metric handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+func toEntry(req EntryRequest) *Entry {
+	return &Entry{
+		MeasuredAt: req.MeasuredAt,
+		WeightKg:   req.WeightKg,
+		BodyFatPct: req.BodyFatPct,
+		NeckCm:     req.NeckCm,
+		ChestCm:    req.ChestCm,
+		WaistCm:    req.WaistCm,
+		HipsCm:     req.HipsCm,
+		ArmCm:      req.ArmCm,
+		ThighCm:    req.ThighCm,
+		CalfCm:     req.CalfCm,
+		Note:       req.Note,
+	}
+}
+
+func toEntryResponse(entry *Entry) EntryResponse {
+	return EntryResponse{
+		ID:         entry.ID,
+		MeasuredAt: entry.MeasuredAt.UTC().Format(time.RFC3339),
+		WeightKg:   entry.WeightKg,
+		BodyFatPct: entry.BodyFatPct,
+		NeckCm:     entry.NeckCm,
+		ChestCm:    entry.ChestCm,
+		WaistCm:    entry.WaistCm,
+		HipsCm:     entry.HipsCm,
+		ArmCm:      entry.ArmCm,
+		ThighCm:    entry.ThighCm,
+		CalfCm:     entry.CalfCm,
+		Note:       entry.Note,
+	}
+}
+
+func parseListFilter(r *http.Request) (ListFilter, error) {
+	query := r.URL.Query()
+	var filter ListFilter
+	for key, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		raw := query.Get(key)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, errors.New(key + " must be an RFC 3339 timestamp")
+		}
+		*dst = t
+	}
+	return filter, nil
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req EntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating body metric entry")
+
+	entry, err := h.service.Create(ctx, userID, toEntry(req))
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toEntryResponse(entry)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("body_metric_id", entry.ID).Msg("Created body metric entry")
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	entryID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid body metric id")
+		return
+	}
+
+	entry, err := h.service.GetByID(ctx, userID, entryID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toEntryResponse(entry)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	filter, err := parseListFilter(r)
+	if err != nil {
+		httpx.BadRequest(w, err.Error())
+		return
+	}
+
+	entries, err := h.service.List(ctx, userID, filter)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ListResponse{Entries: make([]EntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, toEntryResponse(entry))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	entryID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid body metric id")
+		return
+	}
+
+	var req EntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("body_metric_id", entryID).Msg("Updating body metric entry")
+
+	entry := toEntry(req)
+	entry.ID = entryID
+	if err := h.service.Update(ctx, userID, entry); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toEntryResponse(entry)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("body_metric_id", entryID).Msg("Updated body metric entry")
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	entryID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid body metric id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("body_metric_id", entryID).Msg("Deleting body metric entry")
+
+	if err := h.service.Delete(ctx, userID, entryID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	logger.Ctx(ctx).Info().Int("body_metric_id", entryID).Msg("Deleted body metric entry")
+}
+
+// Trend returns the smoothed weight of the last days calendar days,
+// 90 by default.
+func (h *Handler) Trend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var days int
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		days, err = strconv.Atoi(raw)
+		if err != nil || days <= 0 {
+			httpx.BadRequest(w, "days must be a positive integer")
+			return
+		}
+	}
+
+	trend, err := h.service.Trend(ctx, userID, days)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := TrendResponse{TimeZone: trend.TimeZone, Points: trend.Points}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
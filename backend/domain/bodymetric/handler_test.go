@@ -0,0 +1,131 @@
+package bodymetric_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/bodymetric"
+	"workup_fitness/domain/bodymetric/mocks"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, entryID string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if entryID != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", entryID)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := bodymetric.NewHandler(mockService)
+	measuredAt := time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, &bodymetric.Entry{MeasuredAt: measuredAt, WeightKg: null.FloatFrom(81.4)}).
+		Return(&bodymetric.Entry{ID: 5, UserID: 1, MeasuredAt: measuredAt, WeightKg: null.FloatFrom(81.4)}, nil)
+
+	body, _ := json.Marshal(bodymetric.EntryRequest{MeasuredAt: measuredAt, WeightKg: null.FloatFrom(81.4)})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/body-metrics", body, 1, ""))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp bodymetric.EntryResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 5, resp.ID)
+	require.Equal(t, "2026-03-01T07:30:00Z", resp.MeasuredAt)
+	require.Equal(t, null.FloatFrom(81.4), resp.WeightKg)
+}
+
+func TestCreate_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := bodymetric.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, gomock.Any()).
+		Return(nil, bodymetric.ErrInvalidEntry)
+
+	rr := httptest.NewRecorder()
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/body-metrics", []byte(`{"weight_kg": 1}`), 1, ""))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestList_Filter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := bodymetric.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any(), 1, bodymetric.ListFilter{From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}).
+		Return([]*bodymetric.Entry{{ID: 2, WeightKg: null.FloatFrom(80)}}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.List(rr, newAuthedRequest(http.MethodGet, "/body-metrics?from=2026-03-01T00:00:00Z", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp bodymetric.ListResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Entries, 1)
+
+	rr = httptest.NewRecorder()
+	handler.List(rr, newAuthedRequest(http.MethodGet, "/body-metrics?to=yesterday", nil, 1, ""))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := bodymetric.NewHandler(mockService)
+
+	mockService.EXPECT().Delete(gomock.Any(), 1, 7).Return(bodymetric.ErrEntryNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.Delete(rr, newAuthedRequest(http.MethodDelete, "/body-metrics/7", nil, 1, "7"))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestTrend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := bodymetric.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Trend(gomock.Any(), 1, 30).
+		Return(&bodymetric.Trend{
+			TimeZone: "Europe/Berlin",
+			Points:   []bodymetric.TrendPoint{{Date: "2026-03-01", WeightKg: 80, TrendKg: 80.2, WeeklyRateKg: null.FloatFrom(-0.3)}},
+		}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Trend(rr, newAuthedRequest(http.MethodGet, "/body-metrics/trend?days=30", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"time_zone":"Europe/Berlin","points":[{"date":"2026-03-01","weight_kg":80,"trend_kg":80.2,"weekly_rate_kg":-0.3}]}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	handler.Trend(rr, newAuthedRequest(http.MethodGet, "/body-metrics/trend?days=-1", nil, 1, ""))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,61 @@
+package bodymetric
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
+
+// Entry is one weigh-in or set of measurements. Weights are in kilograms
+// and circumferences in centimeters; unset values were not measured.
+type Entry struct {
+	ID         int         `json:"id"`
+	UserID     int         `json:"user_id"`
+	MeasuredAt time.Time   `json:"measured_at"`
+	WeightKg   null.Float  `json:"weight_kg"`
+	BodyFatPct null.Float  `json:"body_fat_pct"`
+	NeckCm     null.Float  `json:"neck_cm"`
+	ChestCm    null.Float  `json:"chest_cm"`
+	WaistCm    null.Float  `json:"waist_cm"`
+	HipsCm     null.Float  `json:"hips_cm"`
+	ArmCm      null.Float  `json:"arm_cm"`
+	ThighCm    null.Float  `json:"thigh_cm"`
+	CalfCm     null.Float  `json:"calf_cm"`
+	Note       zero.String `json:"note"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// circumferences lists the measurements in centimeters by name, for
+// validation.
+func (e *Entry) circumferences() map[string]null.Float {
+	return map[string]null.Float{
+		"neck_cm":  e.NeckCm,
+		"chest_cm": e.ChestCm,
+		"waist_cm": e.WaistCm,
+		"hips_cm":  e.HipsCm,
+		"arm_cm":   e.ArmCm,
+		"thigh_cm": e.ThighCm,
+		"calf_cm":  e.CalfCm,
+	}
+}
+
+type ListFilter struct {
+	// From and To bound MeasuredAt; zero values leave that side open.
+	From time.Time
+	To   time.Time
+}
+
+// TrendPoint is the smoothed weight of one calendar day in the user's time
+// zone. WeightKg is the mean of that day's weigh-ins.
+type TrendPoint struct {
+	Date         string     `json:"date"`
+	WeightKg     float64    `json:"weight_kg"`
+	TrendKg      float64    `json:"trend_kg"`
+	WeeklyRateKg null.Float `json:"weekly_rate_kg"`
+}
+
+type Trend struct {
+	TimeZone string       `json:"time_zone"`
+	Points   []TrendPoint `json:"points"`
+}
@@ -0,0 +1,133 @@
+package bodymetric
+
+import (
+	"context"
+	"database/sql"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/bodymetric Repository
+
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) (int, error)
+	GetByID(ctx context.Context, id int) (*Entry, error)
+	ListByUserID(ctx context.Context, userID int, filter ListFilter) ([]*Entry, error)
+	Update(ctx context.Context, entry *Entry) error
+	Delete(ctx context.Context, id int) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+const selectEntry = `SELECT id, user_id, measured_at, weight_kg, body_fat_pct, neck_cm, chest_cm, waist_cm,
+	hips_cm, arm_cm, thigh_cm, calf_cm, note, created_at FROM body_metrics`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row scanner) (*Entry, error) {
+	var entry Entry
+	err := row.Scan(&entry.ID, &entry.UserID, &entry.MeasuredAt, &entry.WeightKg, &entry.BodyFatPct,
+		&entry.NeckCm, &entry.ChestCm, &entry.WaistCm, &entry.HipsCm, &entry.ArmCm, &entry.ThighCm,
+		&entry.CalfCm, &entry.Note, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (repo *sqliteRepository) Create(ctx context.Context, entry *Entry) (int, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO body_metrics (user_id, measured_at, weight_kg, body_fat_pct, neck_cm, chest_cm, waist_cm,
+			hips_cm, arm_cm, thigh_cm, calf_cm, note) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.UserID, entry.MeasuredAt.UTC(), entry.WeightKg, entry.BodyFatPct, entry.NeckCm, entry.ChestCm,
+		entry.WaistCm, entry.HipsCm, entry.ArmCm, entry.ThighCm, entry.CalfCm, entry.Note,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrMissingField, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetByID(ctx context.Context, id int) (*Entry, error) {
+	entry, err := scanEntry(repo.db.QueryRowContext(ctx, selectEntry+` WHERE id = ?`, id))
+	if err := dbutil.ProcessRowError(err, ErrEntryNotFound); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListByUserID returns the entries of userID in filter's range, oldest
+// first.
+func (repo *sqliteRepository) ListByUserID(ctx context.Context, userID int, filter ListFilter) ([]*Entry, error) {
+	query := selectEntry + ` WHERE user_id = ?`
+	args := []any{userID}
+	if !filter.From.IsZero() {
+		query += ` AND measured_at >= ?`
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		query += ` AND measured_at < ?`
+		args = append(args, filter.To.UTC())
+	}
+	query += ` ORDER BY measured_at, id`
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*Entry, 0)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (repo *sqliteRepository) Update(ctx context.Context, entry *Entry) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE body_metrics SET measured_at = ?, weight_kg = ?, body_fat_pct = ?, neck_cm = ?, chest_cm = ?,
+			waist_cm = ?, hips_cm = ?, arm_cm = ?, thigh_cm = ?, calf_cm = ?, note = ? WHERE id = ?`,
+		entry.MeasuredAt.UTC(), entry.WeightKg, entry.BodyFatPct, entry.NeckCm, entry.ChestCm, entry.WaistCm,
+		entry.HipsCm, entry.ArmCm, entry.ThighCm, entry.CalfCm, entry.Note, entry.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		err = ErrEntryNotFound
+	}
+	return err
+}
+
+func (repo *sqliteRepository) Delete(ctx context.Context, id int) error {
+	result, err := repo.db.ExecContext(ctx, `DELETE FROM body_metrics WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		err = ErrEntryNotFound
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package bodymetric_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/bodymetric"
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (bodymetric.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := bodymetric.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_CreateAndGetByID(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	measuredAt := time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC)
+	id, err := repo.Create(ctx, &bodymetric.Entry{
+		UserID:     1,
+		MeasuredAt: measuredAt,
+		WeightKg:   null.FloatFrom(81.4),
+		WaistCm:    null.FloatFrom(84),
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 1, found.UserID)
+	require.True(t, measuredAt.Equal(found.MeasuredAt))
+	require.Equal(t, null.FloatFrom(81.4), found.WeightKg)
+	require.Equal(t, null.FloatFrom(84), found.WaistCm)
+	require.False(t, found.ChestCm.Valid)
+
+	_, err = repo.GetByID(ctx, id+1)
+	require.ErrorIs(t, err, bodymetric.ErrEntryNotFound)
+}
+
+func TestRepository_ListByUserID(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	day := time.Date(2026, 3, 1, 7, 0, 0, 0, time.UTC)
+	for _, entry := range []*bodymetric.Entry{
+		{UserID: 1, MeasuredAt: day.AddDate(0, 0, 2), WeightKg: null.FloatFrom(80)},
+		{UserID: 1, MeasuredAt: day, WeightKg: null.FloatFrom(81)},
+		{UserID: 1, MeasuredAt: day.AddDate(0, 0, 1), WeightKg: null.FloatFrom(80.5)},
+		{UserID: 2, MeasuredAt: day, WeightKg: null.FloatFrom(60)},
+	} {
+		_, err := repo.Create(ctx, entry)
+		require.NoError(t, err)
+	}
+
+	entries, err := repo.ListByUserID(ctx, 1, bodymetric.ListFilter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, 81.0, entries[0].WeightKg.Float64, "oldest first")
+
+	entries, err = repo.ListByUserID(ctx, 1, bodymetric.ListFilter{From: day.AddDate(0, 0, 1), To: day.AddDate(0, 0, 2)})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 80.5, entries[0].WeightKg.Float64)
+}
+
+func TestRepository_UpdateAndDelete(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &bodymetric.Entry{UserID: 1, MeasuredAt: time.Now(), WeightKg: null.FloatFrom(81)})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Update(ctx, &bodymetric.Entry{ID: id, MeasuredAt: time.Now(), WeightKg: null.FloatFrom(80), ArmCm: null.FloatFrom(36)}))
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 80.0, found.WeightKg.Float64)
+	require.Equal(t, 36.0, found.ArmCm.Float64)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	require.ErrorIs(t, repo.Delete(ctx, id), bodymetric.ErrEntryNotFound)
+	require.ErrorIs(t, repo.Update(ctx, &bodymetric.Entry{ID: id, MeasuredAt: time.Now()}), bodymetric.ErrEntryNotFound)
+}
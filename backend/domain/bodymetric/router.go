@@ -0,0 +1,22 @@
+package bodymetric
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("body_metrics"))
+		r.Get("/body-metrics", h.List)
+		r.Post("/body-metrics", h.Create)
+		r.Get("/body-metrics/trend", h.Trend)
+		r.Get("/body-metrics/{id}", h.GetByID)
+		r.Put("/body-metrics/{id}", h.Update)
+		r.Delete("/body-metrics/{id}", h.Delete)
+	})
+}
@@ -0,0 +1,202 @@
+package bodymetric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"workup_fitness/domain/user"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/bodymetric Service
+
+const (
+	minWeightKg       = 20
+	maxWeightKg       = 500
+	maxBodyFatPct     = 75
+	minCircumference  = 5
+	maxCircumference  = 300
+	maxNoteLength     = 500
+	defaultTrendDays  = 90
+	maxTrendDays      = 3650
+	futureMeasurement = 5 * time.Minute
+)
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type Service interface {
+	Create(ctx context.Context, userID int, entry *Entry) (*Entry, error)
+	GetByID(ctx context.Context, userID, id int) (*Entry, error)
+	List(ctx context.Context, userID int, filter ListFilter) ([]*Entry, error)
+	Update(ctx context.Context, userID int, entry *Entry) error
+	Delete(ctx context.Context, userID, id int) error
+	Trend(ctx context.Context, userID, days int) (*Trend, error)
+}
+
+type serviceImpl struct {
+	repo        Repository
+	userService UserService
+	now         func() time.Time
+}
+
+func NewService(repo Repository, userService UserService) *serviceImpl {
+	logger.Default().Debug().Msg("Creating body metric service...")
+	res := &serviceImpl{repo: repo, userService: userService, now: time.Now}
+	logger.Default().Debug().Msg("Created body metric service")
+	return res
+}
+
+// checkEntry validates entry and defaults MeasuredAt to now.
+func (s *serviceImpl) checkEntry(entry *Entry) error {
+	now := s.now()
+	if entry.MeasuredAt.IsZero() {
+		entry.MeasuredAt = now
+	}
+	// A few minutes of leeway for clocks that run ahead of ours.
+	if entry.MeasuredAt.After(now.Add(futureMeasurement)) {
+		return fmt.Errorf("%w: measured_at is in the future", ErrInvalidEntry)
+	}
+
+	measured := false
+	if entry.WeightKg.Valid {
+		measured = true
+		if entry.WeightKg.Float64 < minWeightKg || entry.WeightKg.Float64 > maxWeightKg {
+			return fmt.Errorf("%w: weight_kg must be between %d and %d", ErrInvalidEntry, minWeightKg, maxWeightKg)
+		}
+	}
+	if entry.BodyFatPct.Valid {
+		measured = true
+		if entry.BodyFatPct.Float64 <= 0 || entry.BodyFatPct.Float64 > maxBodyFatPct {
+			return fmt.Errorf("%w: body_fat_pct must be above 0 and at most %d", ErrInvalidEntry, maxBodyFatPct)
+		}
+	}
+	for name, value := range entry.circumferences() {
+		if !value.Valid {
+			continue
+		}
+		measured = true
+		if value.Float64 < minCircumference || value.Float64 > maxCircumference {
+			return fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidEntry, name, minCircumference, maxCircumference)
+		}
+	}
+	if !measured {
+		return errors.Join(ErrMissingField, errors.New("at least one measurement is required"))
+	}
+	if utf8.RuneCountInString(entry.Note.String) > maxNoteLength {
+		return fmt.Errorf("%w: note is longer than %d characters", ErrInvalidEntry, maxNoteLength)
+	}
+	return nil
+}
+
+// getOwned loads an entry and makes sure it belongs to userID.
+func (s *serviceImpl) getOwned(ctx context.Context, userID, id int) (*Entry, error) {
+	entry, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userID {
+		return nil, ErrInvalidPermissions
+	}
+	return entry, nil
+}
+
+func (s *serviceImpl) Create(ctx context.Context, userID int, entry *Entry) (*Entry, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating body metric entry")
+
+	if err := s.checkEntry(entry); err != nil {
+		return nil, err
+	}
+	entry.UserID = userID
+	createdID, err := s.repo.Create(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = createdID
+	entry.CreatedAt = s.now().UTC()
+	logger.Ctx(ctx).Info().Int("body_metric_id", entry.ID).Int("user_id", userID).Msg("Created body metric entry")
+	return entry, nil
+}
+
+func (s *serviceImpl) GetByID(ctx context.Context, userID, id int) (*Entry, error) {
+	logger.Ctx(ctx).Info().Int("body_metric_id", id).Msg("Getting body metric entry")
+	entry, err := s.getOwned(ctx, userID, id)
+	logger.Ctx(ctx).Info().Int("body_metric_id", id).Msg("Got body metric entry")
+	return entry, err
+}
+
+func (s *serviceImpl) List(ctx context.Context, userID int, filter ListFilter) ([]*Entry, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing body metric entries")
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidEntry)
+	}
+	entries, err := s.repo.ListByUserID(ctx, userID, filter)
+	logger.Ctx(ctx).Info().Int("count", len(entries)).Int("user_id", userID).Msg("Listed body metric entries")
+	return entries, err
+}
+
+func (s *serviceImpl) Update(ctx context.Context, userID int, entry *Entry) error {
+	logger.Ctx(ctx).Info().Int("body_metric_id", entry.ID).Msg("Updating body metric entry")
+
+	stored, err := s.getOwned(ctx, userID, entry.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkEntry(entry); err != nil {
+		return err
+	}
+	entry.UserID = userID
+	entry.CreatedAt = stored.CreatedAt
+	err = s.repo.Update(ctx, entry)
+	logger.Ctx(ctx).Info().Int("body_metric_id", entry.ID).Msg("Updated body metric entry")
+	return err
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, userID, id int) error {
+	logger.Ctx(ctx).Info().Int("body_metric_id", id).Msg("Deleting body metric entry")
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	err := s.repo.Delete(ctx, id)
+	logger.Ctx(ctx).Info().Int("body_metric_id", id).Msg("Deleted body metric entry")
+	return err
+}
+
+// Trend smooths the whole weight log of userID in their time zone and
+// returns the last days calendar days of it. The full log is used so the
+// first returned days are already settled.
+func (s *serviceImpl) Trend(ctx context.Context, userID, days int) (*Trend, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("days", days).Msg("Computing weight trend")
+
+	if days <= 0 {
+		days = defaultTrendDays
+	}
+	days = min(days, maxTrendDays)
+
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := found.Location()
+
+	entries, err := s.repo.ListByUserID(ctx, userID, ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	y, m, d := s.now().In(loc).Date()
+	since := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -days+1).Format(time.DateOnly)
+	points := computeTrend(entries, loc)
+	first := len(points)
+	for first > 0 && points[first-1].Date >= since {
+		first--
+	}
+
+	trend := &Trend{TimeZone: loc.String(), Points: points[first:]}
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("count", len(trend.Points)).Msg("Computed weight trend")
+	return trend, nil
+}
@@ -0,0 +1,138 @@
+package bodymetric_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/bodymetric"
+	"workup_fitness/domain/bodymetric/mocks"
+	"workup_fitness/domain/user"
+	userMocks "workup_fitness/domain/user/mocks"
+)
+
+type testService struct {
+	bodymetric.Service
+	repo  *mocks.MockRepository
+	users *userMocks.MockService
+}
+
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	users := userMocks.NewMockService(ctrl)
+	return testService{
+		Service: bodymetric.NewService(repo, users),
+		repo:    repo,
+		users:   users,
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().Create(ctx, gomock.Any()).Return(4, nil)
+
+	created, err := svc.Create(ctx, 3, &bodymetric.Entry{WeightKg: null.FloatFrom(81.4), WaistCm: null.FloatFrom(84)})
+	require.NoError(t, err)
+	require.Equal(t, 4, created.ID)
+	require.Equal(t, 3, created.UserID)
+	require.WithinDuration(t, time.Now(), created.MeasuredAt, time.Minute, "measured_at defaults to now")
+}
+
+func TestService_Create_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, 3, &bodymetric.Entry{Note: zero.StringFrom("forgot the scale")})
+	require.ErrorIs(t, err, bodymetric.ErrMissingField)
+
+	for name, entry := range map[string]*bodymetric.Entry{
+		"light":    {WeightKg: null.FloatFrom(5)},
+		"heavy":    {WeightKg: null.FloatFrom(900)},
+		"body fat": {BodyFatPct: null.FloatFrom(0)},
+		"waist":    {WaistCm: null.FloatFrom(1000)},
+		"future":   {WeightKg: null.FloatFrom(80), MeasuredAt: time.Now().Add(time.Hour)},
+	} {
+		_, err := svc.Create(ctx, 3, entry)
+		require.ErrorIs(t, err, bodymetric.ErrInvalidEntry, name)
+	}
+}
+
+func TestService_Update_NotOwner(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetByID(ctx, 4).Return(&bodymetric.Entry{ID: 4, UserID: 9}, nil)
+
+	err := svc.Update(ctx, 3, &bodymetric.Entry{ID: 4, WeightKg: null.FloatFrom(80)})
+	require.ErrorIs(t, err, bodymetric.ErrInvalidPermissions)
+}
+
+func TestService_Delete(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetByID(ctx, 4).Return(&bodymetric.Entry{ID: 4, UserID: 3}, nil)
+	svc.repo.EXPECT().Delete(ctx, 4).Return(nil)
+
+	require.NoError(t, svc.Delete(ctx, 3, 4))
+}
+
+func TestService_Trend(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	y, m, d := time.Now().In(loc).Date()
+	at := func(daysAgo, hour, minute int) time.Time {
+		return time.Date(y, m, d-daysAgo, hour, minute, 0, 0, loc)
+	}
+	date := func(daysAgo int) string {
+		return time.Date(y, m, d-daysAgo, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+	}
+
+	svc.users.EXPECT().
+		GetByID(ctx, 3).
+		Return(&user.User{ID: 3, TimeZone: zero.StringFrom("America/Los_Angeles")}, nil).
+		Times(2)
+	svc.repo.EXPECT().
+		ListByUserID(ctx, 3, bodymetric.ListFilter{}).
+		Return([]*bodymetric.Entry{
+			{MeasuredAt: at(8, 7, 0), WeightKg: null.FloatFrom(80)},
+			{MeasuredAt: at(7, 7, 0), WeightKg: null.FloatFrom(80.5)},
+			// Late in the evening is the next day in UTC but the same day
+			// for the user.
+			{MeasuredAt: at(7, 23, 30), WeightKg: null.FloatFrom(81.5)},
+			{MeasuredAt: at(6, 7, 0), WaistCm: null.FloatFrom(84)},
+			{MeasuredAt: at(5, 7, 0), WeightKg: null.FloatFrom(80)},
+			{MeasuredAt: at(0, 0, 5), WeightKg: null.FloatFrom(80)},
+		}, nil).
+		Times(2)
+
+	trend, err := svc.Trend(ctx, 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, "America/Los_Angeles", trend.TimeZone)
+	require.Equal(t, []bodymetric.TrendPoint{
+		{Date: date(8), WeightKg: 80, TrendKg: 80},
+		{Date: date(7), WeightKg: 81, TrendKg: 80.1},
+		// A two-day gap weighs the new day by 1-0.9^2.
+		{Date: date(5), WeightKg: 80, TrendKg: 80.08},
+		// Compared with the trend of seven days earlier.
+		{Date: date(0), WeightKg: 80, TrendKg: 80.05, WeeklyRateKg: null.FloatFrom(-0.05)},
+	}, trend.Points)
+
+	trend, err = svc.Trend(ctx, 3, 1)
+	require.NoError(t, err)
+	require.Len(t, trend.Points, 1)
+	require.Equal(t, date(0), trend.Points[0].Date)
+}
@@ -0,0 +1,81 @@
+package bodymetric
+
+import (
+	"math"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// smoothingFactor is how much one day's weight moves the trend. A tenth
+// evens out water and food swings while still following real change
+// within a few weeks.
+const smoothingFactor = 0.1
+
+type dayWeight struct {
+	date   time.Time
+	sum    float64
+	weighs int
+}
+
+// daysBetween counts calendar days from a to b. Both are UTC midnights, so
+// daylight saving changes cannot skew the count.
+func daysBetween(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
+
+// computeTrend buckets weigh-ins into calendar days in loc and smooths the
+// daily means with an exponential moving average. Days without weigh-ins
+// decay the trend as if each missing day had repeated the last trend, so
+// the next weigh-in after a gap counts for more.
+//
+// The weekly rate compares each day's trend with the trend of the latest
+// day at least a week earlier, scaled to seven days. It is null until the
+// log spans a week.
+func computeTrend(entries []*Entry, loc *time.Location) []TrendPoint {
+	days := make([]*dayWeight, 0)
+	for _, entry := range entries {
+		if !entry.WeightKg.Valid {
+			continue
+		}
+		y, m, d := entry.MeasuredAt.In(loc).Date()
+		date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		if len(days) == 0 || !days[len(days)-1].date.Equal(date) {
+			days = append(days, &dayWeight{date: date})
+		}
+		days[len(days)-1].sum += entry.WeightKg.Float64
+		days[len(days)-1].weighs++
+	}
+
+	points := make([]TrendPoint, 0, len(days))
+	trends := make([]float64, 0, len(days))
+	ref := 0
+	for i, day := range days {
+		weight := day.sum / float64(day.weighs)
+		trend := weight
+		if i > 0 {
+			alpha := 1 - math.Pow(1-smoothingFactor, float64(daysBetween(days[i-1].date, day.date)))
+			trend = trends[i-1] + alpha*(weight-trends[i-1])
+		}
+		trends = append(trends, trend)
+
+		point := TrendPoint{
+			Date:     day.date.Format(time.DateOnly),
+			WeightKg: round(weight),
+			TrendKg:  round(trend),
+		}
+		for ref+1 < i && daysBetween(days[ref+1].date, day.date) >= 7 {
+			ref++
+		}
+		if span := daysBetween(days[ref].date, day.date); span >= 7 {
+			point.WeeklyRateKg = null.FloatFrom(round((trend - trends[ref]) / float64(span) * 7))
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// round keeps two decimals, finer than any bathroom scale.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
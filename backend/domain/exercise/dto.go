@@ -0,0 +1,50 @@
+package exercise
+
+import (
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
+
+type CreateRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SimulatorID null.Int `json:"simulator_id"`
+	Muscles     Muscles  `json:"muscles"`
+}
+
+type CreateResponse struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SimulatorID null.Int `json:"simulator_id"`
+	Muscles     Muscles  `json:"muscles"`
+}
+
+type GetByIDResponse struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SimulatorID null.Int `json:"simulator_id"`
+	Muscles     Muscles  `json:"muscles"`
+}
+
+// UpdateRequest replaces an exercise. Muscle group tags stay as they are
+// when muscles is left out.
+type UpdateRequest struct {
+	Name        zero.String `json:"name"`
+	Description string      `json:"description"`
+	SimulatorID null.Int    `json:"simulator_id"`
+	Muscles     Muscles     `json:"muscles"`
+}
+
+type UpdateResponse struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SimulatorID null.Int `json:"simulator_id"`
+	Muscles     Muscles  `json:"muscles"`
+}
+
+type ListResponse struct {
+	Exercises []GetByIDResponse `json:"exercises"`
+}
@@ -0,0 +1,26 @@
+package exercise
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrAlreadyExists      = errors.New("exercise already exists")
+	ErrMissingField       = errors.New("missing field")
+	ErrExerciseNotFound   = errors.New("exercise not found")
+	ErrExerciseInUse      = errors.New("exercise is used by workouts, sessions or templates")
+	ErrSimulatorNotFound  = errors.New("referenced simulator not found")
+	ErrInvalidMuscleGroup = errors.New("invalid muscle group")
+)
+
+func init() {
+	httpx.RegisterError(ErrAlreadyExists, http.StatusConflict, "exercise_already_exists")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrExerciseNotFound, http.StatusNotFound, "exercise_not_found")
+	httpx.RegisterError(ErrExerciseInUse, http.StatusConflict, "exercise_in_use")
+	httpx.RegisterError(ErrSimulatorNotFound, http.StatusUnprocessableEntity, "unknown_simulator")
+	httpx.RegisterError(ErrInvalidMuscleGroup, http.StatusUnprocessableEntity, "invalid_muscle_group")
+}
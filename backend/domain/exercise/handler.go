@@ -0,0 +1,213 @@
+package exercise
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating exercise handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created exercise handler")
+	return res
+}
+
+func toGetByIDResponse(exercise *Exercise) GetByIDResponse {
+	return GetByIDResponse{
+		ID:          exercise.ID,
+		Name:        exercise.Name.String,
+		Description: exercise.Description,
+		SimulatorID: exercise.SimulatorID,
+		Muscles:     exercise.Muscles,
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Str("name", req.Name).Msg("Creating exercise")
+
+	exercise, err := h.service.Create(ctx, req.Name, req.Description, req.SimulatorID, req.Muscles)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	var resp CreateResponse
+	resp.ID = exercise.ID
+	resp.Name = exercise.Name.String
+	resp.Description = exercise.Description
+	resp.SimulatorID = exercise.SimulatorID
+	resp.Muscles = exercise.Muscles
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exercise.ID).Msg("Created exercise")
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Msg("Getting exercise")
+
+	exercise, err := h.service.GetByID(ctx, exerciseID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toGetByIDResponse(exercise)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Msg("Got exercise")
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	logger.Ctx(ctx).Info().Msg("Listing exercises")
+
+	exercises, err := h.service.List(ctx)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ListResponse{Exercises: make([]GetByIDResponse, 0, len(exercises))}
+	for _, exercise := range exercises {
+		resp.Exercises = append(resp.Exercises, toGetByIDResponse(exercise))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("count", len(exercises)).Msg("Listed exercises")
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Msg("Updating exercise")
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	exercise := &Exercise{
+		ID:          exerciseID,
+		Name:        req.Name,
+		Description: req.Description,
+		SimulatorID: req.SimulatorID,
+		Muscles:     req.Muscles,
+	}
+
+	if err := h.service.Update(ctx, exercise); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	var resp UpdateResponse
+	resp.ID = exercise.ID
+	resp.Name = exercise.Name.String
+	resp.Description = exercise.Description
+	resp.SimulatorID = exercise.SimulatorID
+	resp.Muscles = exercise.Muscles
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Msg("Updated exercise")
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Msg("Deleting exercise")
+
+	err = h.service.Delete(ctx, exerciseID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Msg("Deleted exercise")
+}
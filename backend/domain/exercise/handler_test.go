@@ -0,0 +1,306 @@
+package exercise_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/exercise/mocks"
+)
+
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestCreate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockExercise := &exercise.Exercise{
+		ID:          1,
+		Name:        zero.StringFrom("Leg press"),
+		Description: "Legs",
+		SimulatorID: null.IntFrom(2),
+	}
+
+	mockService.EXPECT().
+		Create(gomock.Any(), "Leg press", "Legs", null.IntFrom(2), exercise.Muscles{}).
+		Return(mockExercise, nil)
+
+	body, _ := json.Marshal(exercise.CreateRequest{
+		Name:        "Leg press",
+		Description: "Legs",
+		SimulatorID: null.IntFrom(2),
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/exercises", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp exercise.CreateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 1, resp.ID)
+	require.Equal(t, "Leg press", resp.Name)
+	require.Equal(t, int64(2), resp.SimulatorID.Int64)
+}
+
+func TestCreate_InvalidRequestBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/exercises", bytes.NewReader([]byte("invalid json")))
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestCreate_SimulatorNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), "Leg press", "", null.IntFrom(42), gomock.Any()).
+		Return(nil, exercise.ErrSimulatorNotFound)
+
+	body, _ := json.Marshal(exercise.CreateRequest{Name: "Leg press", SimulatorID: null.IntFrom(42)})
+
+	req := httptest.NewRequest(http.MethodPost, "/exercises", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestCreate_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), "Push up", "", null.Int{}, gomock.Any()).
+		Return(nil, exercise.ErrAlreadyExists)
+
+	body, _ := json.Marshal(exercise.CreateRequest{Name: "Push up"})
+
+	req := httptest.NewRequest(http.MethodPost, "/exercises", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestGetByID_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&exercise.Exercise{ID: 1, Name: zero.StringFrom("Push up")}, nil)
+
+	req := withURLParam(httptest.NewRequest(http.MethodGet, "/exercises/1", nil), "id", "1")
+	rr := httptest.NewRecorder()
+
+	handler.GetByID(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp exercise.GetByIDResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, "Push up", resp.Name)
+	require.False(t, resp.SimulatorID.Valid)
+}
+
+func TestGetByID_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	req := withURLParam(httptest.NewRequest(http.MethodGet, "/exercises/invalid", nil), "id", "invalid")
+	rr := httptest.NewRecorder()
+
+	handler.GetByID(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(nil, exercise.ErrExerciseNotFound)
+
+	req := withURLParam(httptest.NewRequest(http.MethodGet, "/exercises/1", nil), "id", "1")
+	rr := httptest.NewRecorder()
+
+	handler.GetByID(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestList_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any()).
+		Return([]*exercise.Exercise{
+			{ID: 1, Name: zero.StringFrom("Push up")},
+			{ID: 2, Name: zero.StringFrom("Leg press"), SimulatorID: null.IntFrom(1)},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp exercise.ListResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Exercises, 2)
+	require.Equal(t, "Leg press", resp.Exercises[1].Name)
+}
+
+func TestList_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any()).
+		Return(nil, errors.New("db error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	body, _ := json.Marshal(exercise.UpdateRequest{Name: zero.StringFrom("Updated")})
+
+	req := withURLParam(httptest.NewRequest(http.MethodPut, "/exercises/1", bytes.NewReader(body)), "id", "1")
+	rr := httptest.NewRecorder()
+
+	handler.Update(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp exercise.UpdateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 1, resp.ID)
+	require.Equal(t, "Updated", resp.Name)
+}
+
+func TestUpdate_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Return(exercise.ErrExerciseNotFound)
+
+	body, _ := json.Marshal(exercise.UpdateRequest{Name: zero.StringFrom("Updated")})
+
+	req := withURLParam(httptest.NewRequest(http.MethodPut, "/exercises/1", bytes.NewReader(body)), "id", "1")
+	rr := httptest.NewRecorder()
+
+	handler.Update(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestDelete_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Delete(gomock.Any(), 1).
+		Return(nil)
+
+	req := withURLParam(httptest.NewRequest(http.MethodDelete, "/exercises/1", nil), "id", "1")
+	rr := httptest.NewRecorder()
+
+	handler.Delete(rr, req)
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestDelete_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := exercise.NewHandler(mockService)
+
+	req := withURLParam(httptest.NewRequest(http.MethodDelete, "/exercises/invalid", nil), "id", "invalid")
+	rr := httptest.NewRecorder()
+
+	handler.Delete(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,15 @@
+package exercise
+
+import (
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
+
+type Exercise struct {
+	ID          int         `json:"id"`
+	Name        zero.String `json:"name"`
+	Description string      `json:"description"`
+	SimulatorID null.Int    `json:"simulator_id"`
+	Muscles     Muscles     `json:"muscles"`
+	CreatedAt   null.Time   `json:"created_at"`
+}
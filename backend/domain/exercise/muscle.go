@@ -0,0 +1,73 @@
+package exercise
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MuscleGroup is a muscle group an exercise trains.
+type MuscleGroup string
+
+const (
+	MuscleChest      MuscleGroup = "chest"
+	MuscleLats       MuscleGroup = "lats"
+	MuscleUpperBack  MuscleGroup = "upper_back"
+	MuscleLowerBack  MuscleGroup = "lower_back"
+	MuscleTraps      MuscleGroup = "traps"
+	MuscleShoulders  MuscleGroup = "shoulders"
+	MuscleBiceps     MuscleGroup = "biceps"
+	MuscleTriceps    MuscleGroup = "triceps"
+	MuscleForearms   MuscleGroup = "forearms"
+	MuscleAbs        MuscleGroup = "abs"
+	MuscleGlutes     MuscleGroup = "glutes"
+	MuscleQuads      MuscleGroup = "quads"
+	MuscleHamstrings MuscleGroup = "hamstrings"
+	MuscleAdductors  MuscleGroup = "adductors"
+	MuscleCalves     MuscleGroup = "calves"
+)
+
+var MuscleGroups = []MuscleGroup{
+	MuscleChest, MuscleLats, MuscleUpperBack, MuscleLowerBack, MuscleTraps, MuscleShoulders, MuscleBiceps,
+	MuscleTriceps, MuscleForearms, MuscleAbs, MuscleGlutes, MuscleQuads, MuscleHamstrings, MuscleAdductors,
+	MuscleCalves,
+}
+
+func (m MuscleGroup) Valid() bool {
+	return slices.Contains(MuscleGroups, m)
+}
+
+// Muscles are the muscle groups an exercise mainly works and those that
+// assist. Reports credit secondary muscles with part of the work.
+type Muscles struct {
+	Primary   []MuscleGroup `json:"primary"`
+	Secondary []MuscleGroup `json:"secondary"`
+}
+
+// IsZero reports whether neither list is set, which on update means the
+// tags stay as they are.
+func (m Muscles) IsZero() bool {
+	return m.Primary == nil && m.Secondary == nil
+}
+
+// normalize checks the groups, sorts them and drops duplicates. A group
+// cannot be both primary and secondary.
+func (m *Muscles) normalize() error {
+	for _, groups := range []*[]MuscleGroup{&m.Primary, &m.Secondary} {
+		if *groups == nil {
+			*groups = []MuscleGroup{}
+		}
+		for _, group := range *groups {
+			if !group.Valid() {
+				return fmt.Errorf("%w: %q", ErrInvalidMuscleGroup, group)
+			}
+		}
+		slices.Sort(*groups)
+		*groups = slices.Compact(*groups)
+	}
+	for _, group := range m.Primary {
+		if slices.Contains(m.Secondary, group) {
+			return fmt.Errorf("%w: %q is both primary and secondary", ErrInvalidMuscleGroup, group)
+		}
+	}
+	return nil
+}
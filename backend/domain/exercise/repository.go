@@ -0,0 +1,227 @@
+package exercise
+
+import (
+	"context"
+	"database/sql"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/exercise Repository
+
+type Repository interface {
+	Create(ctx context.Context, exercise *Exercise) (int, error)
+	GetByID(ctx context.Context, id int) (*Exercise, error)
+	List(ctx context.Context) ([]*Exercise, error)
+	Update(ctx context.Context, exercise *Exercise) error
+	Delete(ctx context.Context, id int) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// replaceMuscles swaps the muscle group tags of exerciseID for muscles.
+func replaceMuscles(ctx context.Context, db execer, exerciseID int, muscles Muscles) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM exercise_muscle_groups WHERE exercise_id = ?`, exerciseID); err != nil {
+		return err
+	}
+	for role, groups := range map[string][]MuscleGroup{"primary": muscles.Primary, "secondary": muscles.Secondary} {
+		for _, group := range groups {
+			_, err := db.ExecContext(ctx,
+				`INSERT INTO exercise_muscle_groups (exercise_id, muscle_group, role) VALUES (?, ?, ?)`,
+				exerciseID, group, role,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadMuscles fills in the muscle group tags of exercises.
+func (repo *sqliteRepository) loadMuscles(ctx context.Context, exercises ...*Exercise) error {
+	byID := make(map[int]*Exercise, len(exercises))
+	for _, exercise := range exercises {
+		exercise.Muscles = Muscles{Primary: []MuscleGroup{}, Secondary: []MuscleGroup{}}
+		byID[exercise.ID] = exercise
+	}
+
+	query := `SELECT exercise_id, muscle_group, role FROM exercise_muscle_groups`
+	var args []any
+	if len(exercises) == 1 {
+		query += ` WHERE exercise_id = ?`
+		args = append(args, exercises[0].ID)
+	}
+	rows, err := repo.db.QueryContext(ctx, query+` ORDER BY exercise_id, muscle_group`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			exerciseID int
+			group      MuscleGroup
+			role       string
+		)
+		if err := rows.Scan(&exerciseID, &group, &role); err != nil {
+			return err
+		}
+		exercise, ok := byID[exerciseID]
+		if !ok {
+			continue
+		}
+		if role == "primary" {
+			exercise.Muscles.Primary = append(exercise.Muscles.Primary, group)
+		} else {
+			exercise.Muscles.Secondary = append(exercise.Muscles.Secondary, group)
+		}
+	}
+	return rows.Err()
+}
+
+func (repo *sqliteRepository) Create(ctx context.Context, exercise *Exercise) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO exercises (name, description, simulator) VALUES (?, ?, ?)`,
+		exercise.Name, exercise.Description, exercise.SimulatorID,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := replaceMuscles(ctx, tx, int(id), exercise.Muscles); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetByID(ctx context.Context, id int) (*Exercise, error) {
+	var exercise Exercise
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT id, name, description, simulator, created_at FROM exercises WHERE id = ?`,
+		id,
+	)
+	err := row.Scan(&exercise.ID, &exercise.Name, &exercise.Description, &exercise.SimulatorID, &exercise.CreatedAt)
+	if err := dbutil.ProcessRowError(err, ErrExerciseNotFound); err != nil {
+		return nil, err
+	}
+	if err := repo.loadMuscles(ctx, &exercise); err != nil {
+		return nil, err
+	}
+	return &exercise, nil
+}
+
+func (repo *sqliteRepository) List(ctx context.Context) ([]*Exercise, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT id, name, description, simulator, created_at FROM exercises ORDER BY id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exercises := make([]*Exercise, 0)
+	for rows.Next() {
+		var exercise Exercise
+		if err := rows.Scan(&exercise.ID, &exercise.Name, &exercise.Description, &exercise.SimulatorID, &exercise.CreatedAt); err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, &exercise)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := repo.loadMuscles(ctx, exercises...); err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
+// Update saves exercise. Its muscle group tags are only replaced when
+// Muscles is not zero; otherwise the stored ones are filled in.
+func (repo *sqliteRepository) Update(ctx context.Context, exercise *Exercise) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE exercises SET name = ?, description = ?, simulator = ? WHERE id = ?`,
+		exercise.Name, exercise.Description, exercise.SimulatorID, exercise.ID,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrExerciseNotFound
+	}
+	if exercise.Muscles.IsZero() {
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		return repo.loadMuscles(ctx, exercise)
+	}
+	if err := replaceMuscles(ctx, tx, exercise.ID, exercise.Muscles); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) Delete(ctx context.Context, id int) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM exercises WHERE id = ?`,
+		id,
+	)
+	if err := dbutil.ProcessDeleteError(err, ErrExerciseInUse); err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrExerciseNotFound
+	}
+	if err := replaceMuscles(ctx, tx, id, Muscles{}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
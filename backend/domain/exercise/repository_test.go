@@ -0,0 +1,241 @@
+package exercise_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"workup_fitness/domain/exercise"
+	"workup_fitness/internal/testutil"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T) (exercise.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := exercise.NewSQLiteRepository(db)
+	ctx := context.Background()
+	return repo, db, ctx
+}
+
+func createTestSimulator(t *testing.T, db *sql.DB) int64 {
+	t.Helper()
+
+	res, err := db.Exec(
+		`INSERT INTO simulators (name, description, min_weight, max_weight, weight_increment) VALUES (?, ?, ?, ?, ?)`,
+		"Leg press", "Some description", 10, 200, 10,
+	)
+	require.NoError(t, err)
+	id, err := res.LastInsertId()
+	require.NoError(t, err)
+	return id
+}
+
+func TestRepository_Create_Success(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	simulatorID := createTestSimulator(t, db)
+
+	id, err := repo.Create(ctx, &exercise.Exercise{
+		Name:        zero.StringFrom("Leg press"),
+		Description: "Some description",
+		SimulatorID: null.IntFrom(simulatorID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+}
+
+func TestRepository_Create_AlreadyExists(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	newExercise := &exercise.Exercise{
+		Name:        zero.StringFrom("Push up"),
+		Description: "Some description",
+	}
+
+	_, err := repo.Create(ctx, newExercise)
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, newExercise)
+	require.ErrorIs(t, err, exercise.ErrAlreadyExists)
+}
+
+func TestRepository_Create_MissingFields(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	_, err := repo.Create(ctx, &exercise.Exercise{})
+	require.ErrorIs(t, err, exercise.ErrMissingField)
+	require.ErrorContains(t, err, "name")
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	simulatorID := createTestSimulator(t, db)
+
+	newExercise := &exercise.Exercise{
+		Name:        zero.StringFrom("Leg press"),
+		Description: "Some description",
+		SimulatorID: null.IntFrom(simulatorID),
+	}
+
+	id, err := repo.Create(ctx, newExercise)
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, newExercise.Name, found.Name)
+	require.Equal(t, newExercise.Description, found.Description)
+	require.Equal(t, newExercise.SimulatorID, found.SimulatorID)
+	require.True(t, found.CreatedAt.Valid)
+}
+
+func TestRepository_GetByID_NotFound(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	found, err := repo.GetByID(ctx, 42)
+	require.ErrorIs(t, err, exercise.ErrExerciseNotFound)
+	require.Nil(t, found)
+}
+
+func TestRepository_List(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	exercises, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, exercises)
+
+	_, err = repo.Create(ctx, &exercise.Exercise{Name: zero.StringFrom("Push up")})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &exercise.Exercise{Name: zero.StringFrom("Pull up")})
+	require.NoError(t, err)
+
+	exercises, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, exercises, 2)
+	require.Equal(t, "Push up", exercises[0].Name.String)
+	require.Equal(t, "Pull up", exercises[1].Name.String)
+	require.False(t, exercises[0].SimulatorID.Valid)
+}
+
+func TestRepository_Update(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &exercise.Exercise{Name: zero.StringFrom("Push up")})
+	require.NoError(t, err)
+
+	simulatorID := createTestSimulator(t, db)
+	updatedExercise := &exercise.Exercise{
+		ID:          id,
+		Name:        zero.StringFrom("Leg press"),
+		Description: "Updated description",
+		SimulatorID: null.IntFrom(simulatorID),
+	}
+	err = repo.Update(ctx, updatedExercise)
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, updatedExercise.Name, found.Name)
+	require.Equal(t, updatedExercise.Description, found.Description)
+	require.Equal(t, updatedExercise.SimulatorID, found.SimulatorID)
+}
+
+func TestRepository_Update_NotFound(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	err := repo.Update(ctx, &exercise.Exercise{ID: 42, Name: zero.StringFrom("Push up")})
+	require.ErrorIs(t, err, exercise.ErrExerciseNotFound)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &exercise.Exercise{Name: zero.StringFrom("Push up")})
+	require.NoError(t, err)
+
+	err = repo.Delete(ctx, id)
+	require.NoError(t, err)
+
+	_, err = repo.GetByID(ctx, id)
+	require.ErrorIs(t, err, exercise.ErrExerciseNotFound)
+
+	err = repo.Delete(ctx, id)
+	require.ErrorIs(t, err, exercise.ErrExerciseNotFound)
+}
+
+func TestRepository_Delete_InUse(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &exercise.Exercise{Name: zero.StringFrom("Squat")})
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO workouts (user_id, scheduled_at) VALUES (1, CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO workout_exercises (workout_id, exercise_id, weight, sets, repetitions) VALUES (1, ?, 100, 3, 5)`, id)
+	require.NoError(t, err)
+
+	err = repo.Delete(ctx, id)
+	require.ErrorIs(t, err, exercise.ErrExerciseInUse)
+
+	_, err = repo.GetByID(ctx, id)
+	require.NoError(t, err)
+}
+
+func TestRepository_Muscles(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &exercise.Exercise{
+		Name: zero.StringFrom("Squat"),
+		Muscles: exercise.Muscles{
+			Primary:   []exercise.MuscleGroup{exercise.MuscleGlutes, exercise.MuscleQuads},
+			Secondary: []exercise.MuscleGroup{exercise.MuscleAdductors},
+		},
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &exercise.Exercise{Name: zero.StringFrom("Plank")})
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, []exercise.MuscleGroup{exercise.MuscleGlutes, exercise.MuscleQuads}, found.Muscles.Primary)
+	require.Equal(t, []exercise.MuscleGroup{exercise.MuscleAdductors}, found.Muscles.Secondary)
+
+	updated := &exercise.Exercise{ID: id, Name: zero.StringFrom("Back squat")}
+	require.NoError(t, repo.Update(ctx, updated))
+	require.Equal(t, found.Muscles, updated.Muscles, "tags are kept and filled in when left out")
+
+	require.NoError(t, repo.Update(ctx, &exercise.Exercise{
+		ID:      id,
+		Name:    zero.StringFrom("Back squat"),
+		Muscles: exercise.Muscles{Primary: []exercise.MuscleGroup{exercise.MuscleQuads}, Secondary: []exercise.MuscleGroup{}},
+	}))
+
+	exercises, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, exercises, 2)
+	require.Equal(t, []exercise.MuscleGroup{exercise.MuscleQuads}, exercises[0].Muscles.Primary)
+	require.Empty(t, exercises[0].Muscles.Secondary)
+	require.Empty(t, exercises[1].Muscles.Primary)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM exercise_muscle_groups`).Scan(&count))
+	require.Zero(t, count)
+}
@@ -0,0 +1,21 @@
+package exercise
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Get("/exercises", h.List)
+	r.Get("/exercises/{id}", h.GetByID)
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("exercises"))
+		r.Post("/exercises", h.Create)
+		r.Put("/exercises/{id}", h.Update)
+		r.Delete("/exercises/{id}", h.Delete)
+	})
+}
@@ -0,0 +1,110 @@
+package exercise
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+
+	"workup_fitness/domain/simulator"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/exercise Service
+
+type SimulatorService interface {
+	GetByID(ctx context.Context, id int) (*simulator.Simulator, error)
+}
+
+type Service interface {
+	Create(ctx context.Context, name, description string, simulatorID null.Int, muscles Muscles) (*Exercise, error)
+	GetByID(ctx context.Context, id int) (*Exercise, error)
+	List(ctx context.Context) ([]*Exercise, error)
+	Update(ctx context.Context, exercise *Exercise) error
+	Delete(ctx context.Context, id int) error
+}
+
+type serviceImpl struct {
+	repo             Repository
+	simulatorService SimulatorService
+}
+
+func NewService(repo Repository, simulatorService SimulatorService) *serviceImpl {
+	logger.Default().Debug().Msg("Creating exercise service...")
+	res := &serviceImpl{repo: repo, simulatorService: simulatorService}
+	logger.Default().Debug().Msg("Created exercise service")
+	return res
+}
+
+func (s *serviceImpl) checkSimulator(ctx context.Context, simulatorID null.Int) error {
+	if !simulatorID.Valid {
+		return nil
+	}
+
+	_, err := s.simulatorService.GetByID(ctx, int(simulatorID.Int64))
+	if errors.Is(err, simulator.ErrSimulatorNotFound) {
+		return ErrSimulatorNotFound
+	}
+	return err
+}
+
+func (s *serviceImpl) Create(ctx context.Context, name, description string, simulatorID null.Int, muscles Muscles) (*Exercise, error) {
+	logger.Ctx(ctx).Info().Str("name", name).Msg("Creating exercise")
+
+	if err := s.checkSimulator(ctx, simulatorID); err != nil {
+		return nil, err
+	}
+	if err := muscles.normalize(); err != nil {
+		return nil, err
+	}
+
+	newName := zero.StringFromPtr(&name)
+
+	exercise := &Exercise{Name: newName, Description: description, SimulatorID: simulatorID, Muscles: muscles}
+	createdID, err := s.repo.Create(ctx, exercise)
+	if err != nil {
+		return nil, err
+	}
+	exercise.ID = createdID
+	logger.Ctx(ctx).Info().Str("name", exercise.Name.String).Msg("Created exercise")
+	return exercise, nil
+}
+
+func (s *serviceImpl) GetByID(ctx context.Context, id int) (*Exercise, error) {
+	logger.Ctx(ctx).Info().Int("exercise_id", id).Msg("Getting exercise")
+	res, err := s.repo.GetByID(ctx, id)
+	logger.Ctx(ctx).Info().Int("exercise_id", id).Msg("Got exercise")
+	return res, err
+}
+
+func (s *serviceImpl) List(ctx context.Context) ([]*Exercise, error) {
+	logger.Ctx(ctx).Info().Msg("Listing exercises")
+	res, err := s.repo.List(ctx)
+	logger.Ctx(ctx).Info().Int("count", len(res)).Msg("Listed exercises")
+	return res, err
+}
+
+// Update saves exercise. Muscle group tags are left as they are when
+// exercise.Muscles is zero.
+func (s *serviceImpl) Update(ctx context.Context, exercise *Exercise) error {
+	logger.Ctx(ctx).Info().Int("exercise_id", exercise.ID).Msg("Updating exercise")
+	if err := s.checkSimulator(ctx, exercise.SimulatorID); err != nil {
+		return err
+	}
+	if !exercise.Muscles.IsZero() {
+		if err := exercise.Muscles.normalize(); err != nil {
+			return err
+		}
+	}
+	err := s.repo.Update(ctx, exercise)
+	logger.Ctx(ctx).Info().Int("exercise_id", exercise.ID).Msg("Updated exercise")
+	return err
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, id int) error {
+	logger.Ctx(ctx).Info().Int("exercise_id", id).Msg("Deleting exercise")
+	err := s.repo.Delete(ctx, id)
+	logger.Ctx(ctx).Info().Int("exercise_id", id).Msg("Deleted exercise")
+	return err
+}
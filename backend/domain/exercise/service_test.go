@@ -0,0 +1,139 @@
+package exercise_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/exercise/mocks"
+	"workup_fitness/domain/simulator"
+	simulatorMocks "workup_fitness/domain/simulator/mocks"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_Create_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	svc := exercise.NewService(repo, simulatorService)
+	ctx := context.Background()
+
+	simulatorService.EXPECT().
+		GetByID(ctx, 3).
+		Return(&simulator.Simulator{ID: 3}, nil)
+	repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(1, nil)
+
+	newExercise, err := svc.Create(ctx, "Leg press", "Some description", null.IntFrom(3), exercise.Muscles{})
+	require.NoError(t, err)
+	require.Equal(t, 1, newExercise.ID)
+	require.Equal(t, "Leg press", newExercise.Name.String)
+	require.Equal(t, "Some description", newExercise.Description)
+	require.Equal(t, int64(3), newExercise.SimulatorID.Int64)
+}
+
+func TestService_Create_WithoutSimulator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	svc := exercise.NewService(repo, simulatorService)
+	ctx := context.Background()
+
+	repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(1, nil)
+
+	newExercise, err := svc.Create(ctx, "Push up", "", null.Int{}, exercise.Muscles{})
+	require.NoError(t, err)
+	require.False(t, newExercise.SimulatorID.Valid)
+}
+
+func TestService_Create_SimulatorNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	svc := exercise.NewService(repo, simulatorService)
+	ctx := context.Background()
+
+	simulatorService.EXPECT().
+		GetByID(ctx, 3).
+		Return(nil, simulator.ErrSimulatorNotFound)
+
+	_, err := svc.Create(ctx, "Leg press", "Some description", null.IntFrom(3), exercise.Muscles{})
+	require.ErrorIs(t, err, exercise.ErrSimulatorNotFound)
+}
+
+func TestService_Create_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	svc := exercise.NewService(repo, simulatorService)
+	ctx := context.Background()
+
+	repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(0, errors.New("some error"))
+
+	_, err := svc.Create(ctx, "Push up", "", null.Int{}, exercise.Muscles{})
+	require.Error(t, err)
+}
+
+func TestService_Update_SimulatorNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	svc := exercise.NewService(repo, simulatorService)
+	ctx := context.Background()
+
+	simulatorService.EXPECT().
+		GetByID(ctx, 5).
+		Return(nil, simulator.ErrSimulatorNotFound)
+
+	err := svc.Update(ctx, &exercise.Exercise{ID: 1, Name: zero.StringFrom("Leg press"), SimulatorID: null.IntFrom(5)})
+	require.ErrorIs(t, err, exercise.ErrSimulatorNotFound)
+}
+
+func TestService_Create_Muscles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	svc := exercise.NewService(repo, simulatorService)
+	ctx := context.Background()
+
+	repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(1, nil)
+
+	newExercise, err := svc.Create(ctx, "Bench press", "", null.Int{}, exercise.Muscles{
+		Primary:   []exercise.MuscleGroup{"triceps", "chest", "chest"},
+		Secondary: []exercise.MuscleGroup{"shoulders"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []exercise.MuscleGroup{"chest", "triceps"}, newExercise.Muscles.Primary)
+
+	for name, muscles := range map[string]exercise.Muscles{
+		"unknown group":         {Primary: []exercise.MuscleGroup{"pecs"}},
+		"primary and secondary": {Primary: []exercise.MuscleGroup{"chest"}, Secondary: []exercise.MuscleGroup{"chest"}},
+	} {
+		_, err := svc.Create(ctx, "Bench press", "", null.Int{}, muscles)
+		require.ErrorIs(t, err, exercise.ErrInvalidMuscleGroup, name)
+	}
+}
@@ -0,0 +1,97 @@
+package program
+
+import (
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+
+	"workup_fitness/domain/workout"
+)
+
+// TemplateExerciseRequest is one exercise of a template. RepMax defaults
+// to RepMin for a fixed rep target.
+type TemplateExerciseRequest struct {
+	ExerciseID  int        `json:"exercise_id"`
+	Sets        int        `json:"sets"`
+	RepMin      int        `json:"rep_min"`
+	RepMax      int        `json:"rep_max"`
+	Weight      null.Float `json:"weight"`
+	PercentE1RM null.Float `json:"percent_e1rm"`
+	RPE         null.Float `json:"rpe"`
+}
+
+// TemplateRequest creates or replaces a template. Exercises are kept in
+// the order given.
+type TemplateRequest struct {
+	Name      string                    `json:"name"`
+	Notes     zero.String               `json:"notes"`
+	Exercises []TemplateExerciseRequest `json:"exercises"`
+}
+
+type TemplateExerciseResponse struct {
+	ID          int        `json:"id"`
+	Position    int        `json:"position"`
+	ExerciseID  int        `json:"exercise_id"`
+	Sets        int        `json:"sets"`
+	RepMin      int        `json:"rep_min"`
+	RepMax      int        `json:"rep_max"`
+	Weight      null.Float `json:"weight"`
+	PercentE1RM null.Float `json:"percent_e1rm"`
+	RPE         null.Float `json:"rpe"`
+}
+
+type TemplateResponse struct {
+	ID        int                        `json:"id"`
+	Name      string                     `json:"name"`
+	Notes     zero.String                `json:"notes"`
+	Exercises []TemplateExerciseResponse `json:"exercises"`
+	CreatedAt string                     `json:"created_at"`
+}
+
+type TemplateListResponse struct {
+	Templates []TemplateResponse `json:"templates"`
+}
+
+type ProgramDayRequest struct {
+	Week       int `json:"week"`
+	Day        int `json:"day"`
+	TemplateID int `json:"template_id"`
+}
+
+// ProgramRequest creates or replaces a program.
+type ProgramRequest struct {
+	Name  string              `json:"name"`
+	Weeks int                 `json:"weeks"`
+	Notes zero.String         `json:"notes"`
+	Days  []ProgramDayRequest `json:"days"`
+}
+
+type ProgramDayResponse struct {
+	ID         int `json:"id"`
+	Week       int `json:"week"`
+	Day        int `json:"day"`
+	TemplateID int `json:"template_id"`
+}
+
+type ProgramResponse struct {
+	ID        int                  `json:"id"`
+	Name      string               `json:"name"`
+	Weeks     int                  `json:"weeks"`
+	Notes     zero.String          `json:"notes"`
+	Days      []ProgramDayResponse `json:"days"`
+	CreatedAt string               `json:"created_at"`
+}
+
+type ProgramListResponse struct {
+	Programs []ProgramResponse `json:"programs"`
+}
+
+// InstantiateRequest schedules a program from StartDate (YYYY-MM-DD). Time
+// (HH:MM in the user's time zone) defaults to 09:00.
+type InstantiateRequest struct {
+	StartDate string      `json:"start_date"`
+	Time      zero.String `json:"time"`
+}
+
+type InstantiateResponse struct {
+	Workouts []workout.WorkoutResponse `json:"workouts"`
+}
@@ -0,0 +1,36 @@
+package program
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrProgramNotFound      = errors.New("program not found")
+	ErrAlreadyExists        = errors.New("name already taken")
+	ErrMissingField         = errors.New("missing field")
+	ErrInvalidPermissions   = errors.New("invalid permissions")
+	ErrExerciseNotFound     = errors.New("referenced exercise not found")
+	ErrUnknownTemplate      = errors.New("referenced template not found")
+	ErrInvalidTemplate      = errors.New("invalid template")
+	ErrInvalidProgram       = errors.New("invalid program")
+	ErrTemplateInUse        = errors.New("template is used by a program")
+	ErrInvalidInstantiation = errors.New("invalid program start")
+)
+
+func init() {
+	httpx.RegisterError(ErrTemplateNotFound, http.StatusNotFound, "template_not_found")
+	httpx.RegisterError(ErrProgramNotFound, http.StatusNotFound, "program_not_found")
+	httpx.RegisterError(ErrAlreadyExists, http.StatusConflict, "name_taken")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPermissions, http.StatusForbidden, "forbidden")
+	httpx.RegisterError(ErrExerciseNotFound, http.StatusUnprocessableEntity, "unknown_exercise")
+	httpx.RegisterError(ErrUnknownTemplate, http.StatusUnprocessableEntity, "unknown_template")
+	httpx.RegisterError(ErrInvalidTemplate, http.StatusUnprocessableEntity, "invalid_template")
+	httpx.RegisterError(ErrInvalidProgram, http.StatusUnprocessableEntity, "invalid_program")
+	httpx.RegisterError(ErrTemplateInUse, http.StatusConflict, "template_in_use")
+	httpx.RegisterError(ErrInvalidInstantiation, http.StatusBadRequest, "invalid_program_start")
+}
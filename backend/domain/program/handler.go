@@ -0,0 +1,497 @@
+package program
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workup_fitness/domain/workout"
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultTimeOfDay is when instantiated workouts start if no time is given.
+const defaultTimeOfDay = 9 * time.Hour
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating program handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created program handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+func toTemplate(req TemplateRequest) *Template {
+	template := &Template{Name: req.Name, Notes: req.Notes, Exercises: make([]TemplateExercise, 0, len(req.Exercises))}
+	for _, entry := range req.Exercises {
+		template.Exercises = append(template.Exercises, TemplateExercise{
+			ExerciseID:  entry.ExerciseID,
+			Sets:        entry.Sets,
+			RepMin:      entry.RepMin,
+			RepMax:      entry.RepMax,
+			Weight:      entry.Weight,
+			PercentE1RM: entry.PercentE1RM,
+			RPE:         entry.RPE,
+		})
+	}
+	return template
+}
+
+func toTemplateResponse(template *Template) TemplateResponse {
+	resp := TemplateResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		Notes:     template.Notes,
+		Exercises: make([]TemplateExerciseResponse, 0, len(template.Exercises)),
+		CreatedAt: template.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	for _, entry := range template.Exercises {
+		resp.Exercises = append(resp.Exercises, TemplateExerciseResponse{
+			ID:          entry.ID,
+			Position:    entry.Position,
+			ExerciseID:  entry.ExerciseID,
+			Sets:        entry.Sets,
+			RepMin:      entry.RepMin,
+			RepMax:      entry.RepMax,
+			Weight:      entry.Weight,
+			PercentE1RM: entry.PercentE1RM,
+			RPE:         entry.RPE,
+		})
+	}
+	return resp
+}
+
+func toProgram(req ProgramRequest) *Program {
+	program := &Program{Name: req.Name, Weeks: req.Weeks, Notes: req.Notes, Days: make([]ProgramDay, 0, len(req.Days))}
+	for _, day := range req.Days {
+		program.Days = append(program.Days, ProgramDay{Week: day.Week, Day: day.Day, TemplateID: day.TemplateID})
+	}
+	return program
+}
+
+func toProgramResponse(program *Program) ProgramResponse {
+	resp := ProgramResponse{
+		ID:        program.ID,
+		Name:      program.Name,
+		Weeks:     program.Weeks,
+		Notes:     program.Notes,
+		Days:      make([]ProgramDayResponse, 0, len(program.Days)),
+		CreatedAt: program.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	for _, day := range program.Days {
+		resp.Days = append(resp.Days, ProgramDayResponse{ID: day.ID, Week: day.Week, Day: day.Day, TemplateID: day.TemplateID})
+	}
+	return resp
+}
+
+func toWorkoutResponse(planned *workout.Workout) workout.WorkoutResponse {
+	resp := workout.WorkoutResponse{
+		ID:          planned.ID,
+		UserID:      planned.UserID,
+		ScheduledAt: planned.ScheduledAt.Format(time.RFC3339),
+		Exercises:   make([]workout.WorkoutExerciseResponse, 0, len(planned.Exercises)),
+	}
+	for _, entry := range planned.Exercises {
+		resp.Exercises = append(resp.Exercises, workout.WorkoutExerciseResponse{
+			ID:           entry.ID,
+			ExerciseID:   entry.ExerciseID,
+			Weight:       entry.Weight,
+			Sets:         entry.Sets,
+			Repetitions:  entry.Repetitions,
+			AdjustedFrom: entry.AdjustedFrom,
+		})
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+	}
+}
+
+func (h *Handler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req TemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	template, err := h.service.CreateTemplate(ctx, userID, toTemplate(req))
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toTemplateResponse(template))
+}
+
+func (h *Handler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	templateID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid template id")
+		return
+	}
+
+	template, err := h.service.GetTemplate(ctx, userID, templateID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTemplateResponse(template))
+}
+
+func (h *Handler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	templates, err := h.service.ListTemplates(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := TemplateListResponse{Templates: make([]TemplateResponse, 0, len(templates))}
+	for _, template := range templates {
+		resp.Templates = append(resp.Templates, toTemplateResponse(template))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	templateID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid template id")
+		return
+	}
+
+	var req TemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	template := toTemplate(req)
+	template.ID = templateID
+	if err := h.service.UpdateTemplate(ctx, userID, template); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	updated, err := h.service.GetTemplate(ctx, userID, templateID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTemplateResponse(updated))
+}
+
+func (h *Handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	templateID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid template id")
+		return
+	}
+
+	if err := h.service.DeleteTemplate(ctx, userID, templateID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	program, err := h.service.CreateProgram(ctx, userID, toProgram(req))
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toProgramResponse(program))
+}
+
+func (h *Handler) GetProgram(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	programID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid program id")
+		return
+	}
+
+	program, err := h.service.GetProgram(ctx, userID, programID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toProgramResponse(program))
+}
+
+func (h *Handler) ListPrograms(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	programs, err := h.service.ListPrograms(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ProgramListResponse{Programs: make([]ProgramResponse, 0, len(programs))}
+	for _, program := range programs {
+		resp.Programs = append(resp.Programs, toProgramResponse(program))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) UpdateProgram(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	programID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid program id")
+		return
+	}
+
+	var req ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	program := toProgram(req)
+	program.ID = programID
+	if err := h.service.UpdateProgram(ctx, userID, program); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	updated, err := h.service.GetProgram(ctx, userID, programID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toProgramResponse(updated))
+}
+
+func (h *Handler) DeleteProgram(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	programID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid program id")
+		return
+	}
+
+	if err := h.service.DeleteProgram(ctx, userID, programID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	programID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid program id")
+		return
+	}
+
+	var req InstantiateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+	startDate, err := time.Parse(time.DateOnly, req.StartDate)
+	if err != nil {
+		httpx.BadRequest(w, "start_date must be YYYY-MM-DD")
+		return
+	}
+	timeOfDay := defaultTimeOfDay
+	if req.Time.Valid {
+		clock, err := time.Parse("15:04", req.Time.String)
+		if err != nil {
+			httpx.BadRequest(w, "time must be HH:MM")
+			return
+		}
+		timeOfDay = time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+	}
+
+	workouts, err := h.service.Instantiate(ctx, userID, programID, startDate, timeOfDay)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := InstantiateResponse{Workouts: make([]workout.WorkoutResponse, 0, len(workouts))}
+	for _, planned := range workouts {
+		resp.Workouts = append(resp.Workouts, toWorkoutResponse(planned))
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
+}
@@ -0,0 +1,108 @@
+package program_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/program"
+	"workup_fitness/domain/program/mocks"
+	"workup_fitness/domain/workout"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, id string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if id != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreateTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := program.NewHandler(mockService)
+
+	entry := program.TemplateExercise{ExerciseID: 1, Sets: 3, RepMin: 8, RepMax: 12, PercentE1RM: null.FloatFrom(70)}
+	mockService.EXPECT().
+		CreateTemplate(gomock.Any(), 1, &program.Template{Name: "Push", Exercises: []program.TemplateExercise{entry}}).
+		Return(&program.Template{ID: 7, UserID: 1, Name: "Push", Exercises: []program.TemplateExercise{entry}}, nil)
+
+	rr := httptest.NewRecorder()
+	body := []byte(`{"name": "Push", "exercises": [{"exercise_id": 1, "sets": 3, "rep_min": 8, "rep_max": 12, "percent_e1rm": 70}]}`)
+	handler.CreateTemplate(rr, newAuthedRequest(http.MethodPost, "/templates", body, 1, ""))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp program.TemplateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 7, resp.ID)
+	require.Len(t, resp.Exercises, 1)
+	require.Equal(t, 70.0, resp.Exercises[0].PercentE1RM.Float64)
+	require.False(t, resp.Exercises[0].Weight.Valid)
+}
+
+func TestDeleteTemplate_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := program.NewHandler(mockService)
+
+	mockService.EXPECT().DeleteTemplate(gomock.Any(), 1, 7).Return(program.ErrTemplateInUse)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteTemplate(rr, newAuthedRequest(http.MethodDelete, "/templates/7", nil, 1, "7"))
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestInstantiate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := program.NewHandler(mockService)
+
+	scheduledAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	mockService.EXPECT().
+		Instantiate(gomock.Any(), 1, 5, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 9*time.Hour).
+		Return([]*workout.Workout{{ID: 20, UserID: 1, ScheduledAt: scheduledAt, Exercises: []workout.WorkoutExercise{
+			{ID: 40, WorkoutID: 20, ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5},
+		}}}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Instantiate(rr, newAuthedRequest(http.MethodPost, "/programs/5/instantiate", []byte(`{"start_date": "2026-03-02"}`), 1, "5"))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp program.InstantiateResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Workouts, 1)
+	require.Equal(t, "2026-03-02T09:00:00Z", resp.Workouts[0].ScheduledAt)
+	require.Equal(t, 100.0, resp.Workouts[0].Exercises[0].Weight)
+}
+
+func TestInstantiate_InvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := program.NewHandler(mockService)
+
+	for _, body := range []string{
+		`{"start_date": "03/02/2026"}`,
+		`{"start_date": "2026-03-02", "time": "6pm"}`,
+	} {
+		rr := httptest.NewRecorder()
+		handler.Instantiate(rr, newAuthedRequest(http.MethodPost, "/programs/5/instantiate", []byte(body), 1, "5"))
+		require.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/program (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/program Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	program "workup_fitness/domain/program"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateProgram mocks base method.
+func (m *MockRepository) CreateProgram(ctx context.Context, arg1 *program.Program) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProgram", ctx, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProgram indicates an expected call of CreateProgram.
+func (mr *MockRepositoryMockRecorder) CreateProgram(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProgram", reflect.TypeOf((*MockRepository)(nil).CreateProgram), ctx, arg1)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockRepository) CreateTemplate(ctx context.Context, template *program.Template) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, template)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockRepositoryMockRecorder) CreateTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockRepository)(nil).CreateTemplate), ctx, template)
+}
+
+// DeleteProgram mocks base method.
+func (m *MockRepository) DeleteProgram(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProgram", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProgram indicates an expected call of DeleteProgram.
+func (mr *MockRepositoryMockRecorder) DeleteProgram(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProgram", reflect.TypeOf((*MockRepository)(nil).DeleteProgram), ctx, id)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockRepository) DeleteTemplate(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockRepositoryMockRecorder) DeleteTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockRepository)(nil).DeleteTemplate), ctx, id)
+}
+
+// GetProgram mocks base method.
+func (m *MockRepository) GetProgram(ctx context.Context, id int) (*program.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgram", ctx, id)
+	ret0, _ := ret[0].(*program.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgram indicates an expected call of GetProgram.
+func (mr *MockRepositoryMockRecorder) GetProgram(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgram", reflect.TypeOf((*MockRepository)(nil).GetProgram), ctx, id)
+}
+
+// GetTemplate mocks base method.
+func (m *MockRepository) GetTemplate(ctx context.Context, id int) (*program.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, id)
+	ret0, _ := ret[0].(*program.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockRepositoryMockRecorder) GetTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockRepository)(nil).GetTemplate), ctx, id)
+}
+
+// ListPrograms mocks base method.
+func (m *MockRepository) ListPrograms(ctx context.Context, userID int) ([]*program.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrograms", ctx, userID)
+	ret0, _ := ret[0].([]*program.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrograms indicates an expected call of ListPrograms.
+func (mr *MockRepositoryMockRecorder) ListPrograms(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrograms", reflect.TypeOf((*MockRepository)(nil).ListPrograms), ctx, userID)
+}
+
+// ListTemplates mocks base method.
+func (m *MockRepository) ListTemplates(ctx context.Context, userID int) ([]*program.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, userID)
+	ret0, _ := ret[0].([]*program.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockRepositoryMockRecorder) ListTemplates(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockRepository)(nil).ListTemplates), ctx, userID)
+}
+
+// UpdateProgram mocks base method.
+func (m *MockRepository) UpdateProgram(ctx context.Context, arg1 *program.Program) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgram", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgram indicates an expected call of UpdateProgram.
+func (mr *MockRepositoryMockRecorder) UpdateProgram(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgram", reflect.TypeOf((*MockRepository)(nil).UpdateProgram), ctx, arg1)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockRepository) UpdateTemplate(ctx context.Context, template *program.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockRepositoryMockRecorder) UpdateTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockRepository)(nil).UpdateTemplate), ctx, template)
+}
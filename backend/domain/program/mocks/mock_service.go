@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/program (interfaces: Service)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/program Service
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	program "workup_fitness/domain/program"
+	workout "workup_fitness/domain/workout"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateProgram mocks base method.
+func (m *MockService) CreateProgram(ctx context.Context, userID int, arg2 *program.Program) (*program.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProgram", ctx, userID, arg2)
+	ret0, _ := ret[0].(*program.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProgram indicates an expected call of CreateProgram.
+func (mr *MockServiceMockRecorder) CreateProgram(ctx, userID, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProgram", reflect.TypeOf((*MockService)(nil).CreateProgram), ctx, userID, arg2)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockService) CreateTemplate(ctx context.Context, userID int, template *program.Template) (*program.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, userID, template)
+	ret0, _ := ret[0].(*program.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockServiceMockRecorder) CreateTemplate(ctx, userID, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockService)(nil).CreateTemplate), ctx, userID, template)
+}
+
+// DeleteProgram mocks base method.
+func (m *MockService) DeleteProgram(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProgram", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProgram indicates an expected call of DeleteProgram.
+func (mr *MockServiceMockRecorder) DeleteProgram(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProgram", reflect.TypeOf((*MockService)(nil).DeleteProgram), ctx, userID, id)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockService) DeleteTemplate(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockServiceMockRecorder) DeleteTemplate(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockService)(nil).DeleteTemplate), ctx, userID, id)
+}
+
+// GetProgram mocks base method.
+func (m *MockService) GetProgram(ctx context.Context, userID, id int) (*program.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgram", ctx, userID, id)
+	ret0, _ := ret[0].(*program.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgram indicates an expected call of GetProgram.
+func (mr *MockServiceMockRecorder) GetProgram(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgram", reflect.TypeOf((*MockService)(nil).GetProgram), ctx, userID, id)
+}
+
+// GetTemplate mocks base method.
+func (m *MockService) GetTemplate(ctx context.Context, userID, id int) (*program.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, userID, id)
+	ret0, _ := ret[0].(*program.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockServiceMockRecorder) GetTemplate(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockService)(nil).GetTemplate), ctx, userID, id)
+}
+
+// Instantiate mocks base method.
+func (m *MockService) Instantiate(ctx context.Context, userID, id int, startDate time.Time, timeOfDay time.Duration) ([]*workout.Workout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", ctx, userID, id, startDate, timeOfDay)
+	ret0, _ := ret[0].([]*workout.Workout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockServiceMockRecorder) Instantiate(ctx, userID, id, startDate, timeOfDay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockService)(nil).Instantiate), ctx, userID, id, startDate, timeOfDay)
+}
+
+// ListPrograms mocks base method.
+func (m *MockService) ListPrograms(ctx context.Context, userID int) ([]*program.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrograms", ctx, userID)
+	ret0, _ := ret[0].([]*program.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrograms indicates an expected call of ListPrograms.
+func (mr *MockServiceMockRecorder) ListPrograms(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrograms", reflect.TypeOf((*MockService)(nil).ListPrograms), ctx, userID)
+}
+
+// ListTemplates mocks base method.
+func (m *MockService) ListTemplates(ctx context.Context, userID int) ([]*program.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, userID)
+	ret0, _ := ret[0].([]*program.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockServiceMockRecorder) ListTemplates(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockService)(nil).ListTemplates), ctx, userID)
+}
+
+// UpdateProgram mocks base method.
+func (m *MockService) UpdateProgram(ctx context.Context, userID int, arg2 *program.Program) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgram", ctx, userID, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgram indicates an expected call of UpdateProgram.
+func (mr *MockServiceMockRecorder) UpdateProgram(ctx, userID, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgram", reflect.TypeOf((*MockService)(nil).UpdateProgram), ctx, userID, arg2)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockService) UpdateTemplate(ctx context.Context, userID int, template *program.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, userID, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockServiceMockRecorder) UpdateTemplate(ctx, userID, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockService)(nil).UpdateTemplate), ctx, userID, template)
+}
@@ -0,0 +1,56 @@
+package program
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
+
+// Template is a reusable workout: an ordered list of exercises with
+// targets instead of fixed weights.
+type Template struct {
+	ID        int                `json:"id"`
+	UserID    int                `json:"user_id"`
+	Name      string             `json:"name"`
+	Notes     zero.String        `json:"notes"`
+	Exercises []TemplateExercise `json:"exercises"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// TemplateExercise is one exercise of a template. Intensity is given by at
+// most one of Weight, PercentE1RM and RPE; without any, the weight is left
+// for the lifter to pick.
+type TemplateExercise struct {
+	ID          int        `json:"id"`
+	TemplateID  int        `json:"template_id"`
+	Position    int        `json:"position"`
+	ExerciseID  int        `json:"exercise_id"`
+	Sets        int        `json:"sets"`
+	RepMin      int        `json:"rep_min"`
+	RepMax      int        `json:"rep_max"`
+	Weight      null.Float `json:"weight"`
+	PercentE1RM null.Float `json:"percent_e1rm"`
+	RPE         null.Float `json:"rpe"`
+}
+
+// Program lays templates out over a number of weeks.
+type Program struct {
+	ID        int          `json:"id"`
+	UserID    int          `json:"user_id"`
+	Name      string       `json:"name"`
+	Weeks     int          `json:"weeks"`
+	Notes     zero.String  `json:"notes"`
+	Days      []ProgramDay `json:"days"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// ProgramDay schedules a template on a day of a week of a program. Both
+// count from 1, and day 1 is the day the program starts on.
+type ProgramDay struct {
+	ID         int `json:"id"`
+	ProgramID  int `json:"program_id"`
+	Week       int `json:"week"`
+	Day        int `json:"day"`
+	TemplateID int `json:"template_id"`
+}
@@ -0,0 +1,416 @@
+package program
+
+import (
+	"context"
+	"database/sql"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/program Repository
+
+type Repository interface {
+	CreateTemplate(ctx context.Context, template *Template) (int, error)
+	GetTemplate(ctx context.Context, id int) (*Template, error)
+	ListTemplates(ctx context.Context, userID int) ([]*Template, error)
+	UpdateTemplate(ctx context.Context, template *Template) error
+	DeleteTemplate(ctx context.Context, id int) error
+	CreateProgram(ctx context.Context, program *Program) (int, error)
+	GetProgram(ctx context.Context, id int) (*Program, error)
+	ListPrograms(ctx context.Context, userID int) ([]*Program, error)
+	UpdateProgram(ctx context.Context, program *Program) error
+	DeleteProgram(ctx context.Context, id int) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+const (
+	selectTemplate = `SELECT id, user_id, name, notes, created_at FROM workout_templates`
+	selectProgram  = `SELECT id, user_id, name, weeks, notes, created_at FROM programs`
+)
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTemplate(row scanner) (*Template, error) {
+	var template Template
+	err := row.Scan(&template.ID, &template.UserID, &template.Name, &template.Notes, &template.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func scanProgram(row scanner) (*Program, error) {
+	var program Program
+	err := row.Scan(&program.ID, &program.UserID, &program.Name, &program.Weeks, &program.Notes, &program.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
+
+// insertTemplateExercises stores exercises in order, numbering their
+// positions from 1.
+func insertTemplateExercises(ctx context.Context, tx *sql.Tx, templateID int, exercises []TemplateExercise) error {
+	for i := range exercises {
+		entry := &exercises[i]
+		entry.TemplateID = templateID
+		entry.Position = i + 1
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO template_exercises (template_id, position, exercise_id, sets, rep_min, rep_max, weight,
+				percent_e1rm, rpe) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			templateID, entry.Position, entry.ExerciseID, entry.Sets, entry.RepMin, entry.RepMax, entry.Weight,
+			entry.PercentE1RM, entry.RPE,
+		)
+		if err := dbutil.ProcessInsertError(err, ErrInvalidTemplate, ErrMissingField); err != nil {
+			return err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		entry.ID = int(id)
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) listTemplateExercises(ctx context.Context, templateID int) ([]TemplateExercise, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT id, template_id, position, exercise_id, sets, rep_min, rep_max, weight, percent_e1rm, rpe
+			FROM template_exercises WHERE template_id = ? ORDER BY position`,
+		templateID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exercises := make([]TemplateExercise, 0)
+	for rows.Next() {
+		var entry TemplateExercise
+		err := rows.Scan(&entry.ID, &entry.TemplateID, &entry.Position, &entry.ExerciseID, &entry.Sets, &entry.RepMin,
+			&entry.RepMax, &entry.Weight, &entry.PercentE1RM, &entry.RPE)
+		if err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, entry)
+	}
+	return exercises, rows.Err()
+}
+
+func insertProgramDays(ctx context.Context, tx *sql.Tx, programID int, days []ProgramDay) error {
+	for i := range days {
+		day := &days[i]
+		day.ProgramID = programID
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO program_days (program_id, week, day, template_id) VALUES (?, ?, ?, ?)`,
+			programID, day.Week, day.Day, day.TemplateID,
+		)
+		if err := dbutil.ProcessInsertError(err, ErrInvalidProgram, ErrMissingField); err != nil {
+			return err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		day.ID = int(id)
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) listProgramDays(ctx context.Context, programID int) ([]ProgramDay, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT id, program_id, week, day, template_id FROM program_days WHERE program_id = ? ORDER BY week, day, id`,
+		programID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := make([]ProgramDay, 0)
+	for rows.Next() {
+		var day ProgramDay
+		if err := rows.Scan(&day.ID, &day.ProgramID, &day.Week, &day.Day, &day.TemplateID); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+func (repo *sqliteRepository) CreateTemplate(ctx context.Context, template *Template) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO workout_templates (user_id, name, notes) VALUES (?, ?, ?)`,
+		template.UserID, template.Name, template.Notes,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := insertTemplateExercises(ctx, tx, int(id), template.Exercises); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetTemplate(ctx context.Context, id int) (*Template, error) {
+	template, err := scanTemplate(repo.db.QueryRowContext(ctx, selectTemplate+` WHERE id = ?`, id))
+	if err := dbutil.ProcessRowError(err, ErrTemplateNotFound); err != nil {
+		return nil, err
+	}
+
+	template.Exercises, err = repo.listTemplateExercises(ctx, template.ID)
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (repo *sqliteRepository) ListTemplates(ctx context.Context, userID int) ([]*Template, error) {
+	rows, err := repo.db.QueryContext(ctx, selectTemplate+` WHERE user_id = ? ORDER BY name, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]*Template, 0)
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, template := range templates {
+		template.Exercises, err = repo.listTemplateExercises(ctx, template.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+func (repo *sqliteRepository) UpdateTemplate(ctx context.Context, template *Template) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE workout_templates SET name = ?, notes = ? WHERE id = ?`,
+		template.Name, template.Notes, template.ID,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTemplateNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM template_exercises WHERE template_id = ?`, template.ID); err != nil {
+		return err
+	}
+	if err := insertTemplateExercises(ctx, tx, template.ID, template.Exercises); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteTemplate removes a template that no program uses.
+func (repo *sqliteRepository) DeleteTemplate(ctx context.Context, id int) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var used bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM program_days WHERE template_id = ?)`, id).Scan(&used)
+	if err != nil {
+		return err
+	}
+	if used {
+		return ErrTemplateInUse
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM template_exercises WHERE template_id = ?`, id); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM workout_templates WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) CreateProgram(ctx context.Context, program *Program) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO programs (user_id, name, weeks, notes) VALUES (?, ?, ?, ?)`,
+		program.UserID, program.Name, program.Weeks, program.Notes,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := insertProgramDays(ctx, tx, int(id), program.Days); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetProgram(ctx context.Context, id int) (*Program, error) {
+	program, err := scanProgram(repo.db.QueryRowContext(ctx, selectProgram+` WHERE id = ?`, id))
+	if err := dbutil.ProcessRowError(err, ErrProgramNotFound); err != nil {
+		return nil, err
+	}
+
+	program.Days, err = repo.listProgramDays(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+	return program, nil
+}
+
+func (repo *sqliteRepository) ListPrograms(ctx context.Context, userID int) ([]*Program, error) {
+	rows, err := repo.db.QueryContext(ctx, selectProgram+` WHERE user_id = ? ORDER BY name, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	programs := make([]*Program, 0)
+	for rows.Next() {
+		program, err := scanProgram(rows)
+		if err != nil {
+			return nil, err
+		}
+		programs = append(programs, program)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, program := range programs {
+		program.Days, err = repo.listProgramDays(ctx, program.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return programs, nil
+}
+
+func (repo *sqliteRepository) UpdateProgram(ctx context.Context, program *Program) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE programs SET name = ?, weeks = ?, notes = ? WHERE id = ?`,
+		program.Name, program.Weeks, program.Notes, program.ID,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProgramNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM program_days WHERE program_id = ?`, program.ID); err != nil {
+		return err
+	}
+	if err := insertProgramDays(ctx, tx, program.ID, program.Days); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) DeleteProgram(ctx context.Context, id int) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM program_days WHERE program_id = ?`, id); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM programs WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProgramNotFound
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,129 @@
+package program_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/program"
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (program.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := program.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO exercises (name) VALUES ('Squat'), ('Bench press')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_Templates(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.CreateTemplate(ctx, &program.Template{
+		UserID: 1,
+		Name:   "Lower A",
+		Exercises: []program.TemplateExercise{
+			{ExerciseID: 1, Sets: 5, RepMin: 5, RepMax: 5, PercentE1RM: null.FloatFrom(80)},
+			{ExerciseID: 2, Sets: 3, RepMin: 8, RepMax: 12, RPE: null.FloatFrom(8)},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = repo.CreateTemplate(ctx, &program.Template{UserID: 1, Name: "Lower A"})
+	require.ErrorIs(t, err, program.ErrAlreadyExists)
+	_, err = repo.CreateTemplate(ctx, &program.Template{UserID: 2, Name: "Lower A"})
+	require.NoError(t, err, "names are unique per user")
+
+	found, err := repo.GetTemplate(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "Lower A", found.Name)
+	require.Len(t, found.Exercises, 2)
+	require.Equal(t, 1, found.Exercises[0].Position)
+	require.Equal(t, 80.0, found.Exercises[0].PercentE1RM.Float64)
+	require.False(t, found.Exercises[0].Weight.Valid)
+	require.Equal(t, 2, found.Exercises[1].Position)
+	require.Equal(t, 12, found.Exercises[1].RepMax)
+
+	found.Name = "Lower B"
+	found.Notes = zero.StringFrom("heavy day")
+	found.Exercises = []program.TemplateExercise{{ExerciseID: 2, Sets: 4, RepMin: 6, RepMax: 6, Weight: null.FloatFrom(80)}}
+	require.NoError(t, repo.UpdateTemplate(ctx, found))
+
+	updated, err := repo.GetTemplate(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "Lower B", updated.Name)
+	require.Equal(t, "heavy day", updated.Notes.String)
+	require.Len(t, updated.Exercises, 1)
+	require.Equal(t, 80.0, updated.Exercises[0].Weight.Float64)
+
+	templates, err := repo.ListTemplates(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Len(t, templates[0].Exercises, 1)
+
+	require.NoError(t, repo.DeleteTemplate(ctx, id))
+	_, err = repo.GetTemplate(ctx, id)
+	require.ErrorIs(t, err, program.ErrTemplateNotFound)
+	require.ErrorIs(t, repo.DeleteTemplate(ctx, id), program.ErrTemplateNotFound)
+	require.ErrorIs(t, repo.UpdateTemplate(ctx, found), program.ErrTemplateNotFound)
+}
+
+func TestRepository_Programs(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	templateID, err := repo.CreateTemplate(ctx, &program.Template{
+		UserID:    1,
+		Name:      "Full body",
+		Exercises: []program.TemplateExercise{{ExerciseID: 1, Sets: 3, RepMin: 5, RepMax: 5}},
+	})
+	require.NoError(t, err)
+
+	id, err := repo.CreateProgram(ctx, &program.Program{
+		UserID: 1,
+		Name:   "Beginner",
+		Weeks:  2,
+		Days: []program.ProgramDay{
+			{Week: 2, Day: 1, TemplateID: templateID},
+			{Week: 1, Day: 3, TemplateID: templateID},
+			{Week: 1, Day: 1, TemplateID: templateID},
+		},
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetProgram(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, found.Weeks)
+	require.Len(t, found.Days, 3)
+	require.Equal(t, program.ProgramDay{ID: found.Days[0].ID, ProgramID: id, Week: 1, Day: 1, TemplateID: templateID}, found.Days[0])
+	require.Equal(t, 3, found.Days[1].Day, "days are ordered by week and day")
+	require.Equal(t, 2, found.Days[2].Week)
+
+	require.ErrorIs(t, repo.DeleteTemplate(ctx, templateID), program.ErrTemplateInUse)
+
+	found.Weeks = 1
+	found.Days = found.Days[:2]
+	require.NoError(t, repo.UpdateProgram(ctx, found))
+	programs, err := repo.ListPrograms(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, programs, 1)
+	require.Len(t, programs[0].Days, 2)
+
+	require.NoError(t, repo.DeleteProgram(ctx, id))
+	_, err = repo.GetProgram(ctx, id)
+	require.ErrorIs(t, err, program.ErrProgramNotFound)
+	require.NoError(t, repo.DeleteTemplate(ctx, templateID), "the template is free once the program is gone")
+}
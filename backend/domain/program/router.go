@@ -0,0 +1,27 @@
+package program
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("workouts"))
+		r.Get("/templates", h.ListTemplates)
+		r.Post("/templates", h.CreateTemplate)
+		r.Get("/templates/{id}", h.GetTemplate)
+		r.Put("/templates/{id}", h.UpdateTemplate)
+		r.Delete("/templates/{id}", h.DeleteTemplate)
+		r.Get("/programs", h.ListPrograms)
+		r.Post("/programs", h.CreateProgram)
+		r.Get("/programs/{id}", h.GetProgram)
+		r.Put("/programs/{id}", h.UpdateProgram)
+		r.Delete("/programs/{id}", h.DeleteProgram)
+		r.Post("/programs/{id}/instantiate", h.Instantiate)
+	})
+}
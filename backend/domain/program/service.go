@@ -0,0 +1,432 @@
+package program
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"workup_fitness/domain/analytics"
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/user"
+	"workup_fitness/domain/workout"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/program Service
+
+const (
+	maxSets    = 20
+	maxReps    = 100
+	maxWeeks   = 52
+	daysInWeek = 7
+	minRPE     = 1
+	maxRPE     = 10
+	minPercent = 1
+	maxPercent = 120
+	// weightStep is what weights derived from an e1RM are rounded to.
+	// Exercises on a simulator are snapped to its stack afterwards.
+	weightStep = 0.5
+)
+
+type ExerciseService interface {
+	GetByID(ctx context.Context, id int) (*exercise.Exercise, error)
+}
+
+type WorkoutService interface {
+	CreateBatch(ctx context.Context, userID int, workouts []*workout.Workout, snapWeights bool) error
+}
+
+// RecordService provides the current e1RM of an exercise for templates
+// that prescribe a percentage of it.
+type RecordService interface {
+	Bests(ctx context.Context, userID, exerciseID int) ([]*analytics.Record, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id int) (*user.User, error)
+}
+
+type Service interface {
+	CreateTemplate(ctx context.Context, userID int, template *Template) (*Template, error)
+	GetTemplate(ctx context.Context, userID, id int) (*Template, error)
+	ListTemplates(ctx context.Context, userID int) ([]*Template, error)
+	UpdateTemplate(ctx context.Context, userID int, template *Template) error
+	DeleteTemplate(ctx context.Context, userID, id int) error
+	CreateProgram(ctx context.Context, userID int, program *Program) (*Program, error)
+	GetProgram(ctx context.Context, userID, id int) (*Program, error)
+	ListPrograms(ctx context.Context, userID int) ([]*Program, error)
+	UpdateProgram(ctx context.Context, userID int, program *Program) error
+	DeleteProgram(ctx context.Context, userID, id int) error
+	// Instantiate schedules every day of a program as a workout, counting
+	// from startDate. The workouts start at timeOfDay in the user's time
+	// zone.
+	Instantiate(ctx context.Context, userID, id int, startDate time.Time, timeOfDay time.Duration) ([]*workout.Workout, error)
+}
+
+type serviceImpl struct {
+	repo            Repository
+	exerciseService ExerciseService
+	workoutService  WorkoutService
+	recordService   RecordService
+	userService     UserService
+}
+
+func NewService(repo Repository, exerciseService ExerciseService, workoutService WorkoutService, recordService RecordService, userService UserService) *serviceImpl {
+	logger.Default().Debug().Msg("Creating program service...")
+	res := &serviceImpl{
+		repo:            repo,
+		exerciseService: exerciseService,
+		workoutService:  workoutService,
+		recordService:   recordService,
+		userService:     userService,
+	}
+	logger.Default().Debug().Msg("Created program service")
+	return res
+}
+
+// getTemplate loads a template and makes sure it belongs to userID.
+func (s *serviceImpl) getTemplate(ctx context.Context, userID, id int) (*Template, error) {
+	template, err := s.repo.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if template.UserID != userID {
+		return nil, ErrInvalidPermissions
+	}
+	return template, nil
+}
+
+// getProgram loads a program and makes sure it belongs to userID.
+func (s *serviceImpl) getProgram(ctx context.Context, userID, id int) (*Program, error) {
+	program, err := s.repo.GetProgram(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if program.UserID != userID {
+		return nil, ErrInvalidPermissions
+	}
+	return program, nil
+}
+
+func (s *serviceImpl) checkTemplate(ctx context.Context, template *Template) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" {
+		return errors.Join(ErrMissingField, errors.New("name is required"))
+	}
+	if len(template.Exercises) == 0 {
+		return fmt.Errorf("%w: a template needs at least one exercise", ErrInvalidTemplate)
+	}
+
+	for i := range template.Exercises {
+		entry := &template.Exercises[i]
+		if entry.ExerciseID == 0 {
+			return errors.Join(ErrMissingField, errors.New("exercise_id is required"))
+		}
+		if entry.Sets < 1 || entry.Sets > maxSets {
+			return fmt.Errorf("%w: sets must be between 1 and %d", ErrInvalidTemplate, maxSets)
+		}
+		if entry.RepMax == 0 {
+			entry.RepMax = entry.RepMin
+		}
+		if entry.RepMin < 1 || entry.RepMax < entry.RepMin || entry.RepMax > maxReps {
+			return fmt.Errorf("%w: rep_min must be at least 1 and rep_max between rep_min and %d", ErrInvalidTemplate, maxReps)
+		}
+
+		intensities := 0
+		if entry.Weight.Valid {
+			intensities++
+			if entry.Weight.Float64 < 0 {
+				return fmt.Errorf("%w: weight cannot be negative", ErrInvalidTemplate)
+			}
+		}
+		if entry.PercentE1RM.Valid {
+			intensities++
+			if percent := entry.PercentE1RM.Float64; percent < minPercent || percent > maxPercent {
+				return fmt.Errorf("%w: percent_e1rm must be between %d and %d", ErrInvalidTemplate, minPercent, maxPercent)
+			}
+		}
+		if entry.RPE.Valid {
+			intensities++
+			if rpe := entry.RPE.Float64; rpe < minRPE || rpe > maxRPE || math.Mod(rpe*2, 1) != 0 {
+				return fmt.Errorf("%w: rpe must be between %d and %d in steps of 0.5", ErrInvalidTemplate, minRPE, maxRPE)
+			}
+		}
+		if intensities > 1 {
+			return fmt.Errorf("%w: give at most one of weight, percent_e1rm and rpe", ErrInvalidTemplate)
+		}
+
+		_, err := s.exerciseService.GetByID(ctx, entry.ExerciseID)
+		if errors.Is(err, exercise.ErrExerciseNotFound) {
+			return ErrExerciseNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *serviceImpl) checkProgram(ctx context.Context, userID int, program *Program) error {
+	program.Name = strings.TrimSpace(program.Name)
+	if program.Name == "" {
+		return errors.Join(ErrMissingField, errors.New("name is required"))
+	}
+	if program.Weeks < 1 || program.Weeks > maxWeeks {
+		return fmt.Errorf("%w: weeks must be between 1 and %d", ErrInvalidProgram, maxWeeks)
+	}
+	if len(program.Days) == 0 {
+		return fmt.Errorf("%w: a program needs at least one day", ErrInvalidProgram)
+	}
+
+	checked := make(map[int]bool)
+	for _, day := range program.Days {
+		if day.Week < 1 || day.Week > program.Weeks {
+			return fmt.Errorf("%w: week must be between 1 and %d", ErrInvalidProgram, program.Weeks)
+		}
+		if day.Day < 1 || day.Day > daysInWeek {
+			return fmt.Errorf("%w: day must be between 1 and %d", ErrInvalidProgram, daysInWeek)
+		}
+		if day.TemplateID == 0 {
+			return errors.Join(ErrMissingField, errors.New("template_id is required"))
+		}
+		if checked[day.TemplateID] {
+			continue
+		}
+
+		_, err := s.getTemplate(ctx, userID, day.TemplateID)
+		if errors.Is(err, ErrTemplateNotFound) || errors.Is(err, ErrInvalidPermissions) {
+			return ErrUnknownTemplate
+		}
+		if err != nil {
+			return err
+		}
+		checked[day.TemplateID] = true
+	}
+	return nil
+}
+
+// prescribe turns a template exercise into a workout entry. Sets aim for
+// the bottom of the rep range. A percentage of e1RM becomes a weight from
+// the current best e1RM; without one, and for RPE targets, the weight is
+// left at 0 for the lifter to pick.
+func (s *serviceImpl) prescribe(ctx context.Context, userID int, entry TemplateExercise) (workout.WorkoutExercise, error) {
+	res := workout.WorkoutExercise{
+		ExerciseID:  entry.ExerciseID,
+		Weight:      entry.Weight.Float64,
+		Sets:        entry.Sets,
+		Repetitions: entry.RepMin,
+	}
+	if !entry.PercentE1RM.Valid {
+		return res, nil
+	}
+
+	bests, err := s.recordService.Bests(ctx, userID, entry.ExerciseID)
+	if err != nil {
+		return res, err
+	}
+	for _, record := range bests {
+		if record.Kind == analytics.KindBestE1RM {
+			weight := record.Value * entry.PercentE1RM.Float64 / 100
+			res.Weight = math.Round(weight/weightStep) * weightStep
+			break
+		}
+	}
+	return res, nil
+}
+
+func (s *serviceImpl) CreateTemplate(ctx context.Context, userID int, template *Template) (*Template, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating template")
+
+	if err := s.checkTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	template.UserID = userID
+	createdID, err := s.repo.CreateTemplate(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.GetTemplate(ctx, createdID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("template_id", createdID).Int("user_id", userID).Msg("Created template")
+	return created, nil
+}
+
+func (s *serviceImpl) GetTemplate(ctx context.Context, userID, id int) (*Template, error) {
+	logger.Ctx(ctx).Info().Int("template_id", id).Msg("Getting template")
+	template, err := s.getTemplate(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("template_id", id).Msg("Got template")
+	return template, nil
+}
+
+func (s *serviceImpl) ListTemplates(ctx context.Context, userID int) ([]*Template, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing templates")
+	templates, err := s.repo.ListTemplates(ctx, userID)
+	logger.Ctx(ctx).Info().Int("count", len(templates)).Int("user_id", userID).Msg("Listed templates")
+	return templates, err
+}
+
+func (s *serviceImpl) UpdateTemplate(ctx context.Context, userID int, template *Template) error {
+	logger.Ctx(ctx).Info().Int("template_id", template.ID).Msg("Updating template")
+
+	if _, err := s.getTemplate(ctx, userID, template.ID); err != nil {
+		return err
+	}
+	if err := s.checkTemplate(ctx, template); err != nil {
+		return err
+	}
+
+	template.UserID = userID
+	if err := s.repo.UpdateTemplate(ctx, template); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("template_id", template.ID).Msg("Updated template")
+	return nil
+}
+
+// DeleteTemplate fails with ErrTemplateInUse while a program schedules the
+// template.
+func (s *serviceImpl) DeleteTemplate(ctx context.Context, userID, id int) error {
+	logger.Ctx(ctx).Info().Int("template_id", id).Msg("Deleting template")
+
+	if _, err := s.getTemplate(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTemplate(ctx, id); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("template_id", id).Msg("Deleted template")
+	return nil
+}
+
+func (s *serviceImpl) CreateProgram(ctx context.Context, userID int, program *Program) (*Program, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating program")
+
+	if err := s.checkProgram(ctx, userID, program); err != nil {
+		return nil, err
+	}
+
+	program.UserID = userID
+	createdID, err := s.repo.CreateProgram(ctx, program)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.GetProgram(ctx, createdID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("program_id", createdID).Int("user_id", userID).Msg("Created program")
+	return created, nil
+}
+
+func (s *serviceImpl) GetProgram(ctx context.Context, userID, id int) (*Program, error) {
+	logger.Ctx(ctx).Info().Int("program_id", id).Msg("Getting program")
+	program, err := s.getProgram(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("program_id", id).Msg("Got program")
+	return program, nil
+}
+
+func (s *serviceImpl) ListPrograms(ctx context.Context, userID int) ([]*Program, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing programs")
+	programs, err := s.repo.ListPrograms(ctx, userID)
+	logger.Ctx(ctx).Info().Int("count", len(programs)).Int("user_id", userID).Msg("Listed programs")
+	return programs, err
+}
+
+func (s *serviceImpl) UpdateProgram(ctx context.Context, userID int, program *Program) error {
+	logger.Ctx(ctx).Info().Int("program_id", program.ID).Msg("Updating program")
+
+	if _, err := s.getProgram(ctx, userID, program.ID); err != nil {
+		return err
+	}
+	if err := s.checkProgram(ctx, userID, program); err != nil {
+		return err
+	}
+
+	program.UserID = userID
+	if err := s.repo.UpdateProgram(ctx, program); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("program_id", program.ID).Msg("Updated program")
+	return nil
+}
+
+// DeleteProgram removes the program. Workouts already scheduled from it
+// are kept.
+func (s *serviceImpl) DeleteProgram(ctx context.Context, userID, id int) error {
+	logger.Ctx(ctx).Info().Int("program_id", id).Msg("Deleting program")
+
+	if _, err := s.getProgram(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteProgram(ctx, id); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("program_id", id).Msg("Deleted program")
+	return nil
+}
+
+func (s *serviceImpl) Instantiate(ctx context.Context, userID, id int, startDate time.Time, timeOfDay time.Duration) ([]*workout.Workout, error) {
+	logger.Ctx(ctx).Info().Int("program_id", id).Msg("Instantiating program")
+
+	if startDate.IsZero() {
+		return nil, errors.Join(ErrMissingField, errors.New("start_date is required"))
+	}
+	if timeOfDay < 0 || timeOfDay >= 24*time.Hour {
+		return nil, fmt.Errorf("%w: time must be within the day", ErrInvalidInstantiation)
+	}
+
+	program, err := s.getProgram(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	found, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := found.Location()
+	year, month, day := startDate.Date()
+	hour, minute := int(timeOfDay/time.Hour), int(timeOfDay%time.Hour/time.Minute)
+
+	templates := make(map[int]*Template)
+	workouts := make([]*workout.Workout, 0, len(program.Days))
+	for _, programDay := range program.Days {
+		template, ok := templates[programDay.TemplateID]
+		if !ok {
+			template, err = s.getTemplate(ctx, userID, programDay.TemplateID)
+			if err != nil {
+				return nil, err
+			}
+			templates[programDay.TemplateID] = template
+		}
+
+		offset := (programDay.Week-1)*daysInWeek + programDay.Day - 1
+		scheduledAt := time.Date(year, month, day+offset, hour, minute, 0, 0, loc)
+		planned := &workout.Workout{ScheduledAt: scheduledAt.UTC(), Exercises: make([]workout.WorkoutExercise, 0, len(template.Exercises))}
+		for _, entry := range template.Exercises {
+			prescribed, err := s.prescribe(ctx, userID, entry)
+			if err != nil {
+				return nil, err
+			}
+			planned.Exercises = append(planned.Exercises, prescribed)
+		}
+		workouts = append(workouts, planned)
+	}
+
+	if err := s.workoutService.CreateBatch(ctx, userID, workouts, true); err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("program_id", id).Int("workouts", len(workouts)).Msg("Instantiated program")
+	return workouts, nil
+}
@@ -0,0 +1,263 @@
+package program_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/analytics"
+	analyticsMocks "workup_fitness/domain/analytics/mocks"
+	"workup_fitness/domain/exercise"
+	exerciseMocks "workup_fitness/domain/exercise/mocks"
+	"workup_fitness/domain/program"
+	"workup_fitness/domain/program/mocks"
+	simulatorMocks "workup_fitness/domain/simulator/mocks"
+	"workup_fitness/domain/user"
+	userMocks "workup_fitness/domain/user/mocks"
+	"workup_fitness/domain/workout"
+	workoutMocks "workup_fitness/domain/workout/mocks"
+	"workup_fitness/pkg/metrics"
+)
+
+type testService struct {
+	program.Service
+	repo            *mocks.MockRepository
+	exerciseService *exerciseMocks.MockService
+	workoutService  *workoutMocks.MockService
+	recordService   *analyticsMocks.MockService
+	userService     *userMocks.MockService
+}
+
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	exerciseService := exerciseMocks.NewMockService(ctrl)
+	workoutService := workoutMocks.NewMockService(ctrl)
+	recordService := analyticsMocks.NewMockService(ctrl)
+	userService := userMocks.NewMockService(ctrl)
+	return testService{
+		Service:         program.NewService(repo, exerciseService, workoutService, recordService, userService),
+		repo:            repo,
+		exerciseService: exerciseService,
+		workoutService:  workoutService,
+		recordService:   recordService,
+		userService:     userService,
+	}
+}
+
+func TestService_CreateTemplate(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1}, nil)
+	svc.repo.EXPECT().
+		CreateTemplate(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, template *program.Template) (int, error) {
+			require.Equal(t, 3, template.UserID)
+			require.Equal(t, "Push", template.Name, "names are trimmed")
+			require.Equal(t, 5, template.Exercises[0].RepMax, "rep_max defaults to rep_min")
+			return 7, nil
+		})
+	svc.repo.EXPECT().GetTemplate(ctx, 7).Return(&program.Template{ID: 7, UserID: 3, Name: "Push"}, nil)
+
+	created, err := svc.CreateTemplate(ctx, 3, &program.Template{
+		Name:      " Push ",
+		Exercises: []program.TemplateExercise{{ExerciseID: 1, Sets: 5, RepMin: 5}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 7, created.ID)
+}
+
+func TestService_CreateTemplate_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	for name, entry := range map[string]program.TemplateExercise{
+		"no sets":          {ExerciseID: 1, RepMin: 5},
+		"too many sets":    {ExerciseID: 1, Sets: 21, RepMin: 5},
+		"no reps":          {ExerciseID: 1, Sets: 3},
+		"inverted range":   {ExerciseID: 1, Sets: 3, RepMin: 10, RepMax: 8},
+		"negative weight":  {ExerciseID: 1, Sets: 3, RepMin: 5, Weight: null.FloatFrom(-5)},
+		"percent too high": {ExerciseID: 1, Sets: 3, RepMin: 5, PercentE1RM: null.FloatFrom(150)},
+		"odd rpe":          {ExerciseID: 1, Sets: 3, RepMin: 5, RPE: null.FloatFrom(8.3)},
+		"two intensities":  {ExerciseID: 1, Sets: 3, RepMin: 5, Weight: null.FloatFrom(100), RPE: null.FloatFrom(8)},
+	} {
+		_, err := svc.CreateTemplate(ctx, 3, &program.Template{Name: "Push", Exercises: []program.TemplateExercise{entry}})
+		require.ErrorIs(t, err, program.ErrInvalidTemplate, name)
+	}
+
+	_, err := svc.CreateTemplate(ctx, 3, &program.Template{Name: "Push"})
+	require.ErrorIs(t, err, program.ErrInvalidTemplate)
+	_, err = svc.CreateTemplate(ctx, 3, &program.Template{Exercises: []program.TemplateExercise{{ExerciseID: 1, Sets: 3, RepMin: 5}}})
+	require.ErrorIs(t, err, program.ErrMissingField)
+
+	svc.exerciseService.EXPECT().GetByID(ctx, 9).Return(nil, exercise.ErrExerciseNotFound)
+	_, err = svc.CreateTemplate(ctx, 3, &program.Template{Name: "Push", Exercises: []program.TemplateExercise{{ExerciseID: 9, Sets: 3, RepMin: 5}}})
+	require.ErrorIs(t, err, program.ErrExerciseNotFound)
+}
+
+func TestService_DeleteTemplate_OtherUser(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetTemplate(ctx, 7).Return(&program.Template{ID: 7, UserID: 4}, nil)
+
+	require.ErrorIs(t, svc.DeleteTemplate(ctx, 3, 7), program.ErrInvalidPermissions)
+}
+
+func TestService_CreateProgram_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	for name, day := range map[string]program.ProgramDay{
+		"week past the end": {Week: 3, Day: 1, TemplateID: 7},
+		"week zero":         {Week: 0, Day: 1, TemplateID: 7},
+		"day eight":         {Week: 1, Day: 8, TemplateID: 7},
+	} {
+		_, err := svc.CreateProgram(ctx, 3, &program.Program{Name: "Block", Weeks: 2, Days: []program.ProgramDay{day}})
+		require.ErrorIs(t, err, program.ErrInvalidProgram, name)
+	}
+
+	_, err := svc.CreateProgram(ctx, 3, &program.Program{Name: "Block", Weeks: 53, Days: []program.ProgramDay{{Week: 1, Day: 1, TemplateID: 7}}})
+	require.ErrorIs(t, err, program.ErrInvalidProgram)
+
+	svc.repo.EXPECT().GetTemplate(ctx, 7).Return(&program.Template{ID: 7, UserID: 4}, nil)
+	_, err = svc.CreateProgram(ctx, 3, &program.Program{Name: "Block", Weeks: 2, Days: []program.ProgramDay{{Week: 1, Day: 1, TemplateID: 7}}})
+	require.ErrorIs(t, err, program.ErrUnknownTemplate, "templates of other users cannot be scheduled")
+}
+
+func TestService_Instantiate(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetProgram(ctx, 5).Return(&program.Program{
+		ID:     5,
+		UserID: 3,
+		Weeks:  2,
+		Days: []program.ProgramDay{
+			{Week: 1, Day: 1, TemplateID: 7},
+			{Week: 1, Day: 3, TemplateID: 8},
+			{Week: 2, Day: 1, TemplateID: 7},
+		},
+	}, nil)
+	svc.userService.EXPECT().GetByID(ctx, 3).Return(&user.User{ID: 3, TimeZone: zero.StringFrom("America/New_York")}, nil)
+	svc.repo.EXPECT().GetTemplate(ctx, 7).Return(&program.Template{ID: 7, UserID: 3, Exercises: []program.TemplateExercise{
+		{ExerciseID: 1, Sets: 5, RepMin: 5, RepMax: 5, PercentE1RM: null.FloatFrom(75)},
+	}}, nil)
+	svc.repo.EXPECT().GetTemplate(ctx, 8).Return(&program.Template{ID: 8, UserID: 3, Exercises: []program.TemplateExercise{
+		{ExerciseID: 2, Sets: 3, RepMin: 8, RepMax: 12, Weight: null.FloatFrom(60)},
+		{ExerciseID: 1, Sets: 2, RepMin: 10, RepMax: 10, RPE: null.FloatFrom(7)},
+	}}, nil)
+	svc.recordService.EXPECT().
+		Bests(ctx, 3, 1).
+		Return([]*analytics.Record{
+			{ExerciseID: 1, Kind: analytics.KindHeaviestWeight, Value: 140},
+			{ExerciseID: 1, Kind: analytics.KindBestE1RM, Value: 151.3},
+		}, nil).
+		Times(2)
+	svc.workoutService.EXPECT().
+		CreateBatch(ctx, 3, gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, _ int, workouts []*workout.Workout, _ bool) error {
+			for i, planned := range workouts {
+				planned.ID = 20 + i
+			}
+			return nil
+		})
+
+	// The program starts the day before clocks change in New York.
+	start := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	workouts, err := svc.Instantiate(ctx, 3, 5, start, 18*time.Hour+30*time.Minute)
+	require.NoError(t, err)
+	require.Len(t, workouts, 3)
+
+	require.Equal(t, time.Date(2026, 3, 7, 23, 30, 0, 0, time.UTC), workouts[0].ScheduledAt)
+	require.Equal(t, time.Date(2026, 3, 9, 22, 30, 0, 0, time.UTC), workouts[1].ScheduledAt)
+	require.Equal(t, time.Date(2026, 3, 14, 22, 30, 0, 0, time.UTC), workouts[2].ScheduledAt)
+
+	require.Equal(t, []workout.WorkoutExercise{{ExerciseID: 1, Weight: 113.5, Sets: 5, Repetitions: 5}}, workouts[0].Exercises)
+	require.Equal(t, []workout.WorkoutExercise{
+		{ExerciseID: 2, Weight: 60, Sets: 3, Repetitions: 8},
+		{ExerciseID: 1, Weight: 0, Sets: 2, Repetitions: 10},
+	}, workouts[1].Exercises)
+	require.Equal(t, 22, workouts[2].ID)
+}
+
+// counterValue scrapes one unlabelled counter from the default registry.
+func counterValue(t *testing.T, name string) float64 {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	metrics.Default.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	scanner := bufio.NewScanner(rr.Body)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), name+" "); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			require.NoError(t, err)
+			return parsed
+		}
+	}
+	return 0
+}
+
+func TestService_Instantiate_DoesNotCountLoggedWorkouts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	userService := userMocks.NewMockService(ctrl)
+	exerciseService := exerciseMocks.NewMockService(ctrl)
+	workoutRepo := workoutMocks.NewMockRepository(ctrl)
+	workoutService := workout.NewService(workoutRepo, exerciseService, simulatorMocks.NewMockService(ctrl))
+	svc := program.NewService(repo, exerciseService, workoutService, analyticsMocks.NewMockService(ctrl), userService)
+	ctx := context.Background()
+
+	repo.EXPECT().GetProgram(ctx, 5).Return(&program.Program{
+		ID:     5,
+		UserID: 3,
+		Weeks:  1,
+		Days: []program.ProgramDay{
+			{Week: 1, Day: 1, TemplateID: 7},
+			{Week: 1, Day: 3, TemplateID: 7},
+			{Week: 1, Day: 5, TemplateID: 7},
+		},
+	}, nil)
+	userService.EXPECT().GetByID(ctx, 3).Return(&user.User{ID: 3}, nil)
+	repo.EXPECT().GetTemplate(ctx, 7).Return(&program.Template{ID: 7, UserID: 3, Exercises: []program.TemplateExercise{
+		{ExerciseID: 2, Sets: 3, RepMin: 8, RepMax: 12, Weight: null.FloatFrom(60)},
+	}}, nil)
+	exerciseService.EXPECT().GetByID(ctx, 2).Return(&exercise.Exercise{ID: 2}, nil).Times(3)
+	workoutRepo.EXPECT().CreateBatch(ctx, gomock.Len(3)).Return(nil)
+
+	logged := counterValue(t, "workup_workouts_logged_total")
+	scheduled := counterValue(t, "workup_workouts_scheduled_total")
+
+	_, err := svc.Instantiate(ctx, 3, 5, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 18*time.Hour)
+	require.NoError(t, err)
+
+	require.Equal(t, logged, counterValue(t, "workup_workouts_logged_total"), "planned workouts are not logged ones")
+	require.Equal(t, scheduled+3, counterValue(t, "workup_workouts_scheduled_total"))
+}
+
+func TestService_Instantiate_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.Instantiate(ctx, 3, 5, time.Time{}, 0)
+	require.ErrorIs(t, err, program.ErrMissingField)
+	_, err = svc.Instantiate(ctx, 3, 5, time.Now(), 25*time.Hour)
+	require.ErrorIs(t, err, program.ErrInvalidInstantiation)
+
+	svc.repo.EXPECT().GetProgram(ctx, 5).Return(&program.Program{ID: 5, UserID: 4}, nil)
+	_, err = svc.Instantiate(ctx, 3, 5, time.Now(), 0)
+	require.ErrorIs(t, err, program.ErrInvalidPermissions)
+}
@@ -0,0 +1,41 @@
+package progression
+
+import (
+	"github.com/guregu/null/v6"
+)
+
+// PlanRequest sets the plan of the exercise in the path.
+type PlanRequest struct {
+	Rule          RuleKind   `json:"rule"`
+	Sets          int        `json:"sets"`
+	RepMin        int        `json:"rep_min"`
+	RepMax        int        `json:"rep_max"`
+	Increment     null.Float `json:"increment"`
+	StartWeight   null.Float `json:"start_weight"`
+	TrainingMax   null.Float `json:"training_max"`
+	DeloadAfter   int        `json:"deload_after"`
+	DeloadPercent float64    `json:"deload_percent"`
+}
+
+type PlanResponse struct {
+	ID            int        `json:"id"`
+	ExerciseID    int        `json:"exercise_id"`
+	Rule          RuleKind   `json:"rule"`
+	Sets          int        `json:"sets"`
+	RepMin        int        `json:"rep_min"`
+	RepMax        int        `json:"rep_max"`
+	Increment     null.Float `json:"increment"`
+	StartWeight   null.Float `json:"start_weight"`
+	TrainingMax   null.Float `json:"training_max"`
+	DeloadAfter   int        `json:"deload_after"`
+	DeloadPercent float64    `json:"deload_percent"`
+	UpdatedAt     string     `json:"updated_at"`
+}
+
+type PlanListResponse struct {
+	Plans []PlanResponse `json:"plans"`
+}
+
+type PrescriptionsResponse struct {
+	Prescriptions []*Prescription `json:"prescriptions"`
+}
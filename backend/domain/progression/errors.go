@@ -0,0 +1,24 @@
+package progression
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrPlanNotFound     = errors.New("progression plan not found")
+	ErrMissingField     = errors.New("missing field")
+	ErrInvalidPlan      = errors.New("invalid progression plan")
+	ErrExerciseNotFound = errors.New("referenced exercise not found")
+	ErrNoTrainingMax    = errors.New("no training max")
+)
+
+func init() {
+	httpx.RegisterError(ErrPlanNotFound, http.StatusNotFound, "progression_plan_not_found")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPlan, http.StatusUnprocessableEntity, "invalid_progression_plan")
+	httpx.RegisterError(ErrExerciseNotFound, http.StatusUnprocessableEntity, "unknown_exercise")
+	httpx.RegisterError(ErrNoTrainingMax, http.StatusUnprocessableEntity, "training_max_unknown")
+}
@@ -0,0 +1,245 @@
+package progression
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating progression handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created progression handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+func toPlanResponse(plan *Plan) PlanResponse {
+	return PlanResponse{
+		ID:            plan.ID,
+		ExerciseID:    plan.ExerciseID,
+		Rule:          plan.Rule,
+		Sets:          plan.Sets,
+		RepMin:        plan.RepMin,
+		RepMax:        plan.RepMax,
+		Increment:     plan.Increment,
+		StartWeight:   plan.StartWeight,
+		TrainingMax:   plan.TrainingMax,
+		DeloadAfter:   plan.DeloadAfter,
+		DeloadPercent: plan.DeloadPercent,
+		UpdatedAt:     plan.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+	}
+}
+
+func (h *Handler) ListPlans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	plans, err := h.service.ListPlans(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := PlanListResponse{Plans: make([]PlanResponse, 0, len(plans))}
+	for _, plan := range plans {
+		resp.Plans = append(resp.Plans, toPlanResponse(plan))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	plan, err := h.service.GetPlan(ctx, userID, exerciseID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPlanResponse(plan))
+}
+
+// SavePlan creates or replaces the plan of an exercise.
+func (h *Handler) SavePlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	var req PlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	plan, err := h.service.SavePlan(ctx, userID, &Plan{
+		ExerciseID:    exerciseID,
+		Rule:          req.Rule,
+		Sets:          req.Sets,
+		RepMin:        req.RepMin,
+		RepMax:        req.RepMax,
+		Increment:     req.Increment,
+		StartWeight:   req.StartWeight,
+		TrainingMax:   req.TrainingMax,
+		DeloadAfter:   req.DeloadAfter,
+		DeloadPercent: req.DeloadPercent,
+	})
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPlanResponse(plan))
+}
+
+func (h *Handler) DeletePlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	if err := h.service.DeletePlan(ctx, userID, exerciseID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) Next(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid exercise id")
+		return
+	}
+
+	next, err := h.service.Next(ctx, userID, exerciseID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, next)
+}
+
+func (h *Handler) NextAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	prescriptions, err := h.service.NextAll(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PrescriptionsResponse{Prescriptions: prescriptions})
+}
@@ -0,0 +1,91 @@
+package progression_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/progression"
+	"workup_fitness/domain/progression/mocks"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, id string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if id != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestSavePlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := progression.NewHandler(mockService)
+
+	plan := &progression.Plan{ExerciseID: 2, Rule: progression.RuleDouble, Sets: 3, RepMin: 8, RepMax: 12, Increment: null.FloatFrom(5)}
+	mockService.EXPECT().
+		SavePlan(gomock.Any(), 1, plan).
+		DoAndReturn(func(_ context.Context, _ int, plan *progression.Plan) (*progression.Plan, error) {
+			plan.ID = 4
+			return plan, nil
+		})
+
+	rr := httptest.NewRecorder()
+	body := []byte(`{"rule": "double", "sets": 3, "rep_min": 8, "rep_max": 12, "increment": 5}`)
+	handler.SavePlan(rr, newAuthedRequest(http.MethodPut, "/progression/plans/2", body, 1, "2"))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp progression.PlanResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 4, resp.ID)
+	require.Equal(t, 2, resp.ExerciseID)
+	require.Equal(t, 5.0, resp.Increment.Float64)
+}
+
+func TestNext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := progression.NewHandler(mockService)
+
+	mockService.EXPECT().Next(gomock.Any(), 1, 2).Return(&progression.Prescription{
+		ExerciseID: 2,
+		Rule:       progression.RuleWave,
+		Sets:       []progression.PrescribedSet{{Weight: 90, Reps: 5}, {Weight: 100, Reps: 5}, {Weight: 115, Reps: 5, AMRAP: true}},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Next(rr, newAuthedRequest(http.MethodGet, "/progression/plans/2/next", nil, 1, "2"))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp progression.Prescription
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Sets, 3)
+	require.True(t, resp.Sets[2].AMRAP)
+}
+
+func TestNext_NoPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := progression.NewHandler(mockService)
+
+	mockService.EXPECT().Next(gomock.Any(), 1, 2).Return(nil, progression.ErrPlanNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.Next(rr, newAuthedRequest(http.MethodGet, "/progression/plans/2/next", nil, 1, "2"))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
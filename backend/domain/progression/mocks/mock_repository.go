@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/progression (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/progression Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	progression "workup_fitness/domain/progression"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePlan mocks base method.
+func (m *MockRepository) DeletePlan(ctx context.Context, userID, exerciseID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlan", ctx, userID, exerciseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlan indicates an expected call of DeletePlan.
+func (mr *MockRepositoryMockRecorder) DeletePlan(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlan", reflect.TypeOf((*MockRepository)(nil).DeletePlan), ctx, userID, exerciseID)
+}
+
+// GetPlan mocks base method.
+func (m *MockRepository) GetPlan(ctx context.Context, userID, exerciseID int) (*progression.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlan", ctx, userID, exerciseID)
+	ret0, _ := ret[0].(*progression.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlan indicates an expected call of GetPlan.
+func (mr *MockRepositoryMockRecorder) GetPlan(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*MockRepository)(nil).GetPlan), ctx, userID, exerciseID)
+}
+
+// ListPerformances mocks base method.
+func (m *MockRepository) ListPerformances(ctx context.Context, userID, exerciseID int) ([]progression.Performance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPerformances", ctx, userID, exerciseID)
+	ret0, _ := ret[0].([]progression.Performance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPerformances indicates an expected call of ListPerformances.
+func (mr *MockRepositoryMockRecorder) ListPerformances(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPerformances", reflect.TypeOf((*MockRepository)(nil).ListPerformances), ctx, userID, exerciseID)
+}
+
+// ListPlans mocks base method.
+func (m *MockRepository) ListPlans(ctx context.Context, userID int) ([]*progression.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlans", ctx, userID)
+	ret0, _ := ret[0].([]*progression.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlans indicates an expected call of ListPlans.
+func (mr *MockRepositoryMockRecorder) ListPlans(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlans", reflect.TypeOf((*MockRepository)(nil).ListPlans), ctx, userID)
+}
+
+// SavePlan mocks base method.
+func (m *MockRepository) SavePlan(ctx context.Context, plan *progression.Plan) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlan", ctx, plan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePlan indicates an expected call of SavePlan.
+func (mr *MockRepositoryMockRecorder) SavePlan(ctx, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlan", reflect.TypeOf((*MockRepository)(nil).SavePlan), ctx, plan)
+}
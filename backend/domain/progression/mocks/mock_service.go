@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/progression (interfaces: Service)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/progression Service
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	progression "workup_fitness/domain/progression"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// DeletePlan mocks base method.
+func (m *MockService) DeletePlan(ctx context.Context, userID, exerciseID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlan", ctx, userID, exerciseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlan indicates an expected call of DeletePlan.
+func (mr *MockServiceMockRecorder) DeletePlan(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlan", reflect.TypeOf((*MockService)(nil).DeletePlan), ctx, userID, exerciseID)
+}
+
+// GetPlan mocks base method.
+func (m *MockService) GetPlan(ctx context.Context, userID, exerciseID int) (*progression.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlan", ctx, userID, exerciseID)
+	ret0, _ := ret[0].(*progression.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlan indicates an expected call of GetPlan.
+func (mr *MockServiceMockRecorder) GetPlan(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*MockService)(nil).GetPlan), ctx, userID, exerciseID)
+}
+
+// ListPlans mocks base method.
+func (m *MockService) ListPlans(ctx context.Context, userID int) ([]*progression.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlans", ctx, userID)
+	ret0, _ := ret[0].([]*progression.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlans indicates an expected call of ListPlans.
+func (mr *MockServiceMockRecorder) ListPlans(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlans", reflect.TypeOf((*MockService)(nil).ListPlans), ctx, userID)
+}
+
+// Next mocks base method.
+func (m *MockService) Next(ctx context.Context, userID, exerciseID int) (*progression.Prescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx, userID, exerciseID)
+	ret0, _ := ret[0].(*progression.Prescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockServiceMockRecorder) Next(ctx, userID, exerciseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockService)(nil).Next), ctx, userID, exerciseID)
+}
+
+// NextAll mocks base method.
+func (m *MockService) NextAll(ctx context.Context, userID int) ([]*progression.Prescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextAll", ctx, userID)
+	ret0, _ := ret[0].([]*progression.Prescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextAll indicates an expected call of NextAll.
+func (mr *MockServiceMockRecorder) NextAll(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextAll", reflect.TypeOf((*MockService)(nil).NextAll), ctx, userID)
+}
+
+// SavePlan mocks base method.
+func (m *MockService) SavePlan(ctx context.Context, userID int, plan *progression.Plan) (*progression.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlan", ctx, userID, plan)
+	ret0, _ := ret[0].(*progression.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePlan indicates an expected call of SavePlan.
+func (mr *MockServiceMockRecorder) SavePlan(ctx, userID, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlan", reflect.TypeOf((*MockService)(nil).SavePlan), ctx, userID, plan)
+}
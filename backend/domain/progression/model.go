@@ -0,0 +1,81 @@
+package progression
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// RuleKind names the rule a plan progresses by.
+type RuleKind string
+
+const (
+	// RuleLinear adds the increment after every successful session.
+	RuleLinear RuleKind = "linear"
+	// RuleDouble adds reps up to the top of the range before adding weight.
+	RuleDouble RuleKind = "double"
+	// RuleWave cycles through 5/3/1 weeks as percentages of a training max.
+	RuleWave RuleKind = "wave"
+)
+
+func (k RuleKind) Valid() bool {
+	switch k {
+	case RuleLinear, RuleDouble, RuleWave:
+		return true
+	}
+	return false
+}
+
+// Plan is how a user progresses on one exercise. Sets and the rep range
+// are ignored by the wave rule, which prescribes its own.
+type Plan struct {
+	ID         int      `json:"id"`
+	UserID     int      `json:"user_id"`
+	ExerciseID int      `json:"exercise_id"`
+	Rule       RuleKind `json:"rule"`
+	Sets       int      `json:"sets"`
+	RepMin     int      `json:"rep_min"`
+	RepMax     int      `json:"rep_max"`
+	// Increment defaults to the simulator's WeightIncrement, or 2.5 for
+	// free weights.
+	Increment   null.Float `json:"increment"`
+	StartWeight null.Float `json:"start_weight"`
+	// TrainingMax defaults to 90% of the best e1RM for the wave rule.
+	TrainingMax   null.Float `json:"training_max"`
+	DeloadAfter   int        `json:"deload_after"`
+	DeloadPercent float64    `json:"deload_percent"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Performance is what was logged for an exercise in one finished session.
+type Performance struct {
+	SessionID   int
+	PerformedAt time.Time
+	Sets        []PerformedSet
+}
+
+type PerformedSet struct {
+	Weight    float64
+	Reps      int
+	Completed bool
+}
+
+// Prescription is what to do in the next session.
+type Prescription struct {
+	ExerciseID int             `json:"exercise_id"`
+	Rule       RuleKind        `json:"rule"`
+	Sets       []PrescribedSet `json:"sets"`
+	Deload     bool            `json:"deload"`
+	// Capped is set when the rule asked for more than the simulator's
+	// heaviest weight, so the weight could not go up as planned.
+	Capped bool   `json:"capped"`
+	Reason string `json:"reason"`
+}
+
+// PrescribedSet is one set of a prescription. AMRAP sets ask for as many
+// reps as possible, with Reps as the minimum.
+type PrescribedSet struct {
+	Weight float64 `json:"weight"`
+	Reps   int     `json:"reps"`
+	AMRAP  bool    `json:"amrap"`
+}
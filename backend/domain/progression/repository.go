@@ -0,0 +1,143 @@
+package progression
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/progression Repository
+
+type Repository interface {
+	// SavePlan creates the plan of an exercise or replaces the existing one.
+	SavePlan(ctx context.Context, plan *Plan) (int, error)
+	GetPlan(ctx context.Context, userID, exerciseID int) (*Plan, error)
+	ListPlans(ctx context.Context, userID int) ([]*Plan, error)
+	DeletePlan(ctx context.Context, userID, exerciseID int) error
+	// ListPerformances returns the sets of exerciseID from finished sessions
+	// of userID, one Performance per session, oldest first.
+	ListPerformances(ctx context.Context, userID, exerciseID int) ([]Performance, error)
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+const selectPlan = `SELECT id, user_id, exercise_id, rule, sets, rep_min, rep_max, increment, start_weight,
+	training_max, deload_after, deload_percent, updated_at FROM progression_plans`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPlan(row scanner) (*Plan, error) {
+	var plan Plan
+	err := row.Scan(&plan.ID, &plan.UserID, &plan.ExerciseID, &plan.Rule, &plan.Sets, &plan.RepMin, &plan.RepMax,
+		&plan.Increment, &plan.StartWeight, &plan.TrainingMax, &plan.DeloadAfter, &plan.DeloadPercent, &plan.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (repo *sqliteRepository) SavePlan(ctx context.Context, plan *Plan) (int, error) {
+	var id int
+	err := repo.db.QueryRowContext(ctx,
+		`INSERT INTO progression_plans (user_id, exercise_id, rule, sets, rep_min, rep_max, increment, start_weight,
+			training_max, deload_after, deload_percent, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (user_id, exercise_id) DO UPDATE SET rule = excluded.rule, sets = excluded.sets,
+			rep_min = excluded.rep_min, rep_max = excluded.rep_max, increment = excluded.increment,
+			start_weight = excluded.start_weight, training_max = excluded.training_max,
+			deload_after = excluded.deload_after, deload_percent = excluded.deload_percent,
+			updated_at = excluded.updated_at
+			RETURNING id`,
+		plan.UserID, plan.ExerciseID, plan.Rule, plan.Sets, plan.RepMin, plan.RepMax, plan.Increment, plan.StartWeight,
+		plan.TrainingMax, plan.DeloadAfter, plan.DeloadPercent, plan.UpdatedAt.UTC(),
+	).Scan(&id)
+	if err := dbutil.ProcessInsertError(err, ErrInvalidPlan, ErrMissingField); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (repo *sqliteRepository) GetPlan(ctx context.Context, userID, exerciseID int) (*Plan, error) {
+	plan, err := scanPlan(repo.db.QueryRowContext(ctx, selectPlan+` WHERE user_id = ? AND exercise_id = ?`, userID, exerciseID))
+	if err := dbutil.ProcessRowError(err, ErrPlanNotFound); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (repo *sqliteRepository) ListPlans(ctx context.Context, userID int) ([]*Plan, error) {
+	rows, err := repo.db.QueryContext(ctx, selectPlan+` WHERE user_id = ? ORDER BY exercise_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := make([]*Plan, 0)
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, rows.Err()
+}
+
+func (repo *sqliteRepository) DeletePlan(ctx context.Context, userID, exerciseID int) error {
+	result, err := repo.db.ExecContext(ctx,
+		`DELETE FROM progression_plans WHERE user_id = ? AND exercise_id = ?`,
+		userID, exerciseID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrPlanNotFound
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) ListPerformances(ctx context.Context, userID, exerciseID int) ([]Performance, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT ws.id, ws.started_at, s.weight, s.reps, s.completed FROM session_sets s
+			JOIN workout_sessions ws ON ws.id = s.session_id
+			WHERE ws.user_id = ? AND s.exercise_id = ? AND ws.finished_at IS NOT NULL
+			ORDER BY ws.started_at, ws.id, s.set_index`,
+		userID, exerciseID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	performances := make([]Performance, 0)
+	for rows.Next() {
+		var (
+			sessionID int
+			startedAt time.Time
+			set       PerformedSet
+		)
+		if err := rows.Scan(&sessionID, &startedAt, &set.Weight, &set.Reps, &set.Completed); err != nil {
+			return nil, err
+		}
+		if n := len(performances); n == 0 || performances[n-1].SessionID != sessionID {
+			performances = append(performances, Performance{SessionID: sessionID, PerformedAt: startedAt})
+		}
+		last := &performances[len(performances)-1]
+		last.Sets = append(last.Sets, set)
+	}
+	return performances, rows.Err()
+}
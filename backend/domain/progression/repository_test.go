@@ -0,0 +1,97 @@
+package progression_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/progression"
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (progression.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := progression.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO exercises (name) VALUES ('Squat'), ('Bench press')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_SavePlan(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	updatedAt := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	id, err := repo.SavePlan(ctx, &progression.Plan{
+		UserID: 1, ExerciseID: 1, Rule: progression.RuleLinear, Sets: 5, RepMin: 5, RepMax: 5,
+		StartWeight: null.FloatFrom(60), UpdatedAt: updatedAt,
+	})
+	require.NoError(t, err)
+
+	replacedID, err := repo.SavePlan(ctx, &progression.Plan{
+		UserID: 1, ExerciseID: 1, Rule: progression.RuleWave, Sets: 3, TrainingMax: null.FloatFrom(120),
+		DeloadAfter: 2, DeloadPercent: 10, UpdatedAt: updatedAt.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, id, replacedID, "one plan per exercise")
+
+	plan, err := repo.GetPlan(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, progression.RuleWave, plan.Rule)
+	require.Equal(t, 120.0, plan.TrainingMax.Float64)
+	require.False(t, plan.StartWeight.Valid)
+	require.Equal(t, 2, plan.DeloadAfter)
+	require.True(t, plan.UpdatedAt.Equal(updatedAt.Add(time.Hour)))
+
+	_, err = repo.GetPlan(ctx, 2, 1)
+	require.ErrorIs(t, err, progression.ErrPlanNotFound)
+
+	plans, err := repo.ListPlans(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+
+	require.NoError(t, repo.DeletePlan(ctx, 1, 1))
+	require.ErrorIs(t, repo.DeletePlan(ctx, 1, 1), progression.ErrPlanNotFound)
+}
+
+func TestRepository_ListPerformances(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	startedAt := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	_, err := db.Exec(`INSERT INTO workout_sessions (user_id, started_at, finished_at) VALUES
+		(1, ?, ?), (1, ?, ?), (2, ?, ?), (1, ?, NULL)`,
+		startedAt.Add(48*time.Hour), startedAt.Add(49*time.Hour),
+		startedAt, startedAt.Add(time.Hour),
+		startedAt, startedAt.Add(time.Hour),
+		startedAt.Add(96*time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO session_sets (session_id, exercise_id, set_index, weight, reps, completed, performed_at) VALUES
+		(1, 1, 2, 102.5, 3, 0, ?), (1, 1, 1, 102.5, 5, 1, ?), (1, 2, 1, 60, 8, 1, ?),
+		(2, 1, 1, 100, 5, 1, ?), (3, 1, 1, 200, 5, 1, ?), (4, 1, 1, 105, 5, 1, ?)`,
+		startedAt, startedAt, startedAt, startedAt, startedAt, startedAt,
+	)
+	require.NoError(t, err)
+
+	performances, err := repo.ListPerformances(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, performances, 2, "other users, exercises and open sessions are left out")
+	require.Equal(t, 2, performances[0].SessionID, "oldest first")
+	require.Equal(t, []progression.PerformedSet{{Weight: 100, Reps: 5, Completed: true}}, performances[0].Sets)
+	require.Equal(t, []progression.PerformedSet{
+		{Weight: 102.5, Reps: 5, Completed: true},
+		{Weight: 102.5, Reps: 3, Completed: false},
+	}, performances[1].Sets)
+}
@@ -0,0 +1,22 @@
+package progression
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("workouts"))
+		r.Get("/progression/plans", h.ListPlans)
+		r.Get("/progression/plans/{id}", h.GetPlan)
+		r.Put("/progression/plans/{id}", h.SavePlan)
+		r.Delete("/progression/plans/{id}", h.DeletePlan)
+		r.Get("/progression/plans/{id}/next", h.Next)
+		r.Get("/progression/next", h.NextAll)
+	})
+}
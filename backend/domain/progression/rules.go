@@ -0,0 +1,212 @@
+package progression
+
+import (
+	"fmt"
+	"time"
+)
+
+// Rule decides the next prescription for an exercise. Rules work on
+// unrounded weights; fitting them to the equipment is left to the caller.
+type Rule interface {
+	// Next prescribes the session after history, which is oldest first.
+	Next(history []Performance) Prescription
+	// Succeeded reports whether a session met the rule's targets.
+	Succeeded(performed Performance) bool
+}
+
+// topWeight is the heaviest completed set of a session, the weight the
+// session was worked at.
+func topWeight(performed Performance) float64 {
+	var top float64
+	for _, set := range performed.Sets {
+		if set.Completed && set.Weight > top {
+			top = set.Weight
+		}
+	}
+	return top
+}
+
+// workingReps returns the reps of the completed sets at the top weight.
+func workingReps(performed Performance) []int {
+	top := topWeight(performed)
+	reps := make([]int, 0, len(performed.Sets))
+	for _, set := range performed.Sets {
+		if set.Completed && set.Weight >= top {
+			reps = append(reps, set.Reps)
+		}
+	}
+	return reps
+}
+
+// countAtLeast counts the reps of at least minReps.
+func countAtLeast(reps []int, minReps int) int {
+	count := 0
+	for _, r := range reps {
+		if r >= minReps {
+			count++
+		}
+	}
+	return count
+}
+
+func straightSets(sets, reps int, weight float64) []PrescribedSet {
+	res := make([]PrescribedSet, sets)
+	for i := range res {
+		res[i] = PrescribedSet{Weight: weight, Reps: reps}
+	}
+	return res
+}
+
+// Linear adds Increment whenever every set of the last session reached
+// Reps, and repeats the weight otherwise.
+type Linear struct {
+	Sets        int
+	Reps        int
+	Increment   float64
+	StartWeight float64
+}
+
+func (r Linear) Succeeded(performed Performance) bool {
+	return countAtLeast(workingReps(performed), r.Reps) >= r.Sets
+}
+
+func (r Linear) Next(history []Performance) Prescription {
+	if len(history) == 0 {
+		return Prescription{Sets: straightSets(r.Sets, r.Reps, r.StartWeight), Reason: "starting weight"}
+	}
+
+	last := history[len(history)-1]
+	weight := topWeight(last)
+	if !r.Succeeded(last) {
+		return Prescription{Sets: straightSets(r.Sets, r.Reps, weight), Reason: "repeat the weight until every set reaches the target"}
+	}
+	return Prescription{Sets: straightSets(r.Sets, r.Reps, weight+r.Increment), Reason: fmt.Sprintf("all sets done, add %g", r.Increment)}
+}
+
+// Double works up from RepMin to RepMax at the same weight, one rep per
+// session, and adds Increment once every set reaches RepMax.
+type Double struct {
+	Sets        int
+	RepMin      int
+	RepMax      int
+	Increment   float64
+	StartWeight float64
+}
+
+func (r Double) Succeeded(performed Performance) bool {
+	return countAtLeast(workingReps(performed), r.RepMin) >= r.Sets
+}
+
+func (r Double) Next(history []Performance) Prescription {
+	if len(history) == 0 {
+		return Prescription{Sets: straightSets(r.Sets, r.RepMin, r.StartWeight), Reason: "starting weight"}
+	}
+
+	last := history[len(history)-1]
+	weight := topWeight(last)
+	reps := workingReps(last)
+	if countAtLeast(reps, r.RepMax) >= r.Sets {
+		return Prescription{
+			Sets:   straightSets(r.Sets, r.RepMin, weight+r.Increment),
+			Reason: fmt.Sprintf("all sets reached %d reps, add %g", r.RepMax, r.Increment),
+		}
+	}
+
+	// Aim one rep past the weakest of the sets that count.
+	target := r.RepMin
+	if len(reps) >= r.Sets {
+		weakest := reps[0]
+		for _, rep := range reps[:r.Sets] {
+			weakest = min(weakest, rep)
+		}
+		target = min(max(weakest+1, r.RepMin), r.RepMax)
+	}
+	return Prescription{Sets: straightSets(r.Sets, target, weight), Reason: fmt.Sprintf("build up to %d reps", r.RepMax)}
+}
+
+type waveSet struct {
+	percent float64
+	reps    int
+	amrap   bool
+}
+
+// waveWeeks are the weeks of a 5/3/1 cycle, the last one a deload.
+var waveWeeks = [][]waveSet{
+	{{65, 5, false}, {75, 5, false}, {85, 5, true}},
+	{{70, 3, false}, {80, 3, false}, {90, 3, true}},
+	{{75, 5, false}, {85, 3, false}, {95, 1, true}},
+	{{40, 5, false}, {50, 5, false}, {60, 5, false}},
+}
+
+// Wave runs 5/3/1 cycles off TrainingMax. Every session since Since is one
+// week of the cycle, and every finished cycle adds Increment to the
+// training max.
+type Wave struct {
+	TrainingMax float64
+	Increment   float64
+	Since       time.Time
+}
+
+func (r Wave) Succeeded(performed Performance) bool {
+	if len(performed.Sets) == 0 {
+		return false
+	}
+	for _, set := range performed.Sets {
+		if !set.Completed {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Wave) Next(history []Performance) Prescription {
+	done := 0
+	for _, performed := range history {
+		if !performed.PerformedAt.Before(r.Since) {
+			done++
+		}
+	}
+	cycle, week := done/len(waveWeeks), done%len(waveWeeks)
+	trainingMax := r.TrainingMax + float64(cycle)*r.Increment
+
+	sets := make([]PrescribedSet, 0, len(waveWeeks[week]))
+	for _, set := range waveWeeks[week] {
+		sets = append(sets, PrescribedSet{Weight: trainingMax * set.percent / 100, Reps: set.reps, AMRAP: set.amrap})
+	}
+	return Prescription{
+		Sets:   sets,
+		Deload: week == len(waveWeeks)-1,
+		Reason: fmt.Sprintf("cycle %d, week %d of %d, training max %g", cycle+1, week+1, len(waveWeeks), trainingMax),
+	}
+}
+
+// Deload lowers the weights Rule prescribes by Percent once the last After
+// sessions all failed. A successful session ends the streak.
+type Deload struct {
+	Rule    Rule
+	After   int
+	Percent float64
+}
+
+func (r Deload) Succeeded(performed Performance) bool {
+	return r.Rule.Succeeded(performed)
+}
+
+func (r Deload) Next(history []Performance) Prescription {
+	next := r.Rule.Next(history)
+
+	failures := 0
+	for i := len(history) - 1; i >= 0 && !r.Rule.Succeeded(history[i]); i-- {
+		failures++
+	}
+	if failures < r.After {
+		return next
+	}
+
+	for i := range next.Sets {
+		next.Sets[i].Weight *= 1 - r.Percent/100
+	}
+	next.Deload = true
+	next.Reason = fmt.Sprintf("%d failed sessions in a row, deload by %g%%", failures, r.Percent)
+	return next
+}
@@ -0,0 +1,110 @@
+package progression_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/progression"
+)
+
+// session builds a performance of straight sets at weight. Reps of 0 mark
+// a failed set.
+func session(weight float64, reps ...int) progression.Performance {
+	performed := progression.Performance{PerformedAt: time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)}
+	for _, r := range reps {
+		performed.Sets = append(performed.Sets, progression.PerformedSet{Weight: weight, Reps: r, Completed: r > 0})
+	}
+	return performed
+}
+
+func weights(next progression.Prescription) []float64 {
+	res := make([]float64, 0, len(next.Sets))
+	for _, set := range next.Sets {
+		res = append(res, set.Weight)
+	}
+	return res
+}
+
+func TestLinear(t *testing.T) {
+	rule := progression.Linear{Sets: 3, Reps: 5, Increment: 2.5, StartWeight: 60}
+
+	next := rule.Next(nil)
+	require.Equal(t, []float64{60, 60, 60}, weights(next))
+	require.Equal(t, 5, next.Sets[0].Reps)
+
+	next = rule.Next([]progression.Performance{session(60, 5, 5, 5)})
+	require.Equal(t, []float64{62.5, 62.5, 62.5}, weights(next))
+
+	next = rule.Next([]progression.Performance{session(62.5, 5, 5, 4)})
+	require.Equal(t, []float64{62.5, 62.5, 62.5}, weights(next), "a missed rep repeats the weight")
+
+	warmup := session(62.5, 5, 5, 5)
+	warmup.Sets = append([]progression.PerformedSet{{Weight: 20, Reps: 10, Completed: true}}, warmup.Sets...)
+	require.True(t, rule.Succeeded(warmup), "lighter sets do not count")
+}
+
+func TestDouble(t *testing.T) {
+	rule := progression.Double{Sets: 3, RepMin: 8, RepMax: 12, Increment: 5, StartWeight: 40}
+
+	next := rule.Next(nil)
+	require.Equal(t, 8, next.Sets[0].Reps)
+
+	next = rule.Next([]progression.Performance{session(40, 10, 9, 9)})
+	require.Equal(t, []float64{40, 40, 40}, weights(next))
+	require.Equal(t, 10, next.Sets[0].Reps, "one rep past the weakest set")
+
+	next = rule.Next([]progression.Performance{session(40, 12, 12, 11)})
+	require.Equal(t, 12, next.Sets[0].Reps, "never past the top of the range")
+
+	next = rule.Next([]progression.Performance{session(40, 12, 12, 12)})
+	require.Equal(t, []float64{45, 45, 45}, weights(next))
+	require.Equal(t, 8, next.Sets[0].Reps)
+
+	next = rule.Next([]progression.Performance{session(45, 8, 0)})
+	require.Equal(t, 8, next.Sets[0].Reps, "too few sets start over at the bottom")
+	require.False(t, rule.Succeeded(session(45, 8, 0)))
+}
+
+func TestWave(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rule := progression.Wave{TrainingMax: 100, Increment: 5, Since: since}
+
+	next := rule.Next(nil)
+	require.Equal(t, []float64{65, 75, 85}, weights(next))
+	require.True(t, next.Sets[2].AMRAP)
+	require.False(t, next.Deload)
+
+	// A session before the plan started is not part of the cycle.
+	old := session(80, 5, 5, 5)
+	old.PerformedAt = since.Add(-24 * time.Hour)
+	history := []progression.Performance{old}
+	for range 3 {
+		history = append(history, session(80, 5, 5, 5))
+	}
+	next = rule.Next(history)
+	require.Equal(t, []float64{40, 50, 60}, weights(next))
+	require.True(t, next.Deload)
+
+	history = append(history, session(50, 5, 5, 5))
+	next = rule.Next(history)
+	require.Equal(t, []float64{68.25, 78.75, 89.25}, weights(next), "the next cycle adds the increment to the training max")
+}
+
+func TestDeload(t *testing.T) {
+	rule := progression.Deload{Rule: progression.Linear{Sets: 3, Reps: 5, Increment: 2.5}, After: 2, Percent: 10}
+
+	failed := session(100, 5, 4, 3)
+	next := rule.Next([]progression.Performance{session(100, 5, 5, 5), failed})
+	require.False(t, next.Deload, "one failure is not enough")
+	require.Equal(t, 100.0, next.Sets[0].Weight)
+
+	next = rule.Next([]progression.Performance{session(100, 5, 5, 5), failed, failed})
+	require.True(t, next.Deload)
+	require.Equal(t, []float64{90, 90, 90}, weights(next))
+
+	next = rule.Next([]progression.Performance{failed, failed, session(90, 5, 5, 5)})
+	require.False(t, next.Deload, "a success ends the streak")
+	require.Equal(t, 92.5, next.Sets[0].Weight)
+}
@@ -0,0 +1,341 @@
+package progression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"workup_fitness/domain/analytics"
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/simulator"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/progression Service
+
+const (
+	maxSets          = 20
+	maxReps          = 100
+	maxIncrement     = 50
+	maxDeloadAfter   = 10
+	maxDeloadPercent = 50
+	// defaultIncrement is the smallest usual jump with free weights.
+	defaultIncrement     = 2.5
+	defaultDeloadPercent = 10
+	// trainingMaxShare of the best e1RM is the training max of wave plans
+	// that do not set one.
+	trainingMaxShare = 0.9
+)
+
+type ExerciseService interface {
+	GetByID(ctx context.Context, id int) (*exercise.Exercise, error)
+}
+
+type SimulatorService interface {
+	GetByID(ctx context.Context, id int) (*simulator.Simulator, error)
+}
+
+// RecordService provides the best e1RM that wave plans without a training
+// max are based on.
+type RecordService interface {
+	Bests(ctx context.Context, userID, exerciseID int) ([]*analytics.Record, error)
+}
+
+type Service interface {
+	SavePlan(ctx context.Context, userID int, plan *Plan) (*Plan, error)
+	GetPlan(ctx context.Context, userID, exerciseID int) (*Plan, error)
+	ListPlans(ctx context.Context, userID int) ([]*Plan, error)
+	DeletePlan(ctx context.Context, userID, exerciseID int) error
+	// Next prescribes the next session of an exercise from its plan and the
+	// finished sessions so far.
+	Next(ctx context.Context, userID, exerciseID int) (*Prescription, error)
+	// NextAll prescribes the next session of every exercise with a plan.
+	NextAll(ctx context.Context, userID int) ([]*Prescription, error)
+}
+
+type serviceImpl struct {
+	repo             Repository
+	exerciseService  ExerciseService
+	simulatorService SimulatorService
+	recordService    RecordService
+	now              func() time.Time
+}
+
+func NewService(repo Repository, exerciseService ExerciseService, simulatorService SimulatorService, recordService RecordService) *serviceImpl {
+	logger.Default().Debug().Msg("Creating progression service...")
+	res := &serviceImpl{
+		repo:             repo,
+		exerciseService:  exerciseService,
+		simulatorService: simulatorService,
+		recordService:    recordService,
+		now:              time.Now,
+	}
+	logger.Default().Debug().Msg("Created progression service")
+	return res
+}
+
+func (s *serviceImpl) checkPlan(ctx context.Context, plan *Plan) error {
+	if plan.ExerciseID == 0 {
+		return errors.Join(ErrMissingField, errors.New("exercise_id is required"))
+	}
+	if plan.Rule == "" {
+		return errors.Join(ErrMissingField, errors.New("rule is required"))
+	}
+	if !plan.Rule.Valid() {
+		return fmt.Errorf("%w: rule must be %s, %s or %s", ErrInvalidPlan, RuleLinear, RuleDouble, RuleWave)
+	}
+
+	switch plan.Rule {
+	case RuleLinear, RuleDouble:
+		if plan.Sets < 1 || plan.Sets > maxSets {
+			return fmt.Errorf("%w: sets must be between 1 and %d", ErrInvalidPlan, maxSets)
+		}
+		if plan.Rule == RuleLinear || plan.RepMax == 0 {
+			plan.RepMax = plan.RepMin
+		}
+		if plan.RepMin < 1 || plan.RepMax > maxReps {
+			return fmt.Errorf("%w: reps must be between 1 and %d", ErrInvalidPlan, maxReps)
+		}
+		if plan.Rule == RuleDouble && plan.RepMax <= plan.RepMin {
+			return fmt.Errorf("%w: double progression needs rep_max above rep_min", ErrInvalidPlan)
+		}
+		plan.TrainingMax.Valid = false
+	case RuleWave:
+		plan.Sets, plan.RepMin, plan.RepMax = len(waveWeeks[0]), 0, 0
+		plan.StartWeight.Valid = false
+		if plan.TrainingMax.Valid && plan.TrainingMax.Float64 <= 0 {
+			return fmt.Errorf("%w: training_max must be positive", ErrInvalidPlan)
+		}
+	}
+
+	if plan.Increment.Valid && (plan.Increment.Float64 <= 0 || plan.Increment.Float64 > maxIncrement) {
+		return fmt.Errorf("%w: increment must be above 0 and at most %d", ErrInvalidPlan, maxIncrement)
+	}
+	if plan.StartWeight.Valid && plan.StartWeight.Float64 < 0 {
+		return fmt.Errorf("%w: start_weight cannot be negative", ErrInvalidPlan)
+	}
+	if plan.DeloadAfter < 0 || plan.DeloadAfter > maxDeloadAfter {
+		return fmt.Errorf("%w: deload_after must be between 0 and %d", ErrInvalidPlan, maxDeloadAfter)
+	}
+	if plan.DeloadAfter == 0 {
+		plan.DeloadPercent = 0
+	} else if plan.DeloadPercent == 0 {
+		plan.DeloadPercent = defaultDeloadPercent
+	}
+	if plan.DeloadPercent < 0 || plan.DeloadPercent > maxDeloadPercent {
+		return fmt.Errorf("%w: deload_percent must be between 0 and %d", ErrInvalidPlan, maxDeloadPercent)
+	}
+
+	found, err := s.exerciseService.GetByID(ctx, plan.ExerciseID)
+	if errors.Is(err, exercise.ErrExerciseNotFound) {
+		return ErrExerciseNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if plan.Increment.Valid && found.SimulatorID.Valid {
+		machine, err := s.simulatorService.GetByID(ctx, int(found.SimulatorID.Int64))
+		if err != nil {
+			return err
+		}
+		// Anything below the stack step rounds back to the same plate.
+		if plan.Increment.Float64 < machine.WeightIncrement {
+			return fmt.Errorf("%w: increment must be at least the simulator step of %g", ErrInvalidPlan, machine.WeightIncrement)
+		}
+	}
+	return nil
+}
+
+// equipment returns the simulator of an exercise, or nil for free weights.
+func (s *serviceImpl) equipment(ctx context.Context, exerciseID int) (*simulator.Simulator, error) {
+	found, err := s.exerciseService.GetByID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if !found.SimulatorID.Valid {
+		return nil, nil
+	}
+	return s.simulatorService.GetByID(ctx, int(found.SimulatorID.Int64))
+}
+
+// trainingMax is the training max of a wave plan. Set explicitly it grows
+// by the increment every cycle; taken from the best e1RM it follows that
+// instead.
+func (s *serviceImpl) trainingMax(ctx context.Context, plan *Plan) (float64, bool, error) {
+	if plan.TrainingMax.Valid {
+		return plan.TrainingMax.Float64, true, nil
+	}
+	bests, err := s.recordService.Bests(ctx, plan.UserID, plan.ExerciseID)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, record := range bests {
+		if record.Kind == analytics.KindBestE1RM {
+			return record.Value * trainingMaxShare, false, nil
+		}
+	}
+	return 0, false, fmt.Errorf("%w: set training_max or log a set of exercise %d first", ErrNoTrainingMax, plan.ExerciseID)
+}
+
+// rule builds the rule of a plan, wrapped in a deload when the plan asks
+// for one.
+func (s *serviceImpl) rule(ctx context.Context, plan *Plan, increment float64) (Rule, error) {
+	var rule Rule
+	switch plan.Rule {
+	case RuleLinear:
+		rule = Linear{Sets: plan.Sets, Reps: plan.RepMin, Increment: increment, StartWeight: plan.StartWeight.Float64}
+	case RuleDouble:
+		rule = Double{Sets: plan.Sets, RepMin: plan.RepMin, RepMax: plan.RepMax, Increment: increment, StartWeight: plan.StartWeight.Float64}
+	case RuleWave:
+		trainingMax, fixed, err := s.trainingMax(ctx, plan)
+		if err != nil {
+			return nil, err
+		}
+		wave := Wave{TrainingMax: trainingMax, Since: plan.UpdatedAt}
+		if fixed {
+			wave.Increment = increment
+		}
+		rule = wave
+	default:
+		return nil, fmt.Errorf("%w: unknown rule %q", ErrInvalidPlan, plan.Rule)
+	}
+
+	if plan.DeloadAfter > 0 {
+		rule = Deload{Rule: rule, After: plan.DeloadAfter, Percent: plan.DeloadPercent}
+	}
+	return rule, nil
+}
+
+// roundWeight fits a weight to the equipment: the nearest weight on the
+// simulator's stack, or the nearest multiple of increment for free weights.
+func roundWeight(weight, increment float64, machine *simulator.Simulator) float64 {
+	if machine != nil {
+		return machine.NearestWeight(weight)
+	}
+	return math.Max(0, math.Round(weight/increment)*increment)
+}
+
+func (s *serviceImpl) prescribe(ctx context.Context, plan *Plan) (*Prescription, error) {
+	machine, err := s.equipment(ctx, plan.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	increment := defaultIncrement
+	if plan.Increment.Valid {
+		increment = plan.Increment.Float64
+	} else if machine != nil && machine.WeightIncrement > 0 {
+		increment = machine.WeightIncrement
+	}
+	// The simulator may have changed since the plan was saved.
+	if machine != nil && increment < machine.WeightIncrement {
+		increment = machine.WeightIncrement
+	}
+
+	rule, err := s.rule(ctx, plan, increment)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.repo.ListPerformances(ctx, plan.UserID, plan.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := rule.Next(history)
+	for i := range next.Sets {
+		if machine != nil && next.Sets[i].Weight > machine.NearestWeight(machine.MaxWeight) {
+			next.Capped = true
+		}
+		next.Sets[i].Weight = roundWeight(next.Sets[i].Weight, increment, machine)
+	}
+	if next.Capped {
+		next.Reason = fmt.Sprintf("%g is the heaviest the simulator allows, add reps or change the exercise to keep progressing",
+			machine.NearestWeight(machine.MaxWeight))
+	}
+	next.ExerciseID = plan.ExerciseID
+	next.Rule = plan.Rule
+	return &next, nil
+}
+
+// SavePlan creates or replaces the plan of an exercise. Replacing a wave
+// plan starts a new cycle.
+func (s *serviceImpl) SavePlan(ctx context.Context, userID int, plan *Plan) (*Plan, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", plan.ExerciseID).Msg("Saving progression plan")
+
+	if err := s.checkPlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	plan.UserID = userID
+	plan.UpdatedAt = s.now().UTC()
+	savedID, err := s.repo.SavePlan(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	plan.ID = savedID
+	logger.Ctx(ctx).Info().Int("plan_id", plan.ID).Int("user_id", userID).Msg("Saved progression plan")
+	return plan, nil
+}
+
+func (s *serviceImpl) GetPlan(ctx context.Context, userID, exerciseID int) (*Plan, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Getting progression plan")
+	plan, err := s.repo.GetPlan(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("plan_id", plan.ID).Msg("Got progression plan")
+	return plan, nil
+}
+
+func (s *serviceImpl) ListPlans(ctx context.Context, userID int) ([]*Plan, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing progression plans")
+	plans, err := s.repo.ListPlans(ctx, userID)
+	logger.Ctx(ctx).Info().Int("count", len(plans)).Int("user_id", userID).Msg("Listed progression plans")
+	return plans, err
+}
+
+func (s *serviceImpl) DeletePlan(ctx context.Context, userID, exerciseID int) error {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Deleting progression plan")
+	if err := s.repo.DeletePlan(ctx, userID, exerciseID); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Deleted progression plan")
+	return nil
+}
+
+func (s *serviceImpl) Next(ctx context.Context, userID, exerciseID int) (*Prescription, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("exercise_id", exerciseID).Msg("Prescribing next session")
+
+	plan, err := s.repo.GetPlan(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	next, err := s.prescribe(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx).Info().Int("exercise_id", exerciseID).Bool("deload", next.Deload).Msg("Prescribed next session")
+	return next, nil
+}
+
+func (s *serviceImpl) NextAll(ctx context.Context, userID int) ([]*Prescription, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Prescribing next sessions")
+
+	plans, err := s.repo.ListPlans(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	prescriptions := make([]*Prescription, 0, len(plans))
+	for _, plan := range plans {
+		next, err := s.prescribe(ctx, plan)
+		if err != nil {
+			return nil, err
+		}
+		prescriptions = append(prescriptions, next)
+	}
+
+	logger.Ctx(ctx).Info().Int("count", len(prescriptions)).Int("user_id", userID).Msg("Prescribed next sessions")
+	return prescriptions, nil
+}
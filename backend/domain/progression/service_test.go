@@ -0,0 +1,187 @@
+package progression_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/analytics"
+	analyticsMocks "workup_fitness/domain/analytics/mocks"
+	"workup_fitness/domain/exercise"
+	exerciseMocks "workup_fitness/domain/exercise/mocks"
+	"workup_fitness/domain/progression"
+	"workup_fitness/domain/progression/mocks"
+	"workup_fitness/domain/simulator"
+	simulatorMocks "workup_fitness/domain/simulator/mocks"
+)
+
+type testService struct {
+	progression.Service
+	repo             *mocks.MockRepository
+	exerciseService  *exerciseMocks.MockService
+	simulatorService *simulatorMocks.MockService
+	recordService    *analyticsMocks.MockService
+}
+
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	exerciseService := exerciseMocks.NewMockService(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	recordService := analyticsMocks.NewMockService(ctrl)
+	return testService{
+		Service:          progression.NewService(repo, exerciseService, simulatorService, recordService),
+		repo:             repo,
+		exerciseService:  exerciseService,
+		simulatorService: simulatorService,
+		recordService:    recordService,
+	}
+}
+
+func TestService_SavePlan(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1}, nil)
+	svc.repo.EXPECT().
+		SavePlan(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, plan *progression.Plan) (int, error) {
+			require.Equal(t, 3, plan.UserID)
+			require.Equal(t, 5, plan.RepMax, "linear plans have a single rep target")
+			require.Equal(t, 10.0, plan.DeloadPercent, "deloads default to 10%")
+			require.False(t, plan.UpdatedAt.IsZero())
+			return 4, nil
+		})
+
+	saved, err := svc.SavePlan(ctx, 3, &progression.Plan{
+		ExerciseID: 1, Rule: progression.RuleLinear, Sets: 5, RepMin: 5, RepMax: 8, DeloadAfter: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, saved.ID)
+}
+
+func TestService_SavePlan_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	for name, plan := range map[string]progression.Plan{
+		"unknown rule":        {ExerciseID: 1, Rule: "random", Sets: 3, RepMin: 5},
+		"no sets":             {ExerciseID: 1, Rule: progression.RuleLinear, RepMin: 5},
+		"no reps":             {ExerciseID: 1, Rule: progression.RuleLinear, Sets: 3},
+		"double without room": {ExerciseID: 1, Rule: progression.RuleDouble, Sets: 3, RepMin: 8, RepMax: 8},
+		"zero increment":      {ExerciseID: 1, Rule: progression.RuleLinear, Sets: 3, RepMin: 5, Increment: null.FloatFrom(0)},
+		"negative start":      {ExerciseID: 1, Rule: progression.RuleLinear, Sets: 3, RepMin: 5, StartWeight: null.FloatFrom(-10)},
+		"negative max":        {ExerciseID: 1, Rule: progression.RuleWave, TrainingMax: null.FloatFrom(-100)},
+		"deload streak":       {ExerciseID: 1, Rule: progression.RuleWave, DeloadAfter: 11},
+		"deload percent":      {ExerciseID: 1, Rule: progression.RuleWave, DeloadAfter: 2, DeloadPercent: 80},
+	} {
+		_, err := svc.SavePlan(ctx, 3, &plan)
+		require.ErrorIs(t, err, progression.ErrInvalidPlan, name)
+	}
+
+	_, err := svc.SavePlan(ctx, 3, &progression.Plan{ExerciseID: 1})
+	require.ErrorIs(t, err, progression.ErrMissingField)
+
+	svc.exerciseService.EXPECT().GetByID(ctx, 9).Return(nil, exercise.ErrExerciseNotFound)
+	_, err = svc.SavePlan(ctx, 3, &progression.Plan{ExerciseID: 9, Rule: progression.RuleWave})
+	require.ErrorIs(t, err, progression.ErrExerciseNotFound)
+}
+
+func TestService_Next_RoundsToSimulator(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetPlan(ctx, 3, 1).Return(&progression.Plan{
+		UserID: 3, ExerciseID: 1, Rule: progression.RuleLinear, Sets: 3, RepMin: 10, RepMax: 10,
+	}, nil)
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1, SimulatorID: null.IntFrom(2)}, nil)
+	svc.simulatorService.EXPECT().
+		GetByID(ctx, 2).
+		Return(&simulator.Simulator{ID: 2, MinWeight: 5, MaxWeight: 100, WeightIncrement: 7.5}, nil)
+	svc.repo.EXPECT().
+		ListPerformances(ctx, 3, 1).
+		Return([]progression.Performance{session(95, 10, 10, 10)}, nil)
+
+	next, err := svc.Next(ctx, 3, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, next.ExerciseID)
+	require.Equal(t, progression.RuleLinear, next.Rule)
+	require.Equal(t, []float64{95, 95, 95}, weights(*next), "95 + 7.5 is past the top of the stack")
+	require.True(t, next.Capped)
+	require.Contains(t, next.Reason, "95 is the heaviest")
+}
+
+func TestService_SavePlan_IncrementBelowSimulatorStep(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1, SimulatorID: null.IntFrom(2)}, nil)
+	svc.simulatorService.EXPECT().
+		GetByID(ctx, 2).
+		Return(&simulator.Simulator{ID: 2, MinWeight: 5, MaxWeight: 100, WeightIncrement: 5}, nil)
+
+	_, err := svc.SavePlan(ctx, 3, &progression.Plan{
+		ExerciseID: 1, Rule: progression.RuleLinear, Sets: 3, RepMin: 10, Increment: null.FloatFrom(2),
+	})
+	require.ErrorIs(t, err, progression.ErrInvalidPlan)
+}
+
+func TestService_Next_RaisesIncrementToSimulatorStep(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	// Saved before the simulator's step was changed to 5.
+	svc.repo.EXPECT().GetPlan(ctx, 3, 1).Return(&progression.Plan{
+		UserID: 3, ExerciseID: 1, Rule: progression.RuleLinear, Sets: 3, RepMin: 10, RepMax: 10,
+		Increment: null.FloatFrom(2),
+	}, nil)
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1, SimulatorID: null.IntFrom(2)}, nil)
+	svc.simulatorService.EXPECT().
+		GetByID(ctx, 2).
+		Return(&simulator.Simulator{ID: 2, MinWeight: 5, MaxWeight: 100, WeightIncrement: 5}, nil)
+	svc.repo.EXPECT().
+		ListPerformances(ctx, 3, 1).
+		Return([]progression.Performance{session(50, 10, 10, 10)}, nil)
+
+	next, err := svc.Next(ctx, 3, 1)
+	require.NoError(t, err)
+	require.Equal(t, []float64{55, 55, 55}, weights(*next), "52 would round back to 50")
+	require.False(t, next.Capped)
+}
+
+func TestService_Next_WaveFromE1RM(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetPlan(ctx, 3, 1).Return(&progression.Plan{
+		UserID: 3, ExerciseID: 1, Rule: progression.RuleWave, Increment: null.FloatFrom(5), UpdatedAt: time.Now(),
+	}, nil)
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1}, nil)
+	svc.recordService.EXPECT().
+		Bests(ctx, 3, 1).
+		Return([]*analytics.Record{{ExerciseID: 1, Kind: analytics.KindBestE1RM, Value: 150}}, nil)
+	svc.repo.EXPECT().ListPerformances(ctx, 3, 1).Return([]progression.Performance{}, nil)
+
+	next, err := svc.Next(ctx, 3, 1)
+	require.NoError(t, err)
+	// The training max is 135; free weights round to the increment.
+	require.Equal(t, []float64{90, 100, 115}, weights(*next))
+}
+
+func TestService_Next_NoTrainingMax(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().GetPlan(ctx, 3, 1).Return(&progression.Plan{UserID: 3, ExerciseID: 1, Rule: progression.RuleWave}, nil)
+	svc.exerciseService.EXPECT().GetByID(ctx, 1).Return(&exercise.Exercise{ID: 1}, nil)
+	svc.recordService.EXPECT().Bests(ctx, 3, 1).Return([]*analytics.Record{}, nil)
+
+	_, err := svc.Next(ctx, 3, 1)
+	require.ErrorIs(t, err, progression.ErrNoTrainingMax)
+}
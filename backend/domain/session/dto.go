@@ -0,0 +1,69 @@
+package session
+
+import (
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
+
+type StartRequest struct {
+	WorkoutID null.Int    `json:"workout_id"`
+	Notes     zero.String `json:"notes"`
+}
+
+type FinishRequest struct {
+	Notes zero.String `json:"notes"`
+}
+
+// SetRequest logs or corrects a set. SetIndex defaults to the next index
+// for the exercise and Completed to true. ExerciseID is ignored on update.
+type SetRequest struct {
+	ExerciseID  int         `json:"exercise_id"`
+	SetIndex    int         `json:"set_index"`
+	Weight      float64     `json:"weight"`
+	Reps        int         `json:"reps"`
+	RPE         null.Float  `json:"rpe"`
+	RIR         null.Int    `json:"rir"`
+	Tempo       zero.String `json:"tempo"`
+	RestSeconds null.Int    `json:"rest_seconds"`
+	Completed   null.Bool   `json:"completed"`
+	Notes       zero.String `json:"notes"`
+}
+
+type SetResponse struct {
+	ID          int         `json:"id"`
+	ExerciseID  int         `json:"exercise_id"`
+	SetIndex    int         `json:"set_index"`
+	Weight      float64     `json:"weight"`
+	Reps        int         `json:"reps"`
+	RPE         null.Float  `json:"rpe"`
+	RIR         null.Int    `json:"rir"`
+	Tempo       zero.String `json:"tempo"`
+	RestSeconds null.Int    `json:"rest_seconds"`
+	Completed   bool        `json:"completed"`
+	Notes       zero.String `json:"notes"`
+	PerformedAt string      `json:"performed_at"`
+	NewRecords  []string    `json:"new_records,omitempty"`
+}
+
+// PlannedExerciseResponse is one line of the prescription the session
+// follows.
+type PlannedExerciseResponse struct {
+	ExerciseID  int     `json:"exercise_id"`
+	Weight      float64 `json:"weight"`
+	Sets        int     `json:"sets"`
+	Repetitions int     `json:"repetitions"`
+}
+
+type SessionResponse struct {
+	ID         int                       `json:"id"`
+	WorkoutID  null.Int                  `json:"workout_id"`
+	StartedAt  string                    `json:"started_at"`
+	FinishedAt zero.String               `json:"finished_at"`
+	Notes      zero.String               `json:"notes"`
+	Planned    []PlannedExerciseResponse `json:"planned"`
+	Sets       []SetResponse             `json:"sets"`
+}
+
+type ListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
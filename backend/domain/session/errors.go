@@ -0,0 +1,34 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSetNotFound        = errors.New("set not found")
+	ErrMissingField       = errors.New("missing field")
+	ErrInvalidPermissions = errors.New("invalid permissions")
+	ErrSessionInProgress  = errors.New("another session is in progress")
+	ErrSessionFinished    = errors.New("session is finished")
+	ErrSetIndexTaken      = errors.New("set index is already logged")
+	ErrExerciseNotFound   = errors.New("referenced exercise not found")
+	ErrWorkoutNotFound    = errors.New("referenced workout not found")
+	ErrInvalidSet         = errors.New("invalid set")
+)
+
+func init() {
+	httpx.RegisterError(ErrSessionNotFound, http.StatusNotFound, "session_not_found")
+	httpx.RegisterError(ErrSetNotFound, http.StatusNotFound, "set_not_found")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPermissions, http.StatusForbidden, "forbidden")
+	httpx.RegisterError(ErrSessionInProgress, http.StatusConflict, "session_in_progress")
+	httpx.RegisterError(ErrSessionFinished, http.StatusConflict, "session_finished")
+	httpx.RegisterError(ErrSetIndexTaken, http.StatusConflict, "set_index_taken")
+	httpx.RegisterError(ErrExerciseNotFound, http.StatusUnprocessableEntity, "unknown_exercise")
+	httpx.RegisterError(ErrWorkoutNotFound, http.StatusUnprocessableEntity, "unknown_workout")
+	httpx.RegisterError(ErrInvalidSet, http.StatusUnprocessableEntity, "invalid_set")
+}
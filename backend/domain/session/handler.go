@@ -0,0 +1,384 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating session handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created session handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+func toSet(req SetRequest) *Set {
+	completed := true
+	if req.Completed.Valid {
+		completed = req.Completed.Bool
+	}
+	return &Set{
+		ExerciseID:  req.ExerciseID,
+		SetIndex:    req.SetIndex,
+		Weight:      req.Weight,
+		Reps:        req.Reps,
+		RPE:         req.RPE,
+		RIR:         req.RIR,
+		Tempo:       req.Tempo,
+		RestSeconds: req.RestSeconds,
+		Completed:   completed,
+		Notes:       req.Notes,
+	}
+}
+
+func toSetResponse(set *Set) SetResponse {
+	return SetResponse{
+		ID:          set.ID,
+		ExerciseID:  set.ExerciseID,
+		SetIndex:    set.SetIndex,
+		Weight:      set.Weight,
+		Reps:        set.Reps,
+		RPE:         set.RPE,
+		RIR:         set.RIR,
+		Tempo:       set.Tempo,
+		RestSeconds: set.RestSeconds,
+		Completed:   set.Completed,
+		Notes:       set.Notes,
+		PerformedAt: set.PerformedAt.UTC().Format(time.RFC3339),
+		NewRecords:  set.NewRecords,
+	}
+}
+
+func toSessionResponse(session *Session) SessionResponse {
+	resp := SessionResponse{
+		ID:        session.ID,
+		WorkoutID: session.WorkoutID,
+		StartedAt: session.StartedAt.UTC().Format(time.RFC3339),
+		Notes:     session.Notes,
+		Planned:   make([]PlannedExerciseResponse, 0, len(session.Planned)),
+		Sets:      make([]SetResponse, 0, len(session.Sets)),
+	}
+	if session.FinishedAt.Valid {
+		resp.FinishedAt = zero.StringFrom(session.FinishedAt.Time.UTC().Format(time.RFC3339))
+	}
+	for _, planned := range session.Planned {
+		resp.Planned = append(resp.Planned, PlannedExerciseResponse{
+			ExerciseID:  planned.ExerciseID,
+			Weight:      planned.Weight,
+			Sets:        planned.Sets,
+			Repetitions: planned.Repetitions,
+		})
+	}
+	for i := range session.Sets {
+		resp.Sets = append(resp.Sets, toSetResponse(&session.Sets[i]))
+	}
+	return resp
+}
+
+func writeSession(w http.ResponseWriter, status int, session *Session) {
+	resp := toSessionResponse(session)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+	}
+}
+
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req StartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	session, err := h.service.Start(ctx, userID, req.WorkoutID, req.Notes)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeSession(w, http.StatusCreated, session)
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid session id")
+		return
+	}
+
+	session, err := h.service.GetByID(ctx, userID, sessionID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeSession(w, http.StatusOK, session)
+}
+
+func (h *Handler) GetActive(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	session, err := h.service.GetActive(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeSession(w, http.StatusOK, session)
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessions, err := h.service.List(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ListResponse{Sessions: make([]SessionResponse, 0, len(sessions))}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, toSessionResponse(session))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) Finish(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid session id")
+		return
+	}
+
+	// The body is optional.
+	var req FinishRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httpx.BadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	session, err := h.service.Finish(ctx, userID, sessionID, req.Notes)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	writeSession(w, http.StatusOK, session)
+}
+
+func (h *Handler) LogSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid session id")
+		return
+	}
+
+	var req SetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	set, err := h.service.LogSet(ctx, userID, sessionID, toSet(req))
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toSetResponse(set)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) UpdateSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid session id")
+		return
+	}
+	setID, err := strconv.Atoi(chi.URLParam(r, "setID"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid set id")
+		return
+	}
+
+	var req SetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	set := toSet(req)
+	set.ID = setID
+	if err := h.service.UpdateSet(ctx, userID, sessionID, set); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toSetResponse(set)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+}
+
+func (h *Handler) DeleteSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid session id")
+		return
+	}
+	setID, err := strconv.Atoi(chi.URLParam(r, "setID"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid set id")
+		return
+	}
+
+	if err := h.service.DeleteSet(ctx, userID, sessionID, setID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
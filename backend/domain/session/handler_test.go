@@ -0,0 +1,105 @@
+package session_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/session"
+	"workup_fitness/domain/session/mocks"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, params map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if len(params) > 0 {
+		rctx := chi.NewRouteContext()
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestStart_InProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := session.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Start(gomock.Any(), 1, null.IntFrom(4), zero.String{}).
+		Return(nil, session.ErrSessionInProgress)
+
+	rr := httptest.NewRecorder()
+	handler.Start(rr, newAuthedRequest(http.MethodPost, "/sessions", []byte(`{"workout_id": 4}`), 1, nil))
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestLogSet_DefaultsToCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := session.NewHandler(mockService)
+	performedAt := time.Date(2026, 3, 1, 18, 5, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		LogSet(gomock.Any(), 1, 9, &session.Set{ExerciseID: 2, Weight: 100, Reps: 5, RPE: null.FloatFrom(8), Completed: true}).
+		Return(&session.Set{ID: 30, SessionID: 9, ExerciseID: 2, SetIndex: 1, Weight: 100, Reps: 5, RPE: null.FloatFrom(8), Completed: true, PerformedAt: performedAt}, nil)
+
+	rr := httptest.NewRecorder()
+	body := []byte(`{"exercise_id": 2, "weight": 100, "reps": 5, "rpe": 8}`)
+	handler.LogSet(rr, newAuthedRequest(http.MethodPost, "/sessions/9/sets", body, 1, map[string]string{"id": "9"}))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp session.SetResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 30, resp.ID)
+	require.Equal(t, 1, resp.SetIndex)
+	require.True(t, resp.Completed)
+	require.Equal(t, "2026-03-01T18:05:00Z", resp.PerformedAt)
+}
+
+func TestFinish_WithoutBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := session.NewHandler(mockService)
+	startedAt := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		Finish(gomock.Any(), 1, 9, zero.String{}).
+		Return(&session.Session{ID: 9, UserID: 1, StartedAt: startedAt, FinishedAt: null.TimeFrom(startedAt.Add(time.Hour))}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Finish(rr, newAuthedRequest(http.MethodPost, "/sessions/9/finish", nil, 1, map[string]string{"id": "9"}))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp session.SessionResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, "2026-03-01T19:00:00Z", resp.FinishedAt.String)
+	require.Empty(t, resp.Sets)
+}
+
+func TestUpdateSet_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+	handler := session.NewHandler(mockService)
+
+	rr := httptest.NewRecorder()
+	handler.UpdateSet(rr, newAuthedRequest(http.MethodPut, "/sessions/9/sets/abc", []byte(`{}`), 1, map[string]string{"id": "9", "setID": "abc"}))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workup_fitness/domain/session (interfaces: Service)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/session Service
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	session "workup_fitness/domain/session"
+
+	null "github.com/guregu/null/v6"
+	zero "github.com/guregu/null/v6/zero"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteSet mocks base method.
+func (m *MockService) DeleteSet(ctx context.Context, userID, sessionID, setID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSet", ctx, userID, sessionID, setID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSet indicates an expected call of DeleteSet.
+func (mr *MockServiceMockRecorder) DeleteSet(ctx, userID, sessionID, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSet", reflect.TypeOf((*MockService)(nil).DeleteSet), ctx, userID, sessionID, setID)
+}
+
+// Finish mocks base method.
+func (m *MockService) Finish(ctx context.Context, userID, id int, notes zero.String) (*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, userID, id, notes)
+	ret0, _ := ret[0].(*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockServiceMockRecorder) Finish(ctx, userID, id, notes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockService)(nil).Finish), ctx, userID, id, notes)
+}
+
+// GetActive mocks base method.
+func (m *MockService) GetActive(ctx context.Context, userID int) (*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, userID)
+	ret0, _ := ret[0].(*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockServiceMockRecorder) GetActive(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockService)(nil).GetActive), ctx, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockService) GetByID(ctx context.Context, userID, id int) (*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, userID, id)
+	ret0, _ := ret[0].(*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceMockRecorder) GetByID(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, userID, id)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, userID int) ([]*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, userID)
+}
+
+// LogSet mocks base method.
+func (m *MockService) LogSet(ctx context.Context, userID, sessionID int, set *session.Set) (*session.Set, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogSet", ctx, userID, sessionID, set)
+	ret0, _ := ret[0].(*session.Set)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogSet indicates an expected call of LogSet.
+func (mr *MockServiceMockRecorder) LogSet(ctx, userID, sessionID, set any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSet", reflect.TypeOf((*MockService)(nil).LogSet), ctx, userID, sessionID, set)
+}
+
+// Start mocks base method.
+func (m *MockService) Start(ctx context.Context, userID int, workoutID null.Int, notes zero.String) (*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userID, workoutID, notes)
+	ret0, _ := ret[0].(*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockServiceMockRecorder) Start(ctx, userID, workoutID, notes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockService)(nil).Start), ctx, userID, workoutID, notes)
+}
+
+// UpdateSet mocks base method.
+func (m *MockService) UpdateSet(ctx context.Context, userID, sessionID int, set *session.Set) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSet", ctx, userID, sessionID, set)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSet indicates an expected call of UpdateSet.
+func (mr *MockServiceMockRecorder) UpdateSet(ctx, userID, sessionID, set any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSet", reflect.TypeOf((*MockService)(nil).UpdateSet), ctx, userID, sessionID, set)
+}
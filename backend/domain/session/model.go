@@ -0,0 +1,52 @@
+package session
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+
+	"workup_fitness/domain/workout"
+)
+
+// Session is a workout as it was actually performed. It may follow a
+// planned workout, whose prescription stays untouched in workout_exercises;
+// what was done is recorded set by set.
+type Session struct {
+	ID         int         `json:"id"`
+	UserID     int         `json:"user_id"`
+	WorkoutID  null.Int    `json:"workout_id"`
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt null.Time   `json:"finished_at"`
+	Notes      zero.String `json:"notes"`
+	Sets       []Set       `json:"sets"`
+	// Planned is the prescription of the followed workout. It is not
+	// persisted with the session.
+	Planned []workout.WorkoutExercise `json:"planned"`
+}
+
+func (s *Session) Finished() bool {
+	return s.FinishedAt.Valid
+}
+
+// Set is one performed set. SetIndex counts from 1 per exercise within the
+// session. Failed sets have Completed false and Reps holds the reps that
+// were managed.
+type Set struct {
+	ID          int         `json:"id"`
+	SessionID   int         `json:"session_id"`
+	ExerciseID  int         `json:"exercise_id"`
+	SetIndex    int         `json:"set_index"`
+	Weight      float64     `json:"weight"`
+	Reps        int         `json:"reps"`
+	RPE         null.Float  `json:"rpe"`
+	RIR         null.Int    `json:"rir"`
+	Tempo       zero.String `json:"tempo"`
+	RestSeconds null.Int    `json:"rest_seconds"`
+	Completed   bool        `json:"completed"`
+	Notes       zero.String `json:"notes"`
+	PerformedAt time.Time   `json:"performed_at"`
+	// NewRecords lists the kinds of personal records broken when the set
+	// was logged. It is not stored.
+	NewRecords []string `json:"new_records,omitempty"`
+}
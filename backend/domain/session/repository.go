@@ -0,0 +1,251 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/session Repository
+
+type Repository interface {
+	Create(ctx context.Context, session *Session) (int, error)
+	GetByID(ctx context.Context, id int) (*Session, error)
+	GetActive(ctx context.Context, userID int) (*Session, error)
+	ListByUserID(ctx context.Context, userID int) ([]*Session, error)
+	Finish(ctx context.Context, id int, finishedAt time.Time, notes zero.String) error
+	AddSet(ctx context.Context, set *Set) (int, error)
+	GetSet(ctx context.Context, id int) (*Set, error)
+	UpdateSet(ctx context.Context, set *Set) error
+	DeleteSet(ctx context.Context, id int) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+const (
+	selectSession = `SELECT id, user_id, workout_id, started_at, finished_at, notes FROM workout_sessions`
+	selectSet     = `SELECT id, session_id, exercise_id, set_index, weight, reps, rpe, rir, tempo, rest_seconds,
+	completed, notes, performed_at FROM session_sets`
+)
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row scanner) (*Session, error) {
+	var session Session
+	err := row.Scan(&session.ID, &session.UserID, &session.WorkoutID, &session.StartedAt, &session.FinishedAt, &session.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func scanSet(row scanner) (*Set, error) {
+	var set Set
+	err := row.Scan(&set.ID, &set.SessionID, &set.ExerciseID, &set.SetIndex, &set.Weight, &set.Reps, &set.RPE,
+		&set.RIR, &set.Tempo, &set.RestSeconds, &set.Completed, &set.Notes, &set.PerformedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (repo *sqliteRepository) listSets(ctx context.Context, sessionID int) ([]Set, error) {
+	rows, err := repo.db.QueryContext(ctx, selectSet+` WHERE session_id = ? ORDER BY performed_at, id`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sets := make([]Set, 0)
+	for rows.Next() {
+		set, err := scanSet(rows)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, *set)
+	}
+	return sets, rows.Err()
+}
+
+func (repo *sqliteRepository) getSession(ctx context.Context, query string, args ...any) (*Session, error) {
+	session, err := scanSession(repo.db.QueryRowContext(ctx, query, args...))
+	if err := dbutil.ProcessRowError(err, ErrSessionNotFound); err != nil {
+		return nil, err
+	}
+
+	session.Sets, err = repo.listSets(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Create starts session. It fails with ErrSessionInProgress if the user has
+// an unfinished session already.
+func (repo *sqliteRepository) Create(ctx context.Context, session *Session) (int, error) {
+	res, err := repo.db.ExecContext(ctx,
+		`INSERT INTO workout_sessions (user_id, workout_id, started_at, notes) VALUES (?, ?, ?, ?)`,
+		session.UserID, session.WorkoutID, session.StartedAt.UTC(), session.Notes,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrSessionInProgress, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetByID(ctx context.Context, id int) (*Session, error) {
+	return repo.getSession(ctx, selectSession+` WHERE id = ?`, id)
+}
+
+func (repo *sqliteRepository) GetActive(ctx context.Context, userID int) (*Session, error) {
+	return repo.getSession(ctx, selectSession+` WHERE user_id = ? AND finished_at IS NULL`, userID)
+}
+
+// ListByUserID returns the sessions of userID, newest first.
+func (repo *sqliteRepository) ListByUserID(ctx context.Context, userID int) ([]*Session, error) {
+	rows, err := repo.db.QueryContext(ctx, selectSession+` WHERE user_id = ? ORDER BY started_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Sets, err = repo.listSets(ctx, session.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// Finish closes session id. It fails with ErrSessionFinished if it was
+// finished already. Null notes keep the stored ones.
+func (repo *sqliteRepository) Finish(ctx context.Context, id int, finishedAt time.Time, notes zero.String) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE workout_sessions SET finished_at = ?, notes = COALESCE(?, notes) WHERE id = ? AND finished_at IS NULL`,
+		finishedAt.UTC(), notes, id,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSessionFinished
+	}
+	return nil
+}
+
+// AddSet stores set. A zero SetIndex is replaced with the next index for
+// the exercise in the session.
+func (repo *sqliteRepository) AddSet(ctx context.Context, set *Set) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if set.SetIndex == 0 {
+		err := tx.QueryRowContext(ctx,
+			`SELECT COALESCE(MAX(set_index), 0) + 1 FROM session_sets WHERE session_id = ? AND exercise_id = ?`,
+			set.SessionID, set.ExerciseID,
+		).Scan(&set.SetIndex)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO session_sets (session_id, exercise_id, set_index, weight, reps, rpe, rir, tempo, rest_seconds,
+			completed, notes, performed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		set.SessionID, set.ExerciseID, set.SetIndex, set.Weight, set.Reps, set.RPE, set.RIR, set.Tempo,
+		set.RestSeconds, set.Completed, set.Notes, set.PerformedAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrSetIndexTaken, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (repo *sqliteRepository) GetSet(ctx context.Context, id int) (*Set, error) {
+	set, err := scanSet(repo.db.QueryRowContext(ctx, selectSet+` WHERE id = ?`, id))
+	if err := dbutil.ProcessRowError(err, ErrSetNotFound); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// UpdateSet replaces the recorded values of set. The session, exercise and
+// time it was performed stay as logged.
+func (repo *sqliteRepository) UpdateSet(ctx context.Context, set *Set) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE session_sets SET set_index = ?, weight = ?, reps = ?, rpe = ?, rir = ?, tempo = ?, rest_seconds = ?,
+			completed = ?, notes = ? WHERE id = ?`,
+		set.SetIndex, set.Weight, set.Reps, set.RPE, set.RIR, set.Tempo, set.RestSeconds, set.Completed, set.Notes, set.ID,
+	)
+	if err := dbutil.ProcessInsertError(err, ErrSetIndexTaken, ErrMissingField); err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSetNotFound
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) DeleteSet(ctx context.Context, id int) error {
+	result, err := repo.db.ExecContext(ctx, `DELETE FROM session_sets WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSetNotFound
+	}
+	return nil
+}
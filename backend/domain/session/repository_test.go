@@ -0,0 +1,109 @@
+package session_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/session"
+	"workup_fitness/internal/testutil"
+)
+
+func newTestRepository(t *testing.T) (session.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := session.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO exercises (name) VALUES ('Squat'), ('Bench press')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_OneActiveSessionPerUser(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	startedAt := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	id, err := repo.Create(ctx, &session.Session{UserID: 1, StartedAt: startedAt})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, &session.Session{UserID: 1, StartedAt: startedAt})
+	require.ErrorIs(t, err, session.ErrSessionInProgress)
+	_, err = repo.Create(ctx, &session.Session{UserID: 2, StartedAt: startedAt})
+	require.NoError(t, err, "other users are not affected")
+
+	active, err := repo.GetActive(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, id, active.ID)
+
+	require.NoError(t, repo.Finish(ctx, id, startedAt.Add(time.Hour), zero.StringFrom("felt strong")))
+	require.ErrorIs(t, repo.Finish(ctx, id, startedAt.Add(2*time.Hour), zero.String{}), session.ErrSessionFinished)
+
+	_, err = repo.GetActive(ctx, 1)
+	require.ErrorIs(t, err, session.ErrSessionNotFound)
+	_, err = repo.Create(ctx, &session.Session{UserID: 1, StartedAt: startedAt.Add(24 * time.Hour)})
+	require.NoError(t, err)
+
+	finished, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.True(t, finished.Finished())
+	require.Equal(t, "felt strong", finished.Notes.String)
+
+	sessions, err := repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, id, sessions[1].ID, "newest first")
+}
+
+func TestRepository_Sets(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	sessionID, err := repo.Create(ctx, &session.Session{UserID: 1, StartedAt: time.Now()})
+	require.NoError(t, err)
+
+	performedAt := time.Date(2026, 3, 1, 18, 5, 0, 0, time.UTC)
+	for _, set := range []*session.Set{
+		{SessionID: sessionID, ExerciseID: 1, Weight: 100, Reps: 5, Completed: true, PerformedAt: performedAt},
+		{SessionID: sessionID, ExerciseID: 1, Weight: 100, Reps: 3, Completed: false, RPE: null.FloatFrom(10), PerformedAt: performedAt.Add(3 * time.Minute)},
+		{SessionID: sessionID, ExerciseID: 2, Weight: 60, Reps: 8, Completed: true, Tempo: zero.StringFrom("31X0"), PerformedAt: performedAt.Add(6 * time.Minute)},
+	} {
+		_, err := repo.AddSet(ctx, set)
+		require.NoError(t, err)
+	}
+
+	_, err = repo.AddSet(ctx, &session.Set{SessionID: sessionID, ExerciseID: 1, SetIndex: 2, Weight: 90, Reps: 5, PerformedAt: performedAt})
+	require.ErrorIs(t, err, session.ErrSetIndexTaken)
+
+	found, err := repo.GetByID(ctx, sessionID)
+	require.NoError(t, err)
+	require.Len(t, found.Sets, 3)
+	require.Equal(t, []int{1, 2, 1}, []int{found.Sets[0].SetIndex, found.Sets[1].SetIndex, found.Sets[2].SetIndex})
+	require.False(t, found.Sets[1].Completed)
+	require.Equal(t, null.FloatFrom(10), found.Sets[1].RPE)
+	require.Equal(t, "31X0", found.Sets[2].Tempo.String)
+
+	failed := found.Sets[1]
+	failed.Reps = 5
+	failed.Completed = true
+	require.NoError(t, repo.UpdateSet(ctx, &failed))
+	stored, err := repo.GetSet(ctx, failed.ID)
+	require.NoError(t, err)
+	require.True(t, stored.Completed)
+	require.True(t, performedAt.Add(3*time.Minute).Equal(stored.PerformedAt))
+
+	require.NoError(t, repo.DeleteSet(ctx, failed.ID))
+	require.ErrorIs(t, repo.DeleteSet(ctx, failed.ID), session.ErrSetNotFound)
+	_, err = repo.GetSet(ctx, failed.ID)
+	require.ErrorIs(t, err, session.ErrSetNotFound)
+}
@@ -0,0 +1,24 @@
+package session
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("workouts"))
+		r.Get("/sessions", h.List)
+		r.Post("/sessions", h.Start)
+		r.Get("/sessions/active", h.GetActive)
+		r.Get("/sessions/{id}", h.GetByID)
+		r.Post("/sessions/{id}/finish", h.Finish)
+		r.Post("/sessions/{id}/sets", h.LogSet)
+		r.Put("/sessions/{id}/sets/{setID}", h.UpdateSet)
+		r.Delete("/sessions/{id}/sets/{setID}", h.DeleteSet)
+	})
+}
@@ -0,0 +1,348 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/workout"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/session Service
+
+const (
+	maxRPE         = 10
+	minRPE         = 1
+	maxRIR         = 10
+	maxRestSeconds = 60 * 60
+	maxNoteLength  = 500
+)
+
+// tempoPattern accepts four phases (eccentric, pause, concentric, pause),
+// either as 31X0 or 3-1-X-0. X means explosive.
+var tempoPattern = regexp.MustCompile(`^([0-9X]{4}|[0-9X]{1,2}(-[0-9X]{1,2}){3})$`)
+
+type ExerciseService interface {
+	GetByID(ctx context.Context, id int) (*exercise.Exercise, error)
+}
+
+type WorkoutService interface {
+	GetByID(ctx context.Context, userID, id int) (*workout.Workout, error)
+}
+
+// RecordTracker keeps personal records current as sets are saved.
+type RecordTracker interface {
+	RecordSet(ctx context.Context, userID int, set *Set) ([]string, error)
+	RebuildRecords(ctx context.Context, userID, exerciseID int) error
+}
+
+type Service interface {
+	Start(ctx context.Context, userID int, workoutID null.Int, notes zero.String) (*Session, error)
+	GetByID(ctx context.Context, userID, id int) (*Session, error)
+	GetActive(ctx context.Context, userID int) (*Session, error)
+	List(ctx context.Context, userID int) ([]*Session, error)
+	Finish(ctx context.Context, userID, id int, notes zero.String) (*Session, error)
+	LogSet(ctx context.Context, userID, sessionID int, set *Set) (*Set, error)
+	UpdateSet(ctx context.Context, userID, sessionID int, set *Set) error
+	DeleteSet(ctx context.Context, userID, sessionID, setID int) error
+}
+
+type serviceImpl struct {
+	repo            Repository
+	exerciseService ExerciseService
+	workoutService  WorkoutService
+	records         RecordTracker
+	now             func() time.Time
+}
+
+// NewService creates the session service. records may be nil, in which
+// case no personal records are tracked.
+func NewService(repo Repository, exerciseService ExerciseService, workoutService WorkoutService, records RecordTracker) *serviceImpl {
+	logger.Default().Debug().Msg("Creating session service...")
+	res := &serviceImpl{
+		repo:            repo,
+		exerciseService: exerciseService,
+		workoutService:  workoutService,
+		records:         records,
+		now:             time.Now,
+	}
+	logger.Default().Debug().Msg("Created session service")
+	return res
+}
+
+// getOwned loads a session and makes sure it belongs to userID.
+func (s *serviceImpl) getOwned(ctx context.Context, userID, id int) (*Session, error) {
+	session, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrInvalidPermissions
+	}
+	return session, nil
+}
+
+// getOpen loads a session of userID that still takes sets.
+func (s *serviceImpl) getOpen(ctx context.Context, userID, id int) (*Session, error) {
+	session, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Finished() {
+		return nil, ErrSessionFinished
+	}
+	return session, nil
+}
+
+// getSet loads a set and makes sure it was logged in sessionID.
+func (s *serviceImpl) getSet(ctx context.Context, sessionID, id int) (*Set, error) {
+	set, err := s.repo.GetSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if set.SessionID != sessionID {
+		return nil, ErrSetNotFound
+	}
+	return set, nil
+}
+
+// addPlan attaches the prescription of the followed workout. Sessions keep
+// their sets when the workout is deleted later, so a missing workout is not
+// an error.
+func (s *serviceImpl) addPlan(ctx context.Context, session *Session) error {
+	if !session.WorkoutID.Valid {
+		return nil
+	}
+	planned, err := s.workoutService.GetByID(ctx, session.UserID, int(session.WorkoutID.Int64))
+	if errors.Is(err, workout.ErrWorkoutNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	session.Planned = planned.Exercises
+	return nil
+}
+
+func (s *serviceImpl) checkSet(ctx context.Context, set *Set) error {
+	if set.ExerciseID == 0 {
+		return errors.Join(ErrMissingField, errors.New("exercise_id is required"))
+	}
+	if set.SetIndex < 0 {
+		return fmt.Errorf("%w: set_index cannot be negative", ErrInvalidSet)
+	}
+	if set.Weight < 0 || set.Reps < 0 {
+		return fmt.Errorf("%w: weight and reps cannot be negative", ErrInvalidSet)
+	}
+	if set.Completed && set.Reps == 0 {
+		return fmt.Errorf("%w: a completed set needs at least one rep", ErrInvalidSet)
+	}
+	if set.RPE.Valid {
+		rpe := set.RPE.Float64
+		if rpe < minRPE || rpe > maxRPE || math.Mod(rpe*2, 1) != 0 {
+			return fmt.Errorf("%w: rpe must be between %d and %d in steps of 0.5", ErrInvalidSet, minRPE, maxRPE)
+		}
+	}
+	if set.RIR.Valid && (set.RIR.Int64 < 0 || set.RIR.Int64 > maxRIR) {
+		return fmt.Errorf("%w: rir must be between 0 and %d", ErrInvalidSet, maxRIR)
+	}
+	if set.Tempo.String != "" {
+		tempo := strings.ToUpper(strings.TrimSpace(set.Tempo.String))
+		if !tempoPattern.MatchString(tempo) {
+			return fmt.Errorf("%w: tempo %q must look like 31X0 or 3-1-X-0", ErrInvalidSet, set.Tempo.String)
+		}
+		set.Tempo = zero.StringFrom(tempo)
+	}
+	if set.RestSeconds.Valid && (set.RestSeconds.Int64 < 0 || set.RestSeconds.Int64 > maxRestSeconds) {
+		return fmt.Errorf("%w: rest_seconds must be between 0 and %d", ErrInvalidSet, maxRestSeconds)
+	}
+	if utf8.RuneCountInString(set.Notes.String) > maxNoteLength {
+		return fmt.Errorf("%w: notes are longer than %d characters", ErrInvalidSet, maxNoteLength)
+	}
+
+	_, err := s.exerciseService.GetByID(ctx, set.ExerciseID)
+	if errors.Is(err, exercise.ErrExerciseNotFound) {
+		return ErrExerciseNotFound
+	}
+	return err
+}
+
+// rebuildRecords brings the personal records of an exercise up to date
+// after a set was changed or removed. The change is already saved, so a
+// failure is only logged; the next rebuild catches up.
+func (s *serviceImpl) rebuildRecords(ctx context.Context, userID, exerciseID int) {
+	if s.records == nil {
+		return
+	}
+	if err := s.records.RebuildRecords(ctx, userID, exerciseID); err != nil {
+		logger.Ctx(ctx).Error().Err(err).Int("exercise_id", exerciseID).Msg("Failed to rebuild personal records")
+	}
+}
+
+// Start opens a live session, optionally following the planned workout
+// workoutID. Only one session per user can be open at a time.
+func (s *serviceImpl) Start(ctx context.Context, userID int, workoutID null.Int, notes zero.String) (*Session, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Starting session")
+
+	session := &Session{UserID: userID, WorkoutID: workoutID, StartedAt: s.now().UTC(), Notes: notes, Sets: []Set{}}
+	if workoutID.Valid {
+		planned, err := s.workoutService.GetByID(ctx, userID, int(workoutID.Int64))
+		if errors.Is(err, workout.ErrWorkoutNotFound) || errors.Is(err, workout.ErrInvalidPermissions) {
+			return nil, ErrWorkoutNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		session.Planned = planned.Exercises
+	}
+
+	createdID, err := s.repo.Create(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	session.ID = createdID
+	logger.Ctx(ctx).Info().Int("session_id", session.ID).Int("user_id", userID).Msg("Started session")
+	return session, nil
+}
+
+func (s *serviceImpl) GetByID(ctx context.Context, userID, id int) (*Session, error) {
+	logger.Ctx(ctx).Info().Int("session_id", id).Msg("Getting session")
+	session, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.addPlan(ctx, session); err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("session_id", id).Msg("Got session")
+	return session, nil
+}
+
+func (s *serviceImpl) GetActive(ctx context.Context, userID int) (*Session, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Getting active session")
+	session, err := s.repo.GetActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.addPlan(ctx, session); err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("session_id", session.ID).Msg("Got active session")
+	return session, nil
+}
+
+func (s *serviceImpl) List(ctx context.Context, userID int) ([]*Session, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing sessions")
+	sessions, err := s.repo.ListByUserID(ctx, userID)
+	logger.Ctx(ctx).Info().Int("count", len(sessions)).Int("user_id", userID).Msg("Listed sessions")
+	return sessions, err
+}
+
+func (s *serviceImpl) Finish(ctx context.Context, userID, id int, notes zero.String) (*Session, error) {
+	logger.Ctx(ctx).Info().Int("session_id", id).Msg("Finishing session")
+
+	session, err := s.getOpen(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	finishedAt := s.now().UTC()
+	if err := s.repo.Finish(ctx, id, finishedAt, notes); err != nil {
+		return nil, err
+	}
+	session.FinishedAt = null.TimeFrom(finishedAt)
+	if notes.Valid {
+		session.Notes = notes
+	}
+	if err := s.addPlan(ctx, session); err != nil {
+		return nil, err
+	}
+
+	logger.Ctx(ctx).Info().Int("session_id", id).Int("sets", len(session.Sets)).Msg("Finished session")
+	return session, nil
+}
+
+func (s *serviceImpl) LogSet(ctx context.Context, userID, sessionID int, set *Set) (*Set, error) {
+	logger.Ctx(ctx).Info().Int("session_id", sessionID).Int("exercise_id", set.ExerciseID).Msg("Logging set")
+
+	if _, err := s.getOpen(ctx, userID, sessionID); err != nil {
+		return nil, err
+	}
+	if err := s.checkSet(ctx, set); err != nil {
+		return nil, err
+	}
+
+	set.SessionID = sessionID
+	set.PerformedAt = s.now().UTC()
+	createdID, err := s.repo.AddSet(ctx, set)
+	if err != nil {
+		return nil, err
+	}
+	set.ID = createdID
+	if s.records != nil {
+		set.NewRecords, err = s.records.RecordSet(ctx, userID, set)
+		if err != nil {
+			logger.Ctx(ctx).Error().Err(err).Int("set_id", set.ID).Msg("Failed to check personal records")
+		}
+	}
+	logger.Ctx(ctx).Info().Int("session_id", sessionID).Int("set_id", set.ID).Msg("Logged set")
+	return set, nil
+}
+
+// UpdateSet corrects a set logged in a session that is still open. The
+// exercise of a set cannot change; delete it and log a new one instead.
+func (s *serviceImpl) UpdateSet(ctx context.Context, userID, sessionID int, set *Set) error {
+	logger.Ctx(ctx).Info().Int("session_id", sessionID).Int("set_id", set.ID).Msg("Updating set")
+
+	if _, err := s.getOpen(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	stored, err := s.getSet(ctx, sessionID, set.ID)
+	if err != nil {
+		return err
+	}
+	set.SessionID = sessionID
+	set.ExerciseID = stored.ExerciseID
+	set.PerformedAt = stored.PerformedAt
+	if set.SetIndex == 0 {
+		set.SetIndex = stored.SetIndex
+	}
+	if err := s.checkSet(ctx, set); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateSet(ctx, set); err != nil {
+		return err
+	}
+	s.rebuildRecords(ctx, userID, set.ExerciseID)
+	logger.Ctx(ctx).Info().Int("session_id", sessionID).Int("set_id", set.ID).Msg("Updated set")
+	return nil
+}
+
+func (s *serviceImpl) DeleteSet(ctx context.Context, userID, sessionID, setID int) error {
+	logger.Ctx(ctx).Info().Int("session_id", sessionID).Int("set_id", setID).Msg("Deleting set")
+
+	if _, err := s.getOpen(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	stored, err := s.getSet(ctx, sessionID, setID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSet(ctx, setID); err != nil {
+		return err
+	}
+	s.rebuildRecords(ctx, userID, stored.ExerciseID)
+	logger.Ctx(ctx).Info().Int("session_id", sessionID).Int("set_id", setID).Msg("Deleted set")
+	return nil
+}
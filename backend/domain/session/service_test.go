@@ -0,0 +1,210 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	analyticsMocks "workup_fitness/domain/analytics/mocks"
+	"workup_fitness/domain/exercise"
+	exerciseMocks "workup_fitness/domain/exercise/mocks"
+	"workup_fitness/domain/session"
+	"workup_fitness/domain/session/mocks"
+	"workup_fitness/domain/workout"
+	workoutMocks "workup_fitness/domain/workout/mocks"
+)
+
+type testService struct {
+	session.Service
+	repo            *mocks.MockRepository
+	exerciseService *exerciseMocks.MockService
+	workoutService  *workoutMocks.MockService
+	records         *analyticsMocks.MockService
+}
+
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	exerciseService := exerciseMocks.NewMockService(ctrl)
+	workoutService := workoutMocks.NewMockService(ctrl)
+	records := analyticsMocks.NewMockService(ctrl)
+	return testService{
+		Service:         session.NewService(repo, exerciseService, workoutService, records),
+		repo:            repo,
+		exerciseService: exerciseService,
+		workoutService:  workoutService,
+		records:         records,
+	}
+}
+
+func TestService_Start_FollowsWorkout(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	planned := []workout.WorkoutExercise{{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5}}
+	svc.workoutService.EXPECT().
+		GetByID(ctx, 3, 8).
+		Return(&workout.Workout{ID: 8, UserID: 3, Exercises: planned}, nil)
+	svc.repo.EXPECT().Create(ctx, gomock.Any()).Return(11, nil)
+
+	started, err := svc.Start(ctx, 3, null.IntFrom(8), zero.String{})
+	require.NoError(t, err)
+	require.Equal(t, 11, started.ID)
+	require.Equal(t, planned, started.Planned)
+	require.Empty(t, started.Sets)
+	require.False(t, started.Finished())
+}
+
+func TestService_Start_ForeignWorkout(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.workoutService.EXPECT().
+		GetByID(ctx, 3, 8).
+		Return(nil, workout.ErrInvalidPermissions)
+
+	_, err := svc.Start(ctx, 3, null.IntFrom(8), zero.String{})
+	require.ErrorIs(t, err, session.ErrWorkoutNotFound)
+}
+
+func TestService_GetByID_DeletedWorkout(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3, WorkoutID: null.IntFrom(8)}, nil)
+	svc.workoutService.EXPECT().
+		GetByID(ctx, 3, 8).
+		Return(nil, workout.ErrWorkoutNotFound)
+
+	found, err := svc.GetByID(ctx, 3, 11)
+	require.NoError(t, err)
+	require.Nil(t, found.Planned)
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 4}, nil)
+
+	_, err = svc.GetByID(ctx, 3, 11)
+	require.ErrorIs(t, err, session.ErrInvalidPermissions)
+}
+
+func TestService_LogSet(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3}, nil)
+	svc.exerciseService.EXPECT().
+		GetByID(ctx, 1).
+		Return(&exercise.Exercise{ID: 1}, nil)
+	svc.repo.EXPECT().AddSet(ctx, gomock.Any()).Return(21, nil)
+	svc.records.EXPECT().
+		RecordSet(ctx, 3, gomock.Any()).
+		Return([]string{"heaviest_weight", "best_e1rm"}, nil)
+
+	set, err := svc.LogSet(ctx, 3, 11, &session.Set{ExerciseID: 1, Weight: 100, Reps: 5, Completed: true, Tempo: zero.StringFrom("3-1-x-0")})
+	require.NoError(t, err)
+	require.Equal(t, 21, set.ID)
+	require.Equal(t, 11, set.SessionID)
+	require.Equal(t, "3-1-X-0", set.Tempo.String)
+	require.Equal(t, []string{"heaviest_weight", "best_e1rm"}, set.NewRecords)
+	require.WithinDuration(t, time.Now(), set.PerformedAt, time.Minute)
+}
+
+func TestService_LogSet_Invalid(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3}, nil).
+		AnyTimes()
+
+	_, err := svc.LogSet(ctx, 3, 11, &session.Set{Weight: 100, Reps: 5})
+	require.ErrorIs(t, err, session.ErrMissingField)
+
+	for name, set := range map[string]*session.Set{
+		"negative weight":    {ExerciseID: 1, Weight: -1, Reps: 5},
+		"completed, no reps": {ExerciseID: 1, Weight: 100, Completed: true},
+		"rpe too high":       {ExerciseID: 1, Weight: 100, Reps: 5, RPE: null.FloatFrom(11)},
+		"rpe between halves": {ExerciseID: 1, Weight: 100, Reps: 5, RPE: null.FloatFrom(8.3)},
+		"negative rir":       {ExerciseID: 1, Weight: 100, Reps: 5, RIR: null.IntFrom(-1)},
+		"tempo":              {ExerciseID: 1, Weight: 100, Reps: 5, Tempo: zero.StringFrom("slow")},
+		"rest over an hour":  {ExerciseID: 1, Weight: 100, Reps: 5, RestSeconds: null.IntFrom(7200)},
+		"negative set index": {ExerciseID: 1, Weight: 100, Reps: 5, SetIndex: -1},
+	} {
+		_, err := svc.LogSet(ctx, 3, 11, set)
+		require.ErrorIs(t, err, session.ErrInvalidSet, name)
+	}
+}
+
+func TestService_LogSet_FinishedSession(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3, FinishedAt: null.TimeFrom(time.Now())}, nil)
+
+	_, err := svc.LogSet(ctx, 3, 11, &session.Set{ExerciseID: 1, Weight: 100, Reps: 5, Completed: true})
+	require.ErrorIs(t, err, session.ErrSessionFinished)
+}
+
+func TestService_UpdateSet_OtherSession(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3}, nil)
+	svc.repo.EXPECT().
+		GetSet(ctx, 21).
+		Return(&session.Set{ID: 21, SessionID: 12, ExerciseID: 1}, nil)
+
+	err := svc.UpdateSet(ctx, 3, 11, &session.Set{ID: 21, Weight: 100, Reps: 5, Completed: true})
+	require.ErrorIs(t, err, session.ErrSetNotFound)
+}
+
+func TestService_DeleteSet_RebuildsRecords(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3}, nil)
+	svc.repo.EXPECT().
+		GetSet(ctx, 21).
+		Return(&session.Set{ID: 21, SessionID: 11, ExerciseID: 1}, nil)
+	svc.repo.EXPECT().DeleteSet(ctx, 21).Return(nil)
+	svc.records.EXPECT().RebuildRecords(ctx, 3, 1).Return(errors.New("database is locked"))
+
+	require.NoError(t, svc.DeleteSet(ctx, 3, 11, 21), "the set is gone even if records lag behind")
+}
+
+func TestService_Finish(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 11).
+		Return(&session.Session{ID: 11, UserID: 3, Sets: []session.Set{{ID: 21}}}, nil)
+	svc.repo.EXPECT().
+		Finish(ctx, 11, gomock.Any(), zero.StringFrom("good day")).
+		Return(nil)
+
+	finished, err := svc.Finish(ctx, 3, 11, zero.StringFrom("good day"))
+	require.NoError(t, err)
+	require.True(t, finished.Finished())
+	require.Equal(t, "good day", finished.Notes.String)
+}
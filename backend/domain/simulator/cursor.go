@@ -0,0 +1,54 @@
+package simulator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// listCursor points right after the last simulator of a page. It carries the
+// sort key of that row so keyset pagination stays stable under any ordering,
+// and the sort and order it was issued for, since the key means nothing
+// under another ordering.
+type listCursor struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name,omitempty"`
+	Weight float64 `json:"weight,omitempty"`
+	Sort   string  `json:"sort"`
+	Desc   bool    `json:"desc,omitempty"`
+}
+
+func newListCursor(simulator *Simulator, sort string, desc bool) listCursor {
+	cursor := listCursor{ID: simulator.ID, Sort: sort, Desc: desc}
+	switch sort {
+	case SortByName:
+		cursor.Name = simulator.Name.String
+	case SortByMinWeight:
+		cursor.Weight = simulator.MinWeight
+	case SortByMaxWeight:
+		cursor.Weight = simulator.MaxWeight
+	}
+	return cursor
+}
+
+func (c listCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursor parses raw and rejects cursors issued for a different
+// sort or order than the page being requested.
+func decodeListCursor(raw, sort string, desc bool) (listCursor, error) {
+	var cursor listCursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return cursor, ErrInvalidCursor
+	}
+	if cursor.Sort != sort || cursor.Desc != desc {
+		return cursor, fmt.Errorf("%w: it was issued for a different sort or order", ErrInvalidCursor)
+	}
+	return cursor, nil
+}
@@ -44,3 +44,8 @@ type UpdateResponse struct {
 	MaxWeight       float64 `json:"max_weight"`
 	WeightIncrement float64 `json:"weight_increment"`
 }
+
+type ListResponse struct {
+	Simulators []GetByIDResponse `json:"simulators"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
@@ -1,13 +1,36 @@
 package simulator
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
 
 var (
 	ErrAlreadyExists      = errors.New("simulator already exists")
 	ErrMissingField       = errors.New("missing field")
 	ErrInvalidPermissions = errors.New("invalid permissions")
 	ErrSimulatorNotFound  = errors.New("simulator not found")
+	ErrSimulatorInUse     = errors.New("simulator is used by exercises")
 	ErrNegativeWeight     = errors.New("weight cannot be negative")
 	ErrWrongRange         = errors.New("weight range is invalid")
 	ErrZeroIncrement      = errors.New("weight increment cannot be zero")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidSort        = errors.New("invalid sort field")
+	ErrInvalidLimit       = errors.New("invalid page limit")
 )
+
+func init() {
+	httpx.RegisterError(ErrAlreadyExists, http.StatusConflict, "simulator_already_exists")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPermissions, http.StatusForbidden, "forbidden")
+	httpx.RegisterError(ErrSimulatorNotFound, http.StatusNotFound, "simulator_not_found")
+	httpx.RegisterError(ErrSimulatorInUse, http.StatusConflict, "simulator_in_use")
+	httpx.RegisterError(ErrNegativeWeight, http.StatusBadRequest, "negative_weight")
+	httpx.RegisterError(ErrWrongRange, http.StatusUnprocessableEntity, "invalid_weight_range")
+	httpx.RegisterError(ErrZeroIncrement, http.StatusBadRequest, "zero_weight_increment")
+	httpx.RegisterError(ErrInvalidCursor, http.StatusBadRequest, "invalid_cursor")
+	httpx.RegisterError(ErrInvalidSort, http.StatusBadRequest, "invalid_sort")
+	httpx.RegisterError(ErrInvalidLimit, http.StatusBadRequest, "invalid_limit")
+}
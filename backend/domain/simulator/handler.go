@@ -2,13 +2,15 @@ package simulator
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/rs/zerolog/log"
+	"github.com/guregu/null/v6"
 )
 
 type Handler struct {
@@ -16,9 +18,9 @@ type Handler struct {
 }
 
 func NewHandler(service Service) *Handler {
-	log.Info().Msg("Creating simulator handler...")
+	logger.Default().Debug().Msg("Creating simulator handler...")
 	res := &Handler{service: service}
-	log.Info().Msg("Created simulator handler")
+	logger.Default().Debug().Msg("Created simulator handler")
 	return res
 }
 
@@ -36,15 +38,11 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Creating simulator with name %s", req.Name)
+	logger.Ctx(ctx).Info().Str("name", req.Name).Msg("Creating simulator")
 
 	simulator, err := h.service.Create(ctx, req.Name, req.Description, req.MinWeight, req.MaxWeight, req.WeightIncrement)
 	if err != nil {
-		if err == ErrNegativeWeight || err == ErrZeroIncrement {
-			httpx.BadRequest(w, err.Error())
-			return
-		}
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
@@ -63,7 +61,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Created simulator with id %d", simulator.ID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulator.ID).Msg("Created simulator")
 }
 
 func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
@@ -80,11 +78,11 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Getting simulator with id %d", simulatorID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulatorID).Msg("Getting simulator")
 
 	simulator, err := h.service.GetByID(ctx, simulatorID)
 	if err != nil {
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
@@ -102,7 +100,92 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Got simulator with id %d", simulatorID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulatorID).Msg("Got simulator")
+}
+
+func parseListFilter(r *http.Request) (ListFilter, error) {
+	query := r.URL.Query()
+	filter := ListFilter{
+		Search: query.Get("search"),
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, errors.New("order must be asc or desc")
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return filter, errors.New("limit must be an integer")
+		}
+		filter.Limit = limit
+	}
+
+	for key, dst := range map[string]*null.Float{"weight_from": &filter.WeightFrom, "weight_to": &filter.WeightTo} {
+		raw := query.Get(key)
+		if raw == "" {
+			continue
+		}
+		weight, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return filter, errors.New(key + " must be a number")
+		}
+		*dst = null.FloatFrom(weight)
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	filter, err := parseListFilter(r)
+	if err != nil {
+		httpx.BadRequest(w, err.Error())
+		return
+	}
+
+	logger.Ctx(ctx).Info().Str("search", filter.Search).Msg("Listing simulators")
+
+	page, err := h.service.List(ctx, filter)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ListResponse{
+		Simulators: make([]GetByIDResponse, 0, len(page.Simulators)),
+		NextCursor: page.NextCursor,
+	}
+	for _, simulator := range page.Simulators {
+		resp.Simulators = append(resp.Simulators, GetByIDResponse{
+			ID:              simulator.ID,
+			Name:            simulator.Name.String,
+			Description:     simulator.Description,
+			MinWeight:       simulator.MinWeight,
+			MaxWeight:       simulator.MaxWeight,
+			WeightIncrement: simulator.WeightIncrement,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("count", len(page.Simulators)).Msg("Listed simulators")
 }
 
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
@@ -119,7 +202,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Updating simulator with id %d", simulatorID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulatorID).Msg("Updating simulator")
 
 	var req UpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -137,11 +220,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Update(ctx, simulator); err != nil {
-		if err == ErrNegativeWeight || err == ErrZeroIncrement {
-			httpx.BadRequest(w, err.Error())
-			return
-		}
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
@@ -159,7 +238,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Updated simulator with id %d", simulatorID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulatorID).Msg("Updated simulator")
 }
 
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -176,15 +255,15 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Deleting simulator with id %d", simulatorID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulatorID).Msg("Deleting simulator")
 
 	err = h.service.Delete(ctx, simulatorID)
 	if err != nil {
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 
-	log.Info().Msgf("Deleted simulator with id %d", simulatorID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulatorID).Msg("Deleted simulator")
 }
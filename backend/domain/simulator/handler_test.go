@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -406,3 +407,75 @@ func TestDelete_ServiceError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestList_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := simulator.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any(), simulator.ListFilter{
+			Search:     "press",
+			WeightFrom: null.FloatFrom(80),
+			WeightTo:   null.FloatFrom(80),
+			Sort:       simulator.SortByName,
+			Desc:       true,
+			Cursor:     "abc",
+			Limit:      5,
+		}).
+		Return(&simulator.ListPage{
+			Simulators: []*simulator.Simulator{{ID: 1, Name: zero.StringFrom("Leg press")}},
+			NextCursor: "next",
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/simulators?search=press&weight_from=80&weight_to=80&sort=name&order=desc&cursor=abc&limit=5", nil)
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp simulator.ListResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Simulators, 1)
+	require.Equal(t, "Leg press", resp.Simulators[0].Name)
+	require.Equal(t, "next", resp.NextCursor)
+}
+
+func TestList_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := simulator.NewHandler(mockService)
+
+	for _, query := range []string{"limit=ten", "weight_from=heavy", "order=sideways"} {
+		req := httptest.NewRequest(http.MethodGet, "/simulators?"+query, nil)
+		rr := httptest.NewRecorder()
+
+		handler.List(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestList_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := simulator.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(nil, simulator.ErrInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodGet, "/simulators?cursor=bad", nil)
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -100,6 +100,21 @@ func (mr *MockRepositoryMockRecorder) GetByName(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockRepository)(nil).GetByName), ctx, name)
 }
 
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, filter simulator.ListFilter) (*simulator.ListPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].(*simulator.ListPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctxt context.Context, arg1 *simulator.Simulator) error {
 	m.ctrl.T.Helper()
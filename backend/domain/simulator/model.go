@@ -14,3 +14,28 @@ type Simulator struct {
 	WeightIncrement float64     `json:"weight_increment"`
 	CreatedAt       null.Time   `json:"created_at"`
 }
+
+const (
+	SortByID        = "id"
+	SortByName      = "name"
+	SortByMinWeight = "min_weight"
+	SortByMaxWeight = "max_weight"
+)
+
+// ListFilter narrows and orders a simulators listing. WeightFrom and WeightTo
+// select machines whose stack covers the whole range; Cursor is the opaque
+// NextCursor of the previous page.
+type ListFilter struct {
+	Search     string
+	WeightFrom null.Float
+	WeightTo   null.Float
+	Sort       string
+	Desc       bool
+	Cursor     string
+	Limit      int
+}
+
+type ListPage struct {
+	Simulators []*Simulator
+	NextCursor string
+}
@@ -3,10 +3,11 @@ package simulator
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"workup_fitness/internal/dbutil"
-
-	"github.com/rs/zerolog/log"
+	"workup_fitness/pkg/logger"
 )
 
 //go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/simulator Repository
@@ -15,6 +16,7 @@ type Repository interface {
 	Create(ctx context.Context, simulator *Simulator) (int, error)
 	GetByID(ctx context.Context, id int) (*Simulator, error)
 	GetByName(ctx context.Context, name string) (*Simulator, error)
+	List(ctx context.Context, filter ListFilter) (*ListPage, error)
 	Update(ctxt context.Context, simulator *Simulator) error
 	Delete(ctx context.Context, id int) error
 }
@@ -32,7 +34,7 @@ func (repo *sqliteRepository) Create(ctx context.Context, simulator *Simulator)
 		`INSERT INTO simulators (name, description, min_weight, max_weight, weight_increment) VALUES (?, ?, ?, ?, ?)`,
 		simulator.Name, simulator.Description, simulator.MinWeight, simulator.MaxWeight, simulator.WeightIncrement,
 	)
-	log.Info().Msgf("Created simulator with name %s", simulator.Name.String)
+	logger.Ctx(ctx).Info().Str("name", simulator.Name.String).Msg("Created simulator")
 	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
 		return 0, err
 	}
@@ -51,13 +53,10 @@ func (repo *sqliteRepository) GetByID(ctx context.Context, id int) (*Simulator,
 		id,
 	)
 	err := row.Scan(&simulator.ID, &simulator.Name, &simulator.Description, &simulator.MinWeight, &simulator.MaxWeight, &simulator.WeightIncrement, &simulator.CreatedAt)
-	if err != nil {
-		return nil, err
-	}
 	if err := dbutil.ProcessRowError(err, ErrSimulatorNotFound); err != nil {
 		return nil, err
 	}
-	return &simulator, err
+	return &simulator, nil
 }
 
 func (repo *sqliteRepository) GetByName(ctx context.Context, name string) (*Simulator, error) {
@@ -67,10 +66,105 @@ func (repo *sqliteRepository) GetByName(ctx context.Context, name string) (*Simu
 		name,
 	)
 	err := row.Scan(&simulator.ID, &simulator.Name, &simulator.Description, &simulator.MinWeight, &simulator.MaxWeight, &simulator.WeightIncrement, &simulator.CreatedAt)
-	dbutil.ProcessRowError(err, ErrSimulatorNotFound)
+	if err := dbutil.ProcessRowError(err, ErrSimulatorNotFound); err != nil {
+		return nil, err
+	}
 	return &simulator, nil
 }
 
+var sortColumns = map[string]string{
+	SortByID:        "id",
+	SortByName:      "name",
+	SortByMinWeight: "min_weight",
+	SortByMaxWeight: "max_weight",
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (repo *sqliteRepository) List(ctx context.Context, filter ListFilter) (*ListPage, error) {
+	column, ok := sortColumns[filter.Sort]
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+
+	var conditions []string
+	var args []any
+
+	if filter.Search != "" {
+		conditions = append(conditions, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+	}
+	if filter.WeightFrom.Valid {
+		conditions = append(conditions, `MIN(min_weight, max_weight) <= ?`)
+		args = append(args, filter.WeightFrom.Float64)
+	}
+	if filter.WeightTo.Valid {
+		conditions = append(conditions, `MAX(min_weight, max_weight) >= ?`)
+		args = append(args, filter.WeightTo.Float64)
+	}
+
+	order, cmp := "ASC", ">"
+	if filter.Desc {
+		order, cmp = "DESC", "<"
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeListCursor(filter.Cursor, filter.Sort, filter.Desc)
+		if err != nil {
+			return nil, err
+		}
+		switch filter.Sort {
+		case SortByID:
+			conditions = append(conditions, fmt.Sprintf("id %s ?", cmp))
+			args = append(args, cursor.ID)
+		case SortByName:
+			conditions = append(conditions, fmt.Sprintf("(name %[1]s ? OR (name = ? AND id %[1]s ?))", cmp))
+			args = append(args, cursor.Name, cursor.Name, cursor.ID)
+		default:
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, cmp))
+			args = append(args, cursor.Weight, cursor.Weight, cursor.ID)
+		}
+	}
+
+	query := `SELECT id, name, description, min_weight, max_weight, weight_increment, created_at FROM simulators`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s", column, order)
+	if column != "id" {
+		query += fmt.Sprintf(", id %s", order)
+	}
+	// Fetch one extra row to find out whether there is a next page.
+	query += " LIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &ListPage{Simulators: make([]*Simulator, 0, filter.Limit)}
+	for rows.Next() {
+		var simulator Simulator
+		if err := rows.Scan(&simulator.ID, &simulator.Name, &simulator.Description, &simulator.MinWeight, &simulator.MaxWeight, &simulator.WeightIncrement, &simulator.CreatedAt); err != nil {
+			return nil, err
+		}
+		page.Simulators = append(page.Simulators, &simulator)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Simulators) > filter.Limit {
+		page.Simulators = page.Simulators[:filter.Limit]
+		page.NextCursor = newListCursor(page.Simulators[filter.Limit-1], filter.Sort, filter.Desc).encode()
+	}
+	return page, nil
+}
+
 func (repo *sqliteRepository) Update(ctx context.Context, simulator *Simulator) error {
 	_, err := repo.db.ExecContext(ctx,
 		`UPDATE simulators SET name = ?, description = ?, min_weight = ?, max_weight = ?, weight_increment = ? WHERE id = ?`,
@@ -87,7 +181,7 @@ func (repo *sqliteRepository) Delete(ctx context.Context, id int) error {
 		`DELETE FROM simulators WHERE id = ?`,
 		id,
 	)
-	if err != nil {
+	if err := dbutil.ProcessDeleteError(err, ErrSimulatorInUse); err != nil {
 		return err
 	}
 	affected, err := result.RowsAffected()
@@ -8,6 +8,7 @@ import (
 	"workup_fitness/domain/simulator"
 	"workup_fitness/internal/testutil"
 
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
 	"github.com/stretchr/testify/require"
 )
@@ -172,3 +173,162 @@ func TestRepository_Delete(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, found)
 }
+
+func TestRepository_Delete_InUse(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &simulator.Simulator{Name: zero.StringFrom("Leg press"), MinWeight: 20, MaxWeight: 300, WeightIncrement: 10})
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO exercises (name, simulator) VALUES ('Leg press', ?)`, id)
+	require.NoError(t, err)
+
+	err = repo.Delete(ctx, id)
+	require.ErrorIs(t, err, simulator.ErrSimulatorInUse)
+}
+
+func seedSimulators(t *testing.T, repo simulator.Repository, ctx context.Context) {
+	t.Helper()
+
+	for _, s := range []simulator.Simulator{
+		{Name: zero.StringFrom("Leg press"), MinWeight: 20, MaxWeight: 300, WeightIncrement: 10},
+		{Name: zero.StringFrom("Chest press"), MinWeight: 5, MaxWeight: 100, WeightIncrement: 5},
+		{Name: zero.StringFrom("Leg curl"), MinWeight: 5, MaxWeight: 75, WeightIncrement: 5},
+		{Name: zero.StringFrom("Lat pulldown"), MinWeight: 10, MaxWeight: 120, WeightIncrement: 10},
+		{Name: zero.StringFrom("Cable 100%_row"), MinWeight: 2.5, MaxWeight: 50, WeightIncrement: 2.5},
+	} {
+		_, err := repo.Create(ctx, &s)
+		require.NoError(t, err)
+	}
+}
+
+func simulatorNames(page *simulator.ListPage) []string {
+	names := make([]string, 0, len(page.Simulators))
+	for _, s := range page.Simulators {
+		names = append(names, s.Name.String)
+	}
+	return names
+}
+
+func TestRepository_List_CursorPagination(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	seedSimulators(t, repo, ctx)
+
+	filter := simulator.ListFilter{Sort: simulator.SortByID, Limit: 2}
+
+	page, err := repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Leg press", "Chest press"}, simulatorNames(page))
+	require.NotEmpty(t, page.NextCursor)
+
+	filter.Cursor = page.NextCursor
+	page, err = repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Leg curl", "Lat pulldown"}, simulatorNames(page))
+
+	filter.Cursor = page.NextCursor
+	page, err = repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Cable 100%_row"}, simulatorNames(page))
+	require.Empty(t, page.NextCursor)
+}
+
+func TestRepository_List_SortByNameDesc(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	seedSimulators(t, repo, ctx)
+
+	filter := simulator.ListFilter{Sort: simulator.SortByName, Desc: true, Limit: 3}
+
+	page, err := repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Leg press", "Leg curl", "Lat pulldown"}, simulatorNames(page))
+
+	filter.Cursor = page.NextCursor
+	page, err = repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Chest press", "Cable 100%_row"}, simulatorNames(page))
+}
+
+func TestRepository_List_SortByWeightWithTies(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	seedSimulators(t, repo, ctx)
+
+	filter := simulator.ListFilter{Sort: simulator.SortByMinWeight, Limit: 2}
+
+	page, err := repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Cable 100%_row", "Chest press"}, simulatorNames(page))
+
+	filter.Cursor = page.NextCursor
+	page, err = repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Leg curl", "Lat pulldown"}, simulatorNames(page))
+}
+
+func TestRepository_List_Search(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	seedSimulators(t, repo, ctx)
+
+	page, err := repo.List(ctx, simulator.ListFilter{Search: "leg", Sort: simulator.SortByName, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Leg curl", "Leg press"}, simulatorNames(page))
+
+	page, err = repo.List(ctx, simulator.ListFilter{Search: "%_", Sort: simulator.SortByID, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Cable 100%_row"}, simulatorNames(page))
+}
+
+func TestRepository_List_CoversWeight(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	seedSimulators(t, repo, ctx)
+
+	page, err := repo.List(ctx, simulator.ListFilter{
+		WeightFrom: null.FloatFrom(80),
+		WeightTo:   null.FloatFrom(80),
+		Sort:       simulator.SortByID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Leg press", "Chest press", "Lat pulldown"}, simulatorNames(page))
+
+	page, err = repo.List(ctx, simulator.ListFilter{
+		WeightFrom: null.FloatFrom(5),
+		WeightTo:   null.FloatFrom(110),
+		Sort:       simulator.SortByID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, page.Simulators)
+}
+
+func TestRepository_List_InvalidCursor(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	_, err := repo.List(ctx, simulator.ListFilter{Sort: simulator.SortByID, Cursor: "not a cursor", Limit: 10})
+	require.ErrorIs(t, err, simulator.ErrInvalidCursor)
+}
+
+func TestRepository_List_CursorFromOtherOrdering(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+	seedSimulators(t, repo, ctx)
+
+	filter := simulator.ListFilter{Sort: simulator.SortByName, Limit: 2}
+	page, err := repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+
+	for _, other := range []simulator.ListFilter{
+		{Sort: simulator.SortByMinWeight, Cursor: page.NextCursor, Limit: 2},
+		{Sort: simulator.SortByName, Desc: true, Cursor: page.NextCursor, Limit: 2},
+	} {
+		_, err := repo.List(ctx, other)
+		require.ErrorIs(t, err, simulator.ErrInvalidCursor)
+	}
+}
@@ -1,16 +1,21 @@
 package simulator
 
 import (
-	"workup_fitness/config"
+	"net/http"
+
+	"workup_fitness/domain/user"
 	"workup_fitness/middleware"
 
 	"github.com/go-chi/chi/v5"
 )
 
-func RegisterRoutes(r chi.Router, h *Handler) {
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Get("/simulators", h.List)
 	r.Get("/simulators/{id}", h.GetByID)
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.Auth(config.JwtSecret))
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("simulators"))
+		r.Use(middleware.RequireRole(string(user.RoleAdmin)))
 		r.Post("/simulators", h.Create)
 		r.Put("/simulators/{id}", h.Update)
 		r.Delete("/simulators/{id}", h.Delete)
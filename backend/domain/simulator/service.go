@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"math"
 
+	"workup_fitness/pkg/logger"
+
 	"github.com/guregu/null/v6/zero"
-	"github.com/rs/zerolog/log"
 )
 
 //go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/simulator Service
@@ -15,6 +16,7 @@ type Service interface {
 	Create(ctx context.Context, name, description string, minWeight, maxWeight, weightIncrement float64) (*Simulator, error)
 	GetByID(ctx context.Context, id int) (*Simulator, error)
 	GetByName(ctx context.Context, name string) (*Simulator, error)
+	List(ctx context.Context, filter ListFilter) (*ListPage, error)
 	Update(ctx context.Context, simulator *Simulator) error
 	Delete(ctx context.Context, id int) error
 }
@@ -24,9 +26,9 @@ type serviceImpl struct {
 }
 
 func NewService(repo Repository) *serviceImpl {
-	log.Info().Msg("Creating simulator service...")
+	logger.Default().Debug().Msg("Creating simulator service...")
 	res := &serviceImpl{repo: repo}
-	log.Info().Msg("Created simulator service")
+	logger.Default().Debug().Msg("Created simulator service")
 	return res
 }
 
@@ -40,19 +42,19 @@ func weightCheck(minWeight, maxWeight, weightIncrement float64) error {
 	}
 
 	if minWeight == maxWeight {
-		return fmt.Errorf("minWeight (%f) and maxWeight (%f) cannot be equal", minWeight, maxWeight)
+		return fmt.Errorf("%w: minWeight (%f) and maxWeight (%f) cannot be equal", ErrWrongRange, minWeight, maxWeight)
 	}
 
 	if math.Abs(maxWeight-minWeight) < weightIncrement {
-		return fmt.Errorf("maxWeight (%f) and minWeight (%f) are too close, increment (%f) is too small", maxWeight, minWeight, weightIncrement)
+		return fmt.Errorf("%w: maxWeight (%f) and minWeight (%f) are too close, increment (%f) is too small", ErrWrongRange, maxWeight, minWeight, weightIncrement)
 	}
 
 	if minWeight < maxWeight && weightIncrement < 0 {
-		return fmt.Errorf("weightIncrement cannot be negative when minWeight (%f) is less than maxWeight (%f)", minWeight, maxWeight)
+		return fmt.Errorf("%w: weightIncrement cannot be negative when minWeight (%f) is less than maxWeight (%f)", ErrWrongRange, minWeight, maxWeight)
 	}
 
 	if minWeight > maxWeight && weightIncrement > 0 {
-		return fmt.Errorf("weightIncrement cannot be positive when minWeight (%f) is greater than maxWeight (%f)", minWeight, maxWeight)
+		return fmt.Errorf("%w: weightIncrement cannot be positive when minWeight (%f) is greater than maxWeight (%f)", ErrWrongRange, minWeight, maxWeight)
 	}
 	return nil
 }
@@ -63,7 +65,7 @@ func (s *serviceImpl) Create(ctx context.Context, name, description string, minW
 		return nil, err
 	}
 
-	log.Info().Msgf("Creating simulator with name %s", name)
+	logger.Ctx(ctx).Info().Str("name", name).Msg("Creating simulator")
 
 	newName := zero.StringFromPtr(&name)
 
@@ -73,37 +75,71 @@ func (s *serviceImpl) Create(ctx context.Context, name, description string, minW
 		return nil, err
 	}
 	simulator.ID = createdID
-	log.Info().Msgf("Created simulator with name %s", simulator.Name.String)
+	logger.Ctx(ctx).Info().Str("name", simulator.Name.String).Msg("Created simulator")
 	return simulator, nil
 }
 
 func (s *serviceImpl) GetByID(ctx context.Context, id int) (*Simulator, error) {
-	log.Info().Msgf("Getting simulator by id %d", id)
+	logger.Ctx(ctx).Info().Int("simulator_id", id).Msg("Getting simulator")
 	res, err := s.repo.GetByID(ctx, id)
-	log.Info().Msgf("Got simulator by id %d", id)
+	logger.Ctx(ctx).Info().Int("simulator_id", id).Msg("Got simulator")
 	return res, err
 }
 
 func (s *serviceImpl) GetByName(ctx context.Context, name string) (*Simulator, error) {
-	log.Info().Msgf("Getting simulator by name %s", name)
+	logger.Ctx(ctx).Info().Str("name", name).Msg("Getting simulator")
 	res, err := s.repo.GetByName(ctx, name)
-	log.Info().Msgf("Got simulator by name %s", name)
+	logger.Ctx(ctx).Info().Str("name", name).Msg("Got simulator")
 	return res, err
 }
 
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+func (s *serviceImpl) List(ctx context.Context, filter ListFilter) (*ListPage, error) {
+	logger.Ctx(ctx).Info().Str("search", filter.Search).Msg("Listing simulators")
+
+	if filter.Sort == "" {
+		filter.Sort = SortByID
+	}
+	if _, ok := sortColumns[filter.Sort]; !ok {
+		return nil, ErrInvalidSort
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit < 0 || filter.Limit > MaxListLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	if filter.WeightFrom.Valid && filter.WeightTo.Valid && filter.WeightFrom.Float64 > filter.WeightTo.Float64 {
+		return nil, ErrWrongRange
+	}
+
+	page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	logger.Ctx(ctx).Info().Int("count", len(page.Simulators)).Msg("Listed simulators")
+	return page, nil
+}
+
 func (s *serviceImpl) Update(ctx context.Context, simulator *Simulator) error {
-	log.Info().Msgf("Updating simulator with id %d", simulator.ID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulator.ID).Msg("Updating simulator")
 	if err := weightCheck(simulator.MinWeight, simulator.MaxWeight, simulator.WeightIncrement); err != nil {
 		return err
 	}
 	err := s.repo.Update(ctx, simulator)
-	log.Info().Msgf("Updated simulator with id %d", simulator.ID)
+	logger.Ctx(ctx).Info().Int("simulator_id", simulator.ID).Msg("Updated simulator")
 	return err
 }
 
 func (s *serviceImpl) Delete(ctx context.Context, id int) error {
-	log.Info().Msgf("Deleting simulator with id %d", id)
+	logger.Ctx(ctx).Info().Int("simulator_id", id).Msg("Deleting simulator")
 	err := s.repo.Delete(ctx, id)
-	log.Info().Msgf("Deleted simulator with id %d", id)
+	logger.Ctx(ctx).Info().Int("simulator_id", id).Msg("Deleted simulator")
 	return err
 }
@@ -7,6 +7,7 @@ import (
 	"workup_fitness/domain/simulator"
 	"workup_fitness/domain/simulator/mocks"
 
+	"github.com/guregu/null/v6"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -49,3 +50,37 @@ func TestService_Create_Failure(t *testing.T) {
 	_, err := svc.Create(ctx, "Leg extension", "Some description", 0, 100, 10)
 	require.Error(t, err)
 }
+
+func TestService_List_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	svc := simulator.NewService(repo)
+	ctx := context.Background()
+
+	repo.EXPECT().
+		List(ctx, simulator.ListFilter{Sort: simulator.SortByID, Limit: simulator.DefaultListLimit}).
+		Return(&simulator.ListPage{}, nil)
+
+	_, err := svc.List(ctx, simulator.ListFilter{})
+	require.NoError(t, err)
+}
+
+func TestService_List_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	svc := simulator.NewService(repo)
+	ctx := context.Background()
+
+	_, err := svc.List(ctx, simulator.ListFilter{Sort: "created_at"})
+	require.ErrorIs(t, err, simulator.ErrInvalidSort)
+
+	_, err = svc.List(ctx, simulator.ListFilter{Limit: simulator.MaxListLimit + 1})
+	require.ErrorIs(t, err, simulator.ErrInvalidLimit)
+
+	_, err = svc.List(ctx, simulator.ListFilter{WeightFrom: null.FloatFrom(100), WeightTo: null.FloatFrom(50)})
+	require.ErrorIs(t, err, simulator.ErrWrongRange)
+}
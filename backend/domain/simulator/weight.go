@@ -0,0 +1,31 @@
+package simulator
+
+import "math"
+
+// weightEpsilon absorbs float noise when comparing weights against stack steps.
+const weightEpsilon = 1e-6
+
+// stackSteps returns the number of increments between MinWeight and MaxWeight.
+func (s *Simulator) stackSteps() float64 {
+	return math.Floor((s.MaxWeight-s.MinWeight)/s.WeightIncrement + weightEpsilon)
+}
+
+// NearestWeight returns the weight on the simulator's stack closest to weight,
+// clamped to the MinWeight..MaxWeight range.
+func (s *Simulator) NearestWeight(weight float64) float64 {
+	if s.WeightIncrement == 0 {
+		return s.MinWeight
+	}
+
+	step := math.Round((weight - s.MinWeight) / s.WeightIncrement)
+	step = math.Max(0, math.Min(step, s.stackSteps()))
+
+	nearest := s.MinWeight + step*s.WeightIncrement
+	// Drop float noise such as 42.50000000001 from the increment multiplication.
+	return math.Round(nearest/weightEpsilon) * weightEpsilon
+}
+
+// IsReachable reports whether weight can be selected on the simulator's stack.
+func (s *Simulator) IsReachable(weight float64) bool {
+	return math.Abs(s.NearestWeight(weight)-weight) < weightEpsilon
+}
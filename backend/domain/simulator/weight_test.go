@@ -0,0 +1,45 @@
+package simulator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/domain/simulator"
+)
+
+func TestSimulator_NearestWeight(t *testing.T) {
+	s := &simulator.Simulator{MinWeight: 5, MaxWeight: 100, WeightIncrement: 2.5}
+
+	require.Equal(t, 5.0, s.NearestWeight(0))
+	require.Equal(t, 42.5, s.NearestWeight(42.5))
+	require.Equal(t, 42.5, s.NearestWeight(43.6))
+	require.Equal(t, 45.0, s.NearestWeight(44))
+	require.Equal(t, 100.0, s.NearestWeight(180))
+}
+
+func TestSimulator_NearestWeight_UnevenTop(t *testing.T) {
+	s := &simulator.Simulator{MinWeight: 10, MaxWeight: 95, WeightIncrement: 10}
+
+	require.Equal(t, 90.0, s.NearestWeight(95))
+	require.False(t, s.IsReachable(95))
+}
+
+func TestSimulator_NearestWeight_DescendingStack(t *testing.T) {
+	s := &simulator.Simulator{MinWeight: 50, MaxWeight: 10, WeightIncrement: -5}
+
+	require.Equal(t, 35.0, s.NearestWeight(36))
+	require.Equal(t, 50.0, s.NearestWeight(70))
+	require.Equal(t, 10.0, s.NearestWeight(0))
+}
+
+func TestSimulator_IsReachable(t *testing.T) {
+	s := &simulator.Simulator{MinWeight: 0.5, MaxWeight: 30, WeightIncrement: 0.1}
+
+	require.True(t, s.IsReachable(0.5))
+	require.True(t, s.IsReachable(12.3))
+	require.True(t, s.IsReachable(30))
+	require.False(t, s.IsReachable(12.35))
+	require.False(t, s.IsReachable(0.4))
+	require.False(t, s.IsReachable(31))
+}
@@ -1,21 +1,49 @@
 package user
 
-import "github.com/guregu/null/v6/zero"
+import (
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+)
 
+// GetPublicProfileResponse is what anyone can see about a user. Body
+// metrics, birth date and contact details stay private.
 type GetPublicProfileResponse struct {
-	ID        int         `json:"id"`
-	Username  zero.String `json:"username"`
-	CreatedAt string      `json:"created_at"`
+	ID              int             `json:"id"`
+	Username        zero.String     `json:"username"`
+	DisplayName     zero.String     `json:"display_name"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty"`
+	CreatedAt       string          `json:"created_at"`
 }
 
 type GetPrivateProfileResponse struct {
-	ID        int         `json:"id"`
-	Username  zero.String `json:"username"`
-	CreatedAt string      `json:"created_at"`
-	// TODO: Add private info fields
+	ID              int             `json:"id"`
+	Username        zero.String     `json:"username"`
+	Email           zero.String     `json:"email"`
+	Role            Role            `json:"role"`
+	CreatedAt       string          `json:"created_at"`
+	DisplayName     zero.String     `json:"display_name"`
+	BirthDate       zero.String     `json:"birth_date"`
+	Sex             Sex             `json:"sex,omitempty"`
+	HeightCm        null.Float      `json:"height_cm"`
+	WeightUnit      WeightUnit      `json:"weight_unit"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty"`
+	TimeZone        zero.String     `json:"time_zone"`
 }
 
+// UpdateRequest changes the fields that are set and keeps the others.
 type UpdateRequest struct {
-	Username zero.String `json:"username"`
-	Password zero.String `json:"password"`
+	Username    zero.String `json:"username"`
+	Email       zero.String `json:"email"`
+	DisplayName zero.String `json:"display_name"`
+	// BirthDate is formatted as 2006-01-02.
+	BirthDate       zero.String     `json:"birth_date"`
+	Sex             Sex             `json:"sex"`
+	HeightCm        null.Float      `json:"height_cm"`
+	WeightUnit      WeightUnit      `json:"weight_unit"`
+	ExperienceLevel ExperienceLevel `json:"experience_level"`
+	TimeZone        zero.String     `json:"time_zone"`
+}
+
+type UpdateRoleRequest struct {
+	Role Role `json:"role"`
 }
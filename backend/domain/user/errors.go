@@ -1,10 +1,32 @@
 package user
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
 
 var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrAlreadyExists      = errors.New("user already exists")
 	ErrMissingField       = errors.New("missing field")
 	ErrInvalidPermissions = errors.New("invalid permissions")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrInvalidProfile     = errors.New("invalid profile")
+	// ErrEmailNeedsVerification is returned when a profile update tries
+	// to set the email, which has to go through POST /users/email.
+	ErrEmailNeedsVerification = errors.New("email changes need the current password and a confirmed address")
 )
+
+func init() {
+	httpx.RegisterError(ErrUserNotFound, http.StatusNotFound, "user_not_found")
+	httpx.RegisterError(ErrAlreadyExists, http.StatusConflict, "user_already_exists")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPermissions, http.StatusForbidden, "forbidden")
+	httpx.RegisterError(ErrInvalidRole, http.StatusBadRequest, "invalid_role")
+	httpx.RegisterError(ErrInvalidEmail, http.StatusUnprocessableEntity, "invalid_email")
+	httpx.RegisterError(ErrInvalidProfile, http.StatusUnprocessableEntity, "invalid_profile")
+	httpx.RegisterError(ErrEmailNeedsVerification, http.StatusUnprocessableEntity, "email_needs_verification")
+}
@@ -10,11 +10,11 @@ import (
 
 	"workup_fitness/middleware"
 	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type Handler struct {
@@ -22,9 +22,9 @@ type Handler struct {
 }
 
 func NewHandler(service Service) *Handler {
-	log.Info().Msg("Creating user handler...")
+	logger.Default().Debug().Msg("Creating user handler...")
 	res := &Handler{service: service}
-	log.Info().Msg("Created user handler")
+	logger.Default().Debug().Msg("Created user handler")
 	return res
 }
 
@@ -37,6 +37,26 @@ func getContextUserID(ctx context.Context) (int, error) {
 	return userID, nil
 }
 
+func toPrivateProfileResponse(user *User) GetPrivateProfileResponse {
+	resp := GetPrivateProfileResponse{
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		Role:            user.Role,
+		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
+		DisplayName:     user.DisplayName,
+		Sex:             user.Sex,
+		HeightCm:        user.HeightCm,
+		WeightUnit:      user.WeightUnit,
+		ExperienceLevel: user.ExperienceLevel,
+		TimeZone:        user.TimeZone,
+	}
+	if user.BirthDate.Valid {
+		resp.BirthDate = zero.StringFrom(user.BirthDate.Time.Format(DateFormat))
+	}
+	return resp
+}
+
 func (h *Handler) GetPrivateProfile(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		httpx.MethodNotAllowed(w)
@@ -51,18 +71,15 @@ func (h *Handler) GetPrivateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Getting private profile for user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Getting private profile")
 
 	user, err := h.service.GetByID(ctx, userID)
 	if err != nil {
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
-	var resp GetPrivateProfileResponse
-	resp.ID = user.ID
-	resp.Username = user.Username
-	resp.CreatedAt = user.CreatedAt.Format(time.RFC3339)
+	resp := toPrivateProfileResponse(user)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
@@ -70,7 +87,7 @@ func (h *Handler) GetPrivateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Got private profile for user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Got private profile")
 }
 
 func (h *Handler) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
@@ -87,19 +104,22 @@ func (h *Handler) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Getting public profile for user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Getting public profile")
 
 	user, err := h.service.GetByID(ctx, userID)
 
 	if err != nil {
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
-	var resp GetPublicProfileResponse
-	resp.ID = user.ID
-	resp.Username = user.Username
-	resp.CreatedAt = user.CreatedAt.Format(time.RFC3339)
+	resp := GetPublicProfileResponse{
+		ID:              user.ID,
+		Username:        user.Username,
+		DisplayName:     user.DisplayName,
+		ExperienceLevel: user.ExperienceLevel,
+		CreatedAt:       user.CreatedAt.Format(time.RFC3339),
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
@@ -107,7 +127,7 @@ func (h *Handler) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Got public profile for user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Got public profile")
 }
 
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
@@ -124,7 +144,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Updating user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Updating user")
 
 	var req UpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -132,26 +152,25 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Passwords are changed through POST /users/password in auth.
 	user := &User{
-		ID:           userID,
-		Username:     zero.StringFrom(""),
-		PasswordHash: zero.StringFrom(""),
+		ID:              userID,
+		Username:        req.Username,
+		Email:           req.Email,
+		DisplayName:     req.DisplayName,
+		Sex:             req.Sex,
+		HeightCm:        req.HeightCm,
+		WeightUnit:      req.WeightUnit,
+		ExperienceLevel: req.ExperienceLevel,
+		TimeZone:        req.TimeZone,
 	}
-
-	// TODO: Вынести в ChangePassword в auth, заменить на UpdateUsername
-
-	if req.Password.String != "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password.String), bcrypt.DefaultCost)
+	if req.BirthDate.String != "" {
+		birthDate, err := time.Parse(DateFormat, req.BirthDate.String)
 		if err != nil {
-			httpx.InternalServerError(w, err)
+			httpx.BadRequest(w, "Invalid birth date, use YYYY-MM-DD")
 			return
 		}
-
-		user.PasswordHash = zero.StringFrom(string(hashedPassword))
-	}
-
-	if req.Username.String != "" {
-		user.Username = req.Username
+		user.BirthDate = null.TimeFrom(birthDate)
 	}
 
 	if err := h.service.Update(ctx, user); err != nil {
@@ -160,17 +179,56 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
+		return
+	}
+
+	updated, err := h.service.GetByID(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
 		return
 	}
+	resp := toPrivateProfileResponse(updated)
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(user); err != nil {
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		httpx.InternalServerError(w, err)
 		return
 	}
 
-	log.Info().Msgf("Updated user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Updated user")
+}
+
+func (h *Handler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid user id")
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Str("role", string(req.Role)).Msg("Updating user role")
+
+	if err := h.service.UpdateRole(ctx, userID, req.Role); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Str("role", string(req.Role)).Msg("Updated user role")
 }
 
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -187,15 +245,15 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Info().Msgf("Deleting user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Deleting user")
 
 	err = h.service.Delete(ctx, userID)
 	if err != nil {
-		httpx.InternalServerError(w, err)
+		httpx.Error(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 
-	log.Info().Msgf("Deleted user with id %d", userID)
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Deleted user")
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -96,9 +97,13 @@ func TestGetPublicProfile_Success(t *testing.T) {
 	handler := user.NewHandler(mockService)
 
 	mockUser := &user.User{
-		ID:        1,
-		Username:  zero.StringFrom("publicuser"),
-		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ID:          1,
+		Username:    zero.StringFrom("publicuser"),
+		Email:       zero.StringFrom("public@example.com"),
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		DisplayName: zero.StringFrom("Public User"),
+		BirthDate:   null.TimeFrom(time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)),
+		HeightCm:    null.FloatFrom(180),
 	}
 
 	mockService.EXPECT().
@@ -115,9 +120,14 @@ func TestGetPublicProfile_Success(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, rr.Code)
 
+	require.NotContains(t, rr.Body.String(), "birth_date")
+	require.NotContains(t, rr.Body.String(), "height_cm")
+	require.NotContains(t, rr.Body.String(), "email")
+
 	var resp user.GetPublicProfileResponse
 	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
 	require.Equal(t, mockUser.Username, resp.Username)
+	require.Equal(t, mockUser.DisplayName, resp.DisplayName)
 }
 
 func TestGetPublicProfile_InvalidID(t *testing.T) {
@@ -169,13 +179,15 @@ func TestUpdate_Success(t *testing.T) {
 
 	reqBody := user.UpdateRequest{
 		Username: zero.StringFrom("newusername"),
-		Password: zero.StringFrom("newpassword"),
 	}
 	body, _ := json.Marshal(reqBody)
 
 	mockService.EXPECT().
 		Update(gomock.Any(), gomock.Any()).
 		Return(nil)
+	mockService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1, Username: zero.StringFrom("newusername")}, nil)
 
 	req := httptest.NewRequest(http.MethodPut, "/profile/update", bytes.NewReader(body))
 	ctx := context.WithValue(req.Context(), middleware.UserIDKey, 1)
@@ -185,6 +197,49 @@ func TestUpdate_Success(t *testing.T) {
 	handler.Update(rr, req)
 
 	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp user.GetPrivateProfileResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, "newusername", resp.Username.String)
+}
+
+func TestUpdate_Profile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := user.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Update(gomock.Any(), &user.User{
+			ID:         1,
+			BirthDate:  null.TimeFrom(time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)),
+			HeightCm:   null.FloatFrom(180),
+			WeightUnit: user.WeightUnitLb,
+			TimeZone:   zero.StringFrom("Europe/Berlin"),
+		}).
+		Return(nil)
+	mockService.EXPECT().
+		GetByID(gomock.Any(), 1).
+		Return(&user.User{ID: 1, BirthDate: null.TimeFrom(time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC))}, nil)
+
+	body := `{"birth_date": "1990-05-01", "height_cm": 180, "weight_unit": "lb", "time_zone": "Europe/Berlin"}`
+	req := httptest.NewRequest(http.MethodPut, "/profile/update", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, 1))
+	rr := httptest.NewRecorder()
+
+	handler.Update(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), `"birth_date":"1990-05-01"`)
+
+	req = httptest.NewRequest(http.MethodPut, "/profile/update", strings.NewReader(`{"birth_date": "01.05.1990"}`))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, 1))
+	rr = httptest.NewRecorder()
+
+	handler.Update(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
 func TestUpdate_ServiceError(t *testing.T) {
@@ -249,3 +304,47 @@ func TestDelete_ServiceError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestUpdateRole_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := user.NewHandler(mockService)
+
+	mockService.EXPECT().
+		UpdateRole(gomock.Any(), 2, user.RoleTrainer).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/users/2/role", strings.NewReader(`{"role":"trainer"}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "2")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.UpdateRole(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestUpdateRole_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := user.NewHandler(mockService)
+
+	mockService.EXPECT().
+		UpdateRole(gomock.Any(), 2, user.Role("owner")).
+		Return(user.ErrInvalidRole)
+
+	req := httptest.NewRequest(http.MethodPut, "/users/2/role", strings.NewReader(`{"role":"owner"}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "2")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.UpdateRole(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
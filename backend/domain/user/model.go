@@ -3,12 +3,40 @@ package user
 import (
 	"time"
 
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
 )
 
+type Role string
+
+const (
+	RoleMember  Role = "member"
+	RoleTrainer Role = "trainer"
+	RoleAdmin   Role = "admin"
+)
+
+func (r Role) Valid() bool {
+	switch r {
+	case RoleMember, RoleTrainer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 type User struct {
 	ID           int         `json:"id"`
 	Username     zero.String `json:"username"`
 	PasswordHash zero.String `json:"-"`
+	Email        zero.String `json:"email"`
+	Role         Role        `json:"role"`
 	CreatedAt    time.Time   `json:"created_at"`
+
+	DisplayName     zero.String     `json:"display_name"`
+	BirthDate       null.Time       `json:"birth_date"`
+	Sex             Sex             `json:"sex"`
+	HeightCm        null.Float      `json:"height_cm"`
+	WeightUnit      WeightUnit      `json:"weight_unit"`
+	ExperienceLevel ExperienceLevel `json:"experience_level"`
+	// TimeZone is an IANA zone name such as Europe/Berlin.
+	TimeZone zero.String `json:"time_zone"`
 }
@@ -0,0 +1,126 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Sex is used for sex-specific strength standards. The empty value means
+// the user did not say.
+type Sex string
+
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+)
+
+func (s Sex) Valid() bool {
+	switch s {
+	case SexMale, SexFemale:
+		return true
+	}
+	return false
+}
+
+// WeightUnit is the unit the user prefers to see weights in. Weights are
+// always stored in kilograms.
+type WeightUnit string
+
+const (
+	WeightUnitKg WeightUnit = "kg"
+	WeightUnitLb WeightUnit = "lb"
+)
+
+func (u WeightUnit) Valid() bool {
+	switch u {
+	case WeightUnitKg, WeightUnitLb:
+		return true
+	}
+	return false
+}
+
+type ExperienceLevel string
+
+const (
+	ExperienceBeginner     ExperienceLevel = "beginner"
+	ExperienceIntermediate ExperienceLevel = "intermediate"
+	ExperienceAdvanced     ExperienceLevel = "advanced"
+)
+
+func (l ExperienceLevel) Valid() bool {
+	switch l {
+	case ExperienceBeginner, ExperienceIntermediate, ExperienceAdvanced:
+		return true
+	}
+	return false
+}
+
+const (
+	// DateFormat is how birth dates are written in requests and responses.
+	DateFormat = "2006-01-02"
+
+	maxDisplayNameLength = 64
+	maxAge               = 120
+	minHeightCm          = 50
+	maxHeightCm          = 280
+)
+
+// Location returns the time zone of the user, UTC if none is set.
+func (u *User) Location() *time.Location {
+	if u.TimeZone.String == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.TimeZone.String)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (u *User) hasProfileChanges() bool {
+	return u.DisplayName.String != "" || u.BirthDate.Valid || u.Sex != "" || u.HeightCm.Valid ||
+		u.WeightUnit != "" || u.ExperienceLevel != "" || u.TimeZone.String != ""
+}
+
+// validateProfile checks the profile fields set on u and normalizes them in
+// place. Unset fields are left alone.
+func validateProfile(u *User, now time.Time) error {
+	if u.DisplayName.String != "" {
+		name := strings.TrimSpace(u.DisplayName.String)
+		if name == "" || utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return fmt.Errorf("%w: display name must be 1 to %d characters", ErrInvalidProfile, maxDisplayNameLength)
+		}
+		u.DisplayName.String = name
+	}
+	if u.BirthDate.Valid {
+		y, m, d := u.BirthDate.Time.Date()
+		birthDate := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		if !birthDate.Before(now) || birthDate.Before(now.AddDate(-maxAge, 0, 0)) {
+			return fmt.Errorf("%w: birth date %s is out of range", ErrInvalidProfile, birthDate.Format(DateFormat))
+		}
+		u.BirthDate.Time = birthDate
+	}
+	if u.Sex != "" && !u.Sex.Valid() {
+		return fmt.Errorf("%w: unknown sex %q", ErrInvalidProfile, u.Sex)
+	}
+	if u.HeightCm.Valid && (u.HeightCm.Float64 < minHeightCm || u.HeightCm.Float64 > maxHeightCm) {
+		return fmt.Errorf("%w: height must be between %d and %d cm", ErrInvalidProfile, minHeightCm, maxHeightCm)
+	}
+	if u.WeightUnit != "" && !u.WeightUnit.Valid() {
+		return fmt.Errorf("%w: unknown weight unit %q", ErrInvalidProfile, u.WeightUnit)
+	}
+	if u.ExperienceLevel != "" && !u.ExperienceLevel.Valid() {
+		return fmt.Errorf("%w: unknown experience level %q", ErrInvalidProfile, u.ExperienceLevel)
+	}
+	if u.TimeZone.String != "" {
+		// Local depends on the server and is not a real zone name.
+		loc, err := time.LoadLocation(u.TimeZone.String)
+		if err != nil || u.TimeZone.String == "Local" {
+			return fmt.Errorf("%w: unknown time zone %q", ErrInvalidProfile, u.TimeZone.String)
+		}
+		u.TimeZone.String = loc.String()
+	}
+	return nil
+}
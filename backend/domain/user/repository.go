@@ -12,7 +12,10 @@ type Repository interface {
 	Create(ctx context.Context, user *User) (int, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
 	GetByID(ctx context.Context, id int) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
+	UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error
+	UpdateRole(ctx context.Context, id int, role Role) error
 	Delete(ctx context.Context, id int) error
 }
 
@@ -24,10 +27,23 @@ func NewSQLiteRepository(db *sql.DB) Repository {
 	return &sqliteRepository{db: db}
 }
 
+const selectUser = `SELECT id, username, password_hash, email, role, created_at,
+	display_name, birth_date, sex, height_cm, weight_unit, experience_level, time_zone FROM users`
+
+func scanUser(row *sql.Row) (*User, error) {
+	var user User
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Email, &user.Role, &user.CreatedAt,
+		&user.DisplayName, &user.BirthDate, &user.Sex, &user.HeightCm, &user.WeightUnit, &user.ExperienceLevel, &user.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (repo *sqliteRepository) Create(ctx context.Context, user *User) (int, error) {
 	res, err := repo.db.ExecContext(ctx,
-		`INSERT INTO users (username, password_hash) VALUES (?, ?)`,
-		user.Username, user.PasswordHash,
+		`INSERT INTO users (username, password_hash, email, role) VALUES (?, ?, ?, COALESCE(NULLIF(?, ''), 'member'))`,
+		user.Username, user.PasswordHash, user.Email, user.Role,
 	)
 	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
 		return 0, err
@@ -41,35 +57,49 @@ func (repo *sqliteRepository) Create(ctx context.Context, user *User) (int, erro
 }
 
 func (repo *sqliteRepository) GetByID(ctx context.Context, id int) (*User, error) {
-	var user User
-	row := repo.db.QueryRowContext(ctx,
-		`SELECT id, username, password_hash, created_at FROM users WHERE id = ?`,
-		id,
-	)
-	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.CreatedAt)
+	user, err := scanUser(repo.db.QueryRowContext(ctx, selectUser+` WHERE id = ?`, id))
 	if err := dbutil.ProcessRowError(err, ErrUserNotFound); err != nil {
 		return nil, err
 	}
-	return &user, nil
+	return user, nil
 }
 
 func (repo *sqliteRepository) GetByUsername(ctx context.Context, username string) (*User, error) {
-	var user User
-	row := repo.db.QueryRowContext(ctx,
-		`SELECT id, username, password_hash, created_at FROM users WHERE username = ?`,
-		username,
-	)
-	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.CreatedAt)
+	user, err := scanUser(repo.db.QueryRowContext(ctx, selectUser+` WHERE username = ?`, username))
 	if err := dbutil.ProcessRowError(err, ErrUserNotFound); err != nil {
 		return nil, err
 	}
-	return &user, nil
+	return user, nil
+}
+
+func (repo *sqliteRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
+	user, err := scanUser(repo.db.QueryRowContext(ctx, selectUser+` WHERE email = ?`, email))
+	if err := dbutil.ProcessRowError(err, ErrUserNotFound); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
+// Update changes the username, email and profile of user. Null and empty
+// fields keep their stored value.
 func (repo *sqliteRepository) Update(ctx context.Context, user *User) error {
+	var birthDate any
+	if user.BirthDate.Valid {
+		birthDate = user.BirthDate.Time.Format(DateFormat)
+	}
 	result, err := repo.db.ExecContext(ctx,
-		`UPDATE users SET username = ?, password_hash = ? WHERE id = ?`,
-		user.Username, user.PasswordHash, user.ID,
+		`UPDATE users SET
+			username = COALESCE(?, username),
+			display_name = COALESCE(?, display_name),
+			birth_date = COALESCE(?, birth_date),
+			sex = COALESCE(NULLIF(?, ''), sex),
+			height_cm = COALESCE(?, height_cm),
+			weight_unit = COALESCE(NULLIF(?, ''), weight_unit),
+			experience_level = COALESCE(NULLIF(?, ''), experience_level),
+			time_zone = COALESCE(?, time_zone)
+		WHERE id = ?`,
+		user.Username, user.DisplayName, birthDate, user.Sex, user.HeightCm,
+		user.WeightUnit, user.ExperienceLevel, user.TimeZone, user.ID,
 	)
 	if err := dbutil.ProcessInsertError(err, ErrAlreadyExists, ErrMissingField); err != nil {
 		return err
@@ -82,6 +112,38 @@ func (repo *sqliteRepository) Update(ctx context.Context, user *User) error {
 	return err
 }
 
+func (repo *sqliteRepository) UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE users SET password_hash = ? WHERE id = ?`,
+		passwordHash, id,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		err = ErrUserNotFound
+	}
+	return err
+}
+
+func (repo *sqliteRepository) UpdateRole(ctx context.Context, id int, role Role) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE users SET role = ? WHERE id = ?`,
+		role, id,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		err = ErrUserNotFound
+	}
+	return err
+}
+
 func (repo *sqliteRepository) Delete(ctx context.Context, id int) error {
 	result, err := repo.db.ExecContext(ctx,
 		`DELETE FROM users WHERE id = ?`,
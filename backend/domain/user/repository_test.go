@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
 	"github.com/stretchr/testify/require"
 
@@ -141,7 +142,98 @@ func TestRepository_Update_Success(t *testing.T) {
 	found, err := repo.GetByID(ctx, id)
 	require.NoError(t, err)
 	require.Equal(t, updatedUser.Username, found.Username)
-	require.Equal(t, updatedUser.PasswordHash, found.PasswordHash)
+	require.Equal(t, newUser.PasswordHash, found.PasswordHash)
+}
+
+func TestRepository_UpdatePasswordHash(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &user.User{
+		Username:     zero.StringFrom("bob"),
+		PasswordHash: zero.StringFrom("hash456"),
+	})
+	require.NoError(t, err)
+
+	err = repo.UpdatePasswordHash(ctx, id, "newhash")
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "bob", found.Username.String)
+	require.Equal(t, "newhash", found.PasswordHash.String)
+
+	err = repo.UpdatePasswordHash(ctx, id+1, "newhash")
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+}
+
+func TestRepository_Update_KeepsNullFields(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &user.User{
+		Username:     zero.StringFrom("bob"),
+		PasswordHash: zero.StringFrom("hash456"),
+	})
+	require.NoError(t, err)
+
+	_, err = db.Exec(`UPDATE users SET email = 'bob@example.com' WHERE id = ?`, id)
+	require.NoError(t, err)
+
+	err = repo.Update(ctx, &user.User{ID: id, DisplayName: zero.StringFrom("Bob")})
+	require.NoError(t, err)
+
+	found, err := repo.GetByEmail(ctx, "bob@example.com")
+	require.NoError(t, err)
+	require.Equal(t, id, found.ID)
+	require.Equal(t, "bob", found.Username.String)
+	require.Equal(t, "Bob", found.DisplayName.String)
+
+	_, err = repo.GetByEmail(ctx, "alice@example.com")
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+}
+
+func TestRepository_Update_Profile(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &user.User{
+		Username:     zero.StringFrom("bob"),
+		PasswordHash: zero.StringFrom("hash456"),
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, user.WeightUnitKg, found.WeightUnit)
+	require.Equal(t, "UTC", found.TimeZone.String)
+	require.False(t, found.BirthDate.Valid)
+
+	birthDate := time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)
+	err = repo.Update(ctx, &user.User{
+		ID:              id,
+		DisplayName:     zero.StringFrom("Bobby"),
+		BirthDate:       null.TimeFrom(birthDate),
+		Sex:             user.SexMale,
+		HeightCm:        null.FloatFrom(182.5),
+		WeightUnit:      user.WeightUnitLb,
+		ExperienceLevel: user.ExperienceIntermediate,
+		TimeZone:        zero.StringFrom("Europe/Berlin"),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Update(ctx, &user.User{ID: id, HeightCm: null.FloatFrom(183)}))
+
+	found, err = repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "bob", found.Username.String)
+	require.Equal(t, "Bobby", found.DisplayName.String)
+	require.True(t, birthDate.Equal(found.BirthDate.Time))
+	require.Equal(t, user.SexMale, found.Sex)
+	require.Equal(t, 183.0, found.HeightCm.Float64)
+	require.Equal(t, user.WeightUnitLb, found.WeightUnit)
+	require.Equal(t, user.ExperienceIntermediate, found.ExperienceLevel)
+	require.Equal(t, "Europe/Berlin", found.TimeZone.String)
 }
 
 func TestRepository_Update_NotFound(t *testing.T) {
@@ -209,6 +301,39 @@ func TestRepository_Delete_Success(t *testing.T) {
 	require.Nil(t, found)
 }
 
+func TestRepository_Delete_CascadesToOwnedRows(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &user.User{Username: zero.StringFrom("bob"), PasswordHash: zero.StringFrom("hash")})
+	require.NoError(t, err)
+
+	for _, query := range []string{
+		`INSERT INTO exercises (name) VALUES ('Squat')`,
+		`INSERT INTO workouts (user_id, scheduled_at) VALUES (1, CURRENT_TIMESTAMP)`,
+		`INSERT INTO workout_exercises (workout_id, exercise_id, weight, sets, repetitions) VALUES (1, 1, 100, 3, 5)`,
+		`INSERT INTO workout_sessions (user_id, workout_id, started_at) VALUES (1, 1, CURRENT_TIMESTAMP)`,
+		`INSERT INTO session_sets (session_id, exercise_id, set_index, weight, reps, performed_at) VALUES (1, 1, 1, 100, 5, CURRENT_TIMESTAMP)`,
+		`INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES (1, 'family', 'hash', CURRENT_TIMESTAMP)`,
+		`INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes) VALUES (1, 'ci', 'wk_abc', 'hash', 'read')`,
+		`INSERT INTO body_metrics (user_id, measured_at, weight_kg) VALUES (1, CURRENT_TIMESTAMP, 80)`,
+	} {
+		_, err := db.Exec(query)
+		require.NoError(t, err, query)
+	}
+
+	require.NoError(t, repo.Delete(ctx, id))
+
+	for _, table := range []string{"workouts", "workout_exercises", "workout_sessions", "session_sets", "refresh_tokens", "api_keys", "body_metrics"} {
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM `+table).Scan(&count))
+		require.Zero(t, count, table)
+	}
+	var exercises int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM exercises`).Scan(&exercises))
+	require.Equal(t, 1, exercises, "shared exercises are kept")
+}
+
 func TestRepository_Delete_NotFound(t *testing.T) {
 	repo, db, ctx := newTestRepository(t)
 	defer db.Close()
@@ -216,3 +341,28 @@ func TestRepository_Delete_NotFound(t *testing.T) {
 	err := repo.Delete(ctx, 1)
 	require.ErrorIs(t, err, user.ErrUserNotFound)
 }
+
+func TestRepository_UpdateRole(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &user.User{
+		Username:     zero.StringFrom("bob"),
+		PasswordHash: zero.StringFrom("hash456"),
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, user.RoleMember, found.Role)
+
+	err = repo.UpdateRole(ctx, id, user.RoleAdmin)
+	require.NoError(t, err)
+
+	found, err = repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, user.RoleAdmin, found.Role)
+
+	err = repo.UpdateRole(ctx, id+1, user.RoleAdmin)
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+}
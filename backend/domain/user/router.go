@@ -1,18 +1,30 @@
 package user
 
 import (
-	"workup_fitness/config"
+	"net/http"
+
 	"workup_fitness/middleware"
 
 	"github.com/go-chi/chi/v5"
 )
 
-func RegisterRoutes(r chi.Router, h *Handler) {
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
 	r.Get("/users/{id}", h.GetPublicProfile)
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.Auth(config.JwtSecret))
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("profile"))
 		r.Get("/me", h.GetPrivateProfile)
 		r.Put("/profile/update", h.Update)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireSession)
 		r.Delete("/profile/delete", h.Delete)
 	})
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireSession)
+		r.Use(middleware.RequireRole(string(RoleAdmin)))
+		r.Put("/users/{id}/role", h.UpdateRole)
+	})
 }
@@ -2,9 +2,15 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"workup_fitness/pkg/logger"
 
 	"github.com/guregu/null/v6/zero"
-	"github.com/rs/zerolog/log"
 )
 
 //go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/user Service
@@ -13,7 +19,10 @@ type Service interface {
 	Create(ctx context.Context, username, passwordHash string) (*User, error)
 	GetByID(ctx context.Context, id int) (*User, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
+	UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error
+	UpdateRole(ctx context.Context, id int, role Role) error
 	Delete(ctx context.Context, id int) error
 }
 
@@ -22,52 +31,108 @@ type serviceImpl struct {
 }
 
 func NewService(repo Repository) *serviceImpl {
-	log.Info().Msg("Creating user service...")
+	logger.Default().Debug().Msg("Creating user service...")
 	res := &serviceImpl{repo: repo}
-	log.Info().Msg("Created user service")
+	logger.Default().Debug().Msg("Created user service")
 	return res
 }
 
 func (s *serviceImpl) Create(ctx context.Context, username, passwordHash string) (*User, error) {
-	log.Info().Msgf("Creating user with username %s", username)
+	logger.Ctx(ctx).Info().Str("username", username).Msg("Creating user")
 
 	newName := zero.StringFromPtr(&username)
 	newPasswordHash := zero.StringFromPtr(&passwordHash)
 
-	user := &User{Username: newName, PasswordHash: newPasswordHash}
+	user := &User{Username: newName, PasswordHash: newPasswordHash, Role: RoleMember}
 	createdID, err := s.repo.Create(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 	user.ID = createdID
-	log.Info().Msgf("Created user with username %s", username)
+	logger.Ctx(ctx).Info().Str("username", username).Msg("Created user")
 	return user, nil
 }
 
 func (s *serviceImpl) GetByID(ctx context.Context, id int) (*User, error) {
-	log.Info().Msgf("Getting user by id %d", id)
+	logger.Ctx(ctx).Info().Int("user_id", id).Msg("Getting user")
 	user, err := s.repo.GetByID(ctx, id)
-	log.Info().Msgf("Got user by id %d", id)
+	logger.Ctx(ctx).Info().Int("user_id", id).Msg("Got user")
 	return user, err
 }
 
 func (s *serviceImpl) GetByUsername(ctx context.Context, username string) (*User, error) {
-	log.Info().Msgf("Getting user by username %s", username)
+	logger.Ctx(ctx).Info().Str("username", username).Msg("Getting user")
 	user, err := s.repo.GetByUsername(ctx, username)
-	log.Info().Msgf("Got user by username %s", username)
+	logger.Ctx(ctx).Info().Str("username", username).Msg("Got user")
 	return user, err
 }
 
+func (s *serviceImpl) GetByEmail(ctx context.Context, email string) (*User, error) {
+	logger.Ctx(ctx).Info().Msg("Getting user by email")
+	user, err := s.repo.GetByEmail(ctx, normalizeEmail(email))
+	logger.Ctx(ctx).Info().Msg("Got user by email")
+	return user, err
+}
+
+// Update changes the username and profile of user, leaving empty fields as
+// they are. The email cannot be changed here: a new address has to be
+// confirmed first, through the auth package.
 func (s *serviceImpl) Update(ctx context.Context, user *User) error {
-	log.Info().Msgf("Updating user with id %d", user.ID)
+	logger.Ctx(ctx).Info().Int("user_id", user.ID).Msg("Updating user")
+	if user.Email.String != "" {
+		return ErrEmailNeedsVerification
+	}
+	if user.Username.String == "" && !user.hasProfileChanges() {
+		return ErrMissingField
+	}
+	if err := validateProfile(user, time.Now()); err != nil {
+		return err
+	}
 	err := s.repo.Update(ctx, user)
-	log.Info().Msgf("Updated user with id %d", user.ID)
+	logger.Ctx(ctx).Info().Int("user_id", user.ID).Msg("Updated user")
+	return err
+}
+
+// UpdatePasswordHash stores an already hashed password. Policy checks and
+// hashing live in the auth package.
+func (s *serviceImpl) UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error {
+	logger.Ctx(ctx).Info().Int("user_id", id).Msg("Updating user password")
+	if passwordHash == "" {
+		return errors.Join(ErrMissingField, errors.New("password hash is required"))
+	}
+	err := s.repo.UpdatePasswordHash(ctx, id, passwordHash)
+	logger.Ctx(ctx).Info().Int("user_id", id).Msg("Updated user password")
+	return err
+}
+
+func (s *serviceImpl) UpdateRole(ctx context.Context, id int, role Role) error {
+	logger.Ctx(ctx).Info().Int("user_id", id).Str("role", string(role)).Msg("Updating user role")
+	if !role.Valid() {
+		return ErrInvalidRole
+	}
+	err := s.repo.UpdateRole(ctx, id, role)
+	logger.Ctx(ctx).Info().Int("user_id", id).Str("role", string(role)).Msg("Updated user role")
 	return err
 }
 
 func (s *serviceImpl) Delete(ctx context.Context, id int) error {
-	log.Info().Msgf("Deleting user with id %d", id)
+	logger.Ctx(ctx).Info().Int("user_id", id).Msg("Deleting user")
 	err := s.repo.Delete(ctx, id)
-	log.Info().Msgf("Deleted user with id %d", id)
+	logger.Ctx(ctx).Info().Int("user_id", id).Msg("Deleted user")
 	return err
 }
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail accepts a bare address such as alice@example.com and returns
+// it normalized.
+func ValidateEmail(email string) (string, error) {
+	email = normalizeEmail(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+	return email, nil
+}
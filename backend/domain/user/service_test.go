@@ -3,9 +3,11 @@ package user_test
 import (
 	"context"
 	"testing"
+	"time"
 	"workup_fitness/domain/user"
 	"workup_fitness/domain/user/mocks"
 
+	"github.com/guregu/null/v6"
 	"github.com/guregu/null/v6/zero"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -101,6 +103,89 @@ func TestService_Update_Success(t *testing.T) {
 	require.Equal(t, updatedUser.ID, updatedUser.ID)
 }
 
+func TestService_UpdatePasswordHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(repo)
+	ctx := context.Background()
+
+	repo.EXPECT().UpdatePasswordHash(ctx, 1, "newhash").Return(nil)
+	require.NoError(t, svc.UpdatePasswordHash(ctx, 1, "newhash"))
+
+	err := svc.UpdatePasswordHash(ctx, 1, "")
+	require.ErrorIs(t, err, user.ErrMissingField)
+}
+
+func TestService_Update_Email(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(repo)
+	ctx := context.Background()
+
+	err := svc.Update(ctx, &user.User{ID: 1, Email: zero.StringFrom("alice@example.com")})
+	require.ErrorIs(t, err, user.ErrEmailNeedsVerification, "the email only changes once the new address is confirmed")
+
+	err = svc.Update(ctx, &user.User{ID: 1})
+	require.ErrorIs(t, err, user.ErrMissingField)
+}
+
+func TestValidateEmail(t *testing.T) {
+	email, err := user.ValidateEmail(" Alice@Example.com ")
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", email)
+
+	_, err = user.ValidateEmail("Alice <alice@example.com>")
+	require.ErrorIs(t, err, user.ErrInvalidEmail)
+}
+
+func TestService_Update_Profile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(repo)
+	ctx := context.Background()
+
+	repo.EXPECT().Update(ctx, &user.User{
+		ID:          1,
+		DisplayName: zero.StringFrom("Alice"),
+		BirthDate:   null.TimeFrom(time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)),
+		Sex:         user.SexFemale,
+		TimeZone:    zero.StringFrom("America/New_York"),
+	}).Return(nil)
+	require.NoError(t, svc.Update(ctx, &user.User{
+		ID:          1,
+		DisplayName: zero.StringFrom("  Alice "),
+		BirthDate:   null.TimeFrom(time.Date(1990, 5, 1, 15, 0, 0, 0, time.UTC)),
+		Sex:         user.SexFemale,
+		TimeZone:    zero.StringFrom("America/New_York"),
+	}))
+
+	for name, invalid := range map[string]*user.User{
+		"blank display name": {ID: 1, DisplayName: zero.StringFrom("   ")},
+		"future birth date":  {ID: 1, BirthDate: null.TimeFrom(time.Now().AddDate(1, 0, 0))},
+		"ancient birth date": {ID: 1, BirthDate: null.TimeFrom(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC))},
+		"unknown sex":        {ID: 1, Sex: "robot"},
+		"too short":          {ID: 1, HeightCm: null.FloatFrom(20)},
+		"too tall":           {ID: 1, HeightCm: null.FloatFrom(400)},
+		"unknown unit":       {ID: 1, WeightUnit: "stone"},
+		"unknown level":      {ID: 1, ExperienceLevel: "elite"},
+		"unknown time zone":  {ID: 1, TimeZone: zero.StringFrom("Mars/Olympus")},
+		"local time zone":    {ID: 1, TimeZone: zero.StringFrom("Local")},
+	} {
+		require.ErrorIs(t, svc.Update(ctx, invalid), user.ErrInvalidProfile, name)
+	}
+}
+
+func TestUser_Location(t *testing.T) {
+	require.Equal(t, time.UTC, (&user.User{}).Location())
+	require.Equal(t, "Asia/Tokyo", (&user.User{TimeZone: zero.StringFrom("Asia/Tokyo")}).Location().String())
+}
+
 func TestService_Delete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -116,3 +201,19 @@ func TestService_Delete(t *testing.T) {
 	err := svc.Delete(ctx, 1)
 	require.NoError(t, err)
 }
+
+func TestService_UpdateRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockRepository(ctrl)
+	svc := user.NewService(repo)
+	ctx := context.Background()
+
+	repo.EXPECT().
+		UpdateRole(ctx, 1, user.RoleTrainer).
+		Return(nil)
+
+	require.NoError(t, svc.UpdateRole(ctx, 1, user.RoleTrainer))
+	require.ErrorIs(t, svc.UpdateRole(ctx, 1, user.Role("owner")), user.ErrInvalidRole)
+}
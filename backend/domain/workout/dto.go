@@ -0,0 +1,50 @@
+package workout
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+type WorkoutExerciseRequest struct {
+	ExerciseID  int     `json:"exercise_id"`
+	Weight      float64 `json:"weight"`
+	Sets        int     `json:"sets"`
+	Repetitions int     `json:"repetitions"`
+}
+
+type CreateRequest struct {
+	ScheduledAt time.Time                `json:"scheduled_at"`
+	Exercises   []WorkoutExerciseRequest `json:"exercises"`
+	SnapWeights bool                     `json:"snap_weights"`
+}
+
+type UpdateRequest struct {
+	ScheduledAt time.Time                `json:"scheduled_at"`
+	Exercises   []WorkoutExerciseRequest `json:"exercises"`
+	SnapWeights bool                     `json:"snap_weights"`
+}
+
+type RescheduleRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+type WorkoutExerciseResponse struct {
+	ID           int        `json:"id"`
+	ExerciseID   int        `json:"exercise_id"`
+	Weight       float64    `json:"weight"`
+	Sets         int        `json:"sets"`
+	Repetitions  int        `json:"repetitions"`
+	AdjustedFrom null.Float `json:"adjusted_from,omitzero"`
+}
+
+type WorkoutResponse struct {
+	ID          int                       `json:"id"`
+	UserID      int                       `json:"user_id"`
+	ScheduledAt string                    `json:"scheduled_at"`
+	Exercises   []WorkoutExerciseResponse `json:"exercises"`
+}
+
+type ListResponse struct {
+	Workouts []WorkoutResponse `json:"workouts"`
+}
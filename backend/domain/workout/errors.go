@@ -0,0 +1,28 @@
+package workout
+
+import (
+	"errors"
+	"net/http"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var (
+	ErrWorkoutNotFound    = errors.New("workout not found")
+	ErrMissingField       = errors.New("missing field")
+	ErrInvalidPermissions = errors.New("invalid permissions")
+	ErrExerciseNotFound   = errors.New("referenced exercise not found")
+	ErrNegativeWeight     = errors.New("weight cannot be negative")
+	ErrInvalidVolume      = errors.New("sets and repetitions must be positive")
+	ErrUnreachableWeight  = errors.New("weight is not reachable on the simulator")
+)
+
+func init() {
+	httpx.RegisterError(ErrWorkoutNotFound, http.StatusNotFound, "workout_not_found")
+	httpx.RegisterError(ErrMissingField, http.StatusBadRequest, "missing_field")
+	httpx.RegisterError(ErrInvalidPermissions, http.StatusForbidden, "forbidden")
+	httpx.RegisterError(ErrExerciseNotFound, http.StatusUnprocessableEntity, "unknown_exercise")
+	httpx.RegisterError(ErrNegativeWeight, http.StatusBadRequest, "negative_weight")
+	httpx.RegisterError(ErrInvalidVolume, http.StatusBadRequest, "invalid_volume")
+	httpx.RegisterError(ErrUnreachableWeight, http.StatusUnprocessableEntity, "unreachable_weight")
+}
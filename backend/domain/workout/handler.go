@@ -0,0 +1,312 @@
+package workout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	logger.Default().Debug().Msg("Creating workout handler...")
+	res := &Handler{service: service}
+	logger.Default().Debug().Msg("Created workout handler")
+	return res
+}
+
+func getContextUserID(ctx context.Context) (int, error) {
+	val := ctx.Value(middleware.UserIDKey)
+	userID, ok := val.(int)
+	if !ok {
+		return 0, errors.New("user id not found")
+	}
+	return userID, nil
+}
+
+func toExercises(entries []WorkoutExerciseRequest) []WorkoutExercise {
+	exercises := make([]WorkoutExercise, 0, len(entries))
+	for _, entry := range entries {
+		exercises = append(exercises, WorkoutExercise{
+			ExerciseID:  entry.ExerciseID,
+			Weight:      entry.Weight,
+			Sets:        entry.Sets,
+			Repetitions: entry.Repetitions,
+		})
+	}
+	return exercises
+}
+
+func toWorkoutResponse(workout *Workout) WorkoutResponse {
+	resp := WorkoutResponse{
+		ID:          workout.ID,
+		UserID:      workout.UserID,
+		ScheduledAt: workout.ScheduledAt.Format(time.RFC3339),
+		Exercises:   make([]WorkoutExerciseResponse, 0, len(workout.Exercises)),
+	}
+	for _, exercise := range workout.Exercises {
+		resp.Exercises = append(resp.Exercises, WorkoutExerciseResponse{
+			ID:           exercise.ID,
+			ExerciseID:   exercise.ExerciseID,
+			Weight:       exercise.Weight,
+			Sets:         exercise.Sets,
+			Repetitions:  exercise.Repetitions,
+			AdjustedFrom: exercise.AdjustedFrom,
+		})
+	}
+	return resp
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating workout")
+
+	workout, err := h.service.Create(ctx, userID, req.ScheduledAt, toExercises(req.Exercises), req.SnapWeights)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toWorkoutResponse(workout)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workout.ID).Msg("Created workout")
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid workout id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Getting workout")
+
+	workout, err := h.service.GetByID(ctx, userID, workoutID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toWorkoutResponse(workout)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Got workout")
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing workouts")
+
+	workouts, err := h.service.List(ctx, userID)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := ListResponse{Workouts: make([]WorkoutResponse, 0, len(workouts))}
+	for _, workout := range workouts {
+		resp.Workouts = append(resp.Workouts, toWorkoutResponse(workout))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("count", len(workouts)).Int("user_id", userID).Msg("Listed workouts")
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid workout id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Updating workout")
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	workout := &Workout{
+		ID:          workoutID,
+		UserID:      userID,
+		ScheduledAt: req.ScheduledAt,
+		Exercises:   toExercises(req.Exercises),
+	}
+
+	if err := h.service.Update(ctx, userID, workout, req.SnapWeights); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toWorkoutResponse(workout)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Updated workout")
+}
+
+func (h *Handler) Reschedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid workout id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Rescheduling workout")
+
+	var req RescheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpx.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	workout, err := h.service.Reschedule(ctx, userID, workoutID, req.ScheduledAt)
+	if err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	resp := toWorkoutResponse(workout)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httpx.InternalServerError(w, err)
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Rescheduled workout")
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpx.MethodNotAllowed(w)
+		return
+	}
+
+	ctx := r.Context()
+
+	userID, err := getContextUserID(ctx)
+	if err != nil {
+		httpx.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	workoutID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.BadRequest(w, "Invalid workout id")
+		return
+	}
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Deleting workout")
+
+	if err := h.service.Delete(ctx, userID, workoutID); err != nil {
+		httpx.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+
+	logger.Ctx(ctx).Info().Int("workout_id", workoutID).Msg("Deleted workout")
+}
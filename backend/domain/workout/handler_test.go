@@ -0,0 +1,328 @@
+package workout_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"workup_fitness/domain/workout"
+	"workup_fitness/domain/workout/mocks"
+	"workup_fitness/middleware"
+)
+
+func newAuthedRequest(method, target string, body []byte, userID int, workoutID string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if workoutID != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", workoutID)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+	scheduledAt := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, scheduledAt, []workout.WorkoutExercise{{ExerciseID: 2, Weight: 60, Sets: 3, Repetitions: 10}}, false).
+		Return(&workout.Workout{
+			ID:          5,
+			UserID:      1,
+			ScheduledAt: scheduledAt,
+			Exercises:   []workout.WorkoutExercise{{ID: 1, WorkoutID: 5, ExerciseID: 2, Weight: 60, Sets: 3, Repetitions: 10}},
+		}, nil)
+
+	body, _ := json.Marshal(workout.CreateRequest{
+		ScheduledAt: scheduledAt,
+		Exercises:   []workout.WorkoutExerciseRequest{{ExerciseID: 2, Weight: 60, Sets: 3, Repetitions: 10}},
+	})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/workouts", body, 1, ""))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp workout.WorkoutResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 5, resp.ID)
+	require.Equal(t, "2025-10-10T18:00:00Z", resp.ScheduledAt)
+	require.Len(t, resp.Exercises, 1)
+}
+
+func TestCreate_Unauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/workouts", bytes.NewReader([]byte("{}")))
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestCreate_InvalidRequestBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/workouts", []byte("invalid json"), 1, ""))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestCreate_ExerciseNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, gomock.Any(), gomock.Any(), false).
+		Return(nil, workout.ErrExerciseNotFound)
+
+	body, _ := json.Marshal(workout.CreateRequest{
+		ScheduledAt: time.Now(),
+		Exercises:   []workout.WorkoutExerciseRequest{{ExerciseID: 42, Weight: 60, Sets: 3, Repetitions: 10}},
+	})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/workouts", body, 1, ""))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestGetByID_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		GetByID(gomock.Any(), 1, 5).
+		Return(nil, workout.ErrInvalidPermissions)
+
+	rr := httptest.NewRecorder()
+
+	handler.GetByID(rr, newAuthedRequest(http.MethodGet, "/workouts/5", nil, 1, "5"))
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		GetByID(gomock.Any(), 1, 5).
+		Return(nil, workout.ErrWorkoutNotFound)
+
+	rr := httptest.NewRecorder()
+
+	handler.GetByID(rr, newAuthedRequest(http.MethodGet, "/workouts/5", nil, 1, "5"))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetByID_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	rr := httptest.NewRecorder()
+
+	handler.GetByID(rr, newAuthedRequest(http.MethodGet, "/workouts/invalid", nil, 1, "invalid"))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestList_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		List(gomock.Any(), 1).
+		Return([]*workout.Workout{
+			{ID: 1, UserID: 1, ScheduledAt: time.Now()},
+			{ID: 2, UserID: 1, ScheduledAt: time.Now()},
+		}, nil)
+
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, newAuthedRequest(http.MethodGet, "/workouts", nil, 1, ""))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp workout.ListResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Len(t, resp.Workouts, 2)
+}
+
+func TestReschedule_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+	rescheduled := time.Date(2025, 10, 12, 7, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		Reschedule(gomock.Any(), 1, 5, rescheduled).
+		Return(&workout.Workout{ID: 5, UserID: 1, ScheduledAt: rescheduled}, nil)
+
+	body, _ := json.Marshal(workout.RescheduleRequest{ScheduledAt: rescheduled})
+	rr := httptest.NewRecorder()
+
+	handler.Reschedule(rr, newAuthedRequest(http.MethodPatch, "/workouts/5/schedule", body, 1, "5"))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp workout.WorkoutResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, "2025-10-12T07:00:00Z", resp.ScheduledAt)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Update(gomock.Any(), 1, gomock.Any(), false).
+		Return(nil)
+
+	body, _ := json.Marshal(workout.UpdateRequest{
+		ScheduledAt: time.Now(),
+		Exercises:   []workout.WorkoutExerciseRequest{{ExerciseID: 2, Weight: 60, Sets: 3, Repetitions: 10}},
+	})
+	rr := httptest.NewRecorder()
+
+	handler.Update(rr, newAuthedRequest(http.MethodPut, "/workouts/5", body, 1, "5"))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDelete_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Delete(gomock.Any(), 1, 5).
+		Return(nil)
+
+	rr := httptest.NewRecorder()
+
+	handler.Delete(rr, newAuthedRequest(http.MethodDelete, "/workouts/5", nil, 1, "5"))
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestDelete_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Delete(gomock.Any(), 1, 5).
+		Return(workout.ErrInvalidPermissions)
+
+	rr := httptest.NewRecorder()
+
+	handler.Delete(rr, newAuthedRequest(http.MethodDelete, "/workouts/5", nil, 1, "5"))
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCreate_SnappedWeightReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, gomock.Any(), gomock.Any(), true).
+		Return(&workout.Workout{
+			ID:          5,
+			UserID:      1,
+			ScheduledAt: time.Now(),
+			Exercises:   []workout.WorkoutExercise{{ID: 1, ExerciseID: 2, Weight: 85, Sets: 3, Repetitions: 10, AdjustedFrom: null.FloatFrom(87)}},
+		}, nil)
+
+	body, _ := json.Marshal(workout.CreateRequest{
+		ScheduledAt: time.Now(),
+		Exercises:   []workout.WorkoutExerciseRequest{{ExerciseID: 2, Weight: 87, Sets: 3, Repetitions: 10}},
+		SnapWeights: true,
+	})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/workouts", body, 1, ""))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp workout.WorkoutResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, 85.0, resp.Exercises[0].Weight)
+	require.Equal(t, 87.0, resp.Exercises[0].AdjustedFrom.Float64)
+}
+
+func TestCreate_UnreachableWeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := workout.NewHandler(mockService)
+
+	mockService.EXPECT().
+		Create(gomock.Any(), 1, gomock.Any(), gomock.Any(), false).
+		Return(nil, workout.ErrUnreachableWeight)
+
+	body, _ := json.Marshal(workout.CreateRequest{
+		ScheduledAt: time.Now(),
+		Exercises:   []workout.WorkoutExerciseRequest{{ExerciseID: 2, Weight: 87, Sets: 3, Repetitions: 10}},
+	})
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, newAuthedRequest(http.MethodPost, "/workouts", body, 1, ""))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
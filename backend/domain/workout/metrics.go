@@ -0,0 +1,13 @@
+package workout
+
+import "workup_fitness/pkg/metrics"
+
+var workoutsLoggedTotal = metrics.Default.NewCounterVec(
+	"workup_workouts_logged_total",
+	"Total number of workouts created.",
+)
+
+var workoutsScheduledTotal = metrics.Default.NewCounterVec(
+	"workup_workouts_scheduled_total",
+	"Total number of workouts scheduled ahead by program instantiation.",
+)
@@ -0,0 +1,26 @@
+package workout
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+type Workout struct {
+	ID          int               `json:"id"`
+	UserID      int               `json:"user_id"`
+	ScheduledAt time.Time         `json:"scheduled_at"`
+	Exercises   []WorkoutExercise `json:"exercises"`
+}
+
+type WorkoutExercise struct {
+	ID          int     `json:"id"`
+	WorkoutID   int     `json:"workout_id"`
+	ExerciseID  int     `json:"exercise_id"`
+	Weight      float64 `json:"weight"`
+	Sets        int     `json:"sets"`
+	Repetitions int     `json:"repetitions"`
+	// AdjustedFrom holds the requested weight when it was snapped to the
+	// simulator's stack. It is not persisted.
+	AdjustedFrom null.Float `json:"adjusted_from"`
+}
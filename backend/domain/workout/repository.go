@@ -0,0 +1,258 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"workup_fitness/internal/dbutil"
+)
+
+//go:generate mockgen -destination=mocks/mock_repository.go -package=mocks workup_fitness/domain/workout Repository
+
+type Repository interface {
+	Create(ctx context.Context, workout *Workout) (int, error)
+	CreateBatch(ctx context.Context, workouts []*Workout) error
+	GetByID(ctx context.Context, id int) (*Workout, error)
+	ListByUserID(ctx context.Context, userID int) ([]*Workout, error)
+	Update(ctx context.Context, workout *Workout) error
+	UpdateSchedule(ctx context.Context, id int, scheduledAt time.Time) error
+	Delete(ctx context.Context, id int) error
+}
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{db: db}
+}
+
+func insertExercises(ctx context.Context, tx *sql.Tx, workoutID int, exercises []WorkoutExercise) error {
+	for i := range exercises {
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO workout_exercises (workout_id, exercise_id, weight, sets, repetitions) VALUES (?, ?, ?, ?, ?)`,
+			workoutID, exercises[i].ExerciseID, exercises[i].Weight, exercises[i].Sets, exercises[i].Repetitions,
+		)
+		if err := dbutil.ProcessInsertError(err, ErrMissingField, ErrMissingField); err != nil {
+			return err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		exercises[i].ID = int(id)
+		exercises[i].WorkoutID = workoutID
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) listExercises(ctx context.Context, workoutID int) ([]WorkoutExercise, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT id, workout_id, exercise_id, weight, sets, repetitions FROM workout_exercises WHERE workout_id = ? ORDER BY id`,
+		workoutID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exercises := make([]WorkoutExercise, 0)
+	for rows.Next() {
+		var exercise WorkoutExercise
+		if err := rows.Scan(&exercise.ID, &exercise.WorkoutID, &exercise.ExerciseID, &exercise.Weight, &exercise.Sets, &exercise.Repetitions); err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, exercise)
+	}
+	return exercises, rows.Err()
+}
+
+func (repo *sqliteRepository) Create(ctx context.Context, workout *Workout) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO workouts (user_id, scheduled_at) VALUES (?, ?)`,
+		workout.UserID, workout.ScheduledAt.UTC(),
+	)
+	if err := dbutil.ProcessInsertError(err, ErrMissingField, ErrMissingField); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertExercises(ctx, tx, int(id), workout.Exercises); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// CreateBatch stores all workouts or none and sets their IDs.
+func (repo *sqliteRepository) CreateBatch(ctx context.Context, workouts []*Workout) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, workout := range workouts {
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO workouts (user_id, scheduled_at) VALUES (?, ?)`,
+			workout.UserID, workout.ScheduledAt.UTC(),
+		)
+		if err := dbutil.ProcessInsertError(err, ErrMissingField, ErrMissingField); err != nil {
+			return err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if err := insertExercises(ctx, tx, int(id), workout.Exercises); err != nil {
+			return err
+		}
+		workout.ID = int(id)
+	}
+
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) GetByID(ctx context.Context, id int) (*Workout, error) {
+	var workout Workout
+	row := repo.db.QueryRowContext(ctx,
+		`SELECT id, user_id, scheduled_at FROM workouts WHERE id = ?`,
+		id,
+	)
+	err := row.Scan(&workout.ID, &workout.UserID, &workout.ScheduledAt)
+	if err := dbutil.ProcessRowError(err, ErrWorkoutNotFound); err != nil {
+		return nil, err
+	}
+
+	workout.Exercises, err = repo.listExercises(ctx, workout.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &workout, nil
+}
+
+func (repo *sqliteRepository) ListByUserID(ctx context.Context, userID int) ([]*Workout, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT id, user_id, scheduled_at FROM workouts WHERE user_id = ? ORDER BY scheduled_at, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workouts := make([]*Workout, 0)
+	for rows.Next() {
+		var workout Workout
+		if err := rows.Scan(&workout.ID, &workout.UserID, &workout.ScheduledAt); err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, &workout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, workout := range workouts {
+		workout.Exercises, err = repo.listExercises(ctx, workout.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return workouts, nil
+}
+
+func (repo *sqliteRepository) Update(ctx context.Context, workout *Workout) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE workouts SET scheduled_at = ? WHERE id = ?`,
+		workout.ScheduledAt.UTC(), workout.ID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWorkoutNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM workout_exercises WHERE workout_id = ?`, workout.ID); err != nil {
+		return err
+	}
+	if err := insertExercises(ctx, tx, workout.ID, workout.Exercises); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *sqliteRepository) UpdateSchedule(ctx context.Context, id int, scheduledAt time.Time) error {
+	result, err := repo.db.ExecContext(ctx,
+		`UPDATE workouts SET scheduled_at = ? WHERE id = ?`,
+		scheduledAt.UTC(), id,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWorkoutNotFound
+	}
+	return nil
+}
+
+func (repo *sqliteRepository) Delete(ctx context.Context, id int) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM workout_exercises WHERE workout_id = ?`, id); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM workouts WHERE id = ?`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWorkoutNotFound
+	}
+
+	return tx.Commit()
+}
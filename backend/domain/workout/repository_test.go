@@ -0,0 +1,196 @@
+package workout_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"workup_fitness/domain/workout"
+	"workup_fitness/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T) (workout.Repository, *sql.DB, context.Context) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	repo := workout.NewSQLiteRepository(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO users (username, password_hash) VALUES ('alice', 'hash'), ('bob', 'hash')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO exercises (name) VALUES ('Squat'), ('Bench press')`)
+	require.NoError(t, err)
+
+	return repo, db, ctx
+}
+
+func TestRepository_Create_Success(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	newWorkout := &workout.Workout{
+		UserID:      1,
+		ScheduledAt: time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC),
+		Exercises: []workout.WorkoutExercise{
+			{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5},
+			{ExerciseID: 2, Weight: 80, Sets: 3, Repetitions: 8},
+		},
+	}
+
+	id, err := repo.Create(ctx, newWorkout)
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+	require.Equal(t, 1, newWorkout.Exercises[0].ID)
+	require.Equal(t, id, newWorkout.Exercises[1].WorkoutID)
+}
+
+func TestRepository_GetByID(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	scheduledAt := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+	id, err := repo.Create(ctx, &workout.Workout{
+		UserID:      1,
+		ScheduledAt: scheduledAt,
+		Exercises:   []workout.WorkoutExercise{{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5}},
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 1, found.UserID)
+	require.True(t, scheduledAt.Equal(found.ScheduledAt))
+	require.Len(t, found.Exercises, 1)
+	require.Equal(t, 100.0, found.Exercises[0].Weight)
+	require.Equal(t, 5, found.Exercises[0].Sets)
+	require.Equal(t, 5, found.Exercises[0].Repetitions)
+}
+
+func TestRepository_GetByID_NotFound(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	found, err := repo.GetByID(ctx, 42)
+	require.ErrorIs(t, err, workout.ErrWorkoutNotFound)
+	require.Nil(t, found)
+}
+
+func TestRepository_ListByUserID(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	later := time.Date(2025, 10, 12, 18, 0, 0, 0, time.UTC)
+	earlier := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+
+	_, err := repo.Create(ctx, &workout.Workout{UserID: 1, ScheduledAt: later})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &workout.Workout{
+		UserID:      1,
+		ScheduledAt: earlier,
+		Exercises:   []workout.WorkoutExercise{{ExerciseID: 2, Weight: 60, Sets: 3, Repetitions: 10}},
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &workout.Workout{UserID: 2, ScheduledAt: earlier})
+	require.NoError(t, err)
+
+	workouts, err := repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, workouts, 2)
+	require.True(t, earlier.Equal(workouts[0].ScheduledAt))
+	require.Len(t, workouts[0].Exercises, 1)
+	require.Empty(t, workouts[1].Exercises)
+}
+
+func TestRepository_Update(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &workout.Workout{
+		UserID:      1,
+		ScheduledAt: time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC),
+		Exercises:   []workout.WorkoutExercise{{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5}},
+	})
+	require.NoError(t, err)
+
+	updated := &workout.Workout{
+		ID:          id,
+		UserID:      1,
+		ScheduledAt: time.Date(2025, 10, 11, 9, 0, 0, 0, time.UTC),
+		Exercises: []workout.WorkoutExercise{
+			{ExerciseID: 2, Weight: 70, Sets: 4, Repetitions: 6},
+			{ExerciseID: 1, Weight: 110, Sets: 3, Repetitions: 3},
+		},
+	}
+	require.NoError(t, repo.Update(ctx, updated))
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.True(t, updated.ScheduledAt.Equal(found.ScheduledAt))
+	require.Len(t, found.Exercises, 2)
+	require.Equal(t, 2, found.Exercises[0].ExerciseID)
+	require.Equal(t, 110.0, found.Exercises[1].Weight)
+}
+
+func TestRepository_UpdateSchedule(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &workout.Workout{UserID: 1, ScheduledAt: time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	rescheduled := time.Date(2025, 10, 15, 7, 30, 0, 0, time.UTC)
+	require.NoError(t, repo.UpdateSchedule(ctx, id, rescheduled))
+
+	found, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.True(t, rescheduled.Equal(found.ScheduledAt))
+
+	err = repo.UpdateSchedule(ctx, 42, rescheduled)
+	require.ErrorIs(t, err, workout.ErrWorkoutNotFound)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	id, err := repo.Create(ctx, &workout.Workout{
+		UserID:      1,
+		ScheduledAt: time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC),
+		Exercises:   []workout.WorkoutExercise{{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, id))
+
+	_, err = repo.GetByID(ctx, id)
+	require.ErrorIs(t, err, workout.ErrWorkoutNotFound)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM workout_exercises`).Scan(&count))
+	require.Zero(t, count)
+
+	err = repo.Delete(ctx, id)
+	require.ErrorIs(t, err, workout.ErrWorkoutNotFound)
+}
+
+func TestRepository_CreateBatch(t *testing.T) {
+	repo, db, ctx := newTestRepository(t)
+	defer db.Close()
+
+	monday := time.Date(2025, 10, 6, 18, 0, 0, 0, time.UTC)
+	workouts := []*workout.Workout{
+		{UserID: 1, ScheduledAt: monday, Exercises: []workout.WorkoutExercise{{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5}}},
+		{UserID: 1, ScheduledAt: monday.AddDate(0, 0, 2), Exercises: []workout.WorkoutExercise{{ExerciseID: 2, Weight: 80, Sets: 3, Repetitions: 8}}},
+	}
+	require.NoError(t, repo.CreateBatch(ctx, workouts))
+	require.NotZero(t, workouts[0].ID)
+	require.NotZero(t, workouts[1].ID)
+
+	listed, err := repo.ListByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	require.Equal(t, 2, listed[1].Exercises[0].ExerciseID)
+}
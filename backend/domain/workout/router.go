@@ -0,0 +1,22 @@
+package workout
+
+import (
+	"net/http"
+
+	"workup_fitness/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(r chi.Router, h *Handler, authenticate func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(middleware.RequireScope("workouts"))
+		r.Get("/workouts", h.List)
+		r.Post("/workouts", h.Create)
+		r.Get("/workouts/{id}", h.GetByID)
+		r.Put("/workouts/{id}", h.Update)
+		r.Patch("/workouts/{id}/schedule", h.Reschedule)
+		r.Delete("/workouts/{id}", h.Delete)
+	})
+}
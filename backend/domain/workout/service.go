@@ -0,0 +1,207 @@
+package workout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/guregu/null/v6"
+
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/simulator"
+	"workup_fitness/pkg/logger"
+)
+
+//go:generate mockgen -destination=mocks/mock_service.go -package=mocks workup_fitness/domain/workout Service
+
+type ExerciseService interface {
+	GetByID(ctx context.Context, id int) (*exercise.Exercise, error)
+}
+
+type SimulatorService interface {
+	GetByID(ctx context.Context, id int) (*simulator.Simulator, error)
+}
+
+type Service interface {
+	Create(ctx context.Context, userID int, scheduledAt time.Time, exercises []WorkoutExercise, snapWeights bool) (*Workout, error)
+	CreateBatch(ctx context.Context, userID int, workouts []*Workout, snapWeights bool) error
+	GetByID(ctx context.Context, userID, id int) (*Workout, error)
+	List(ctx context.Context, userID int) ([]*Workout, error)
+	Update(ctx context.Context, userID int, workout *Workout, snapWeights bool) error
+	Reschedule(ctx context.Context, userID, id int, scheduledAt time.Time) (*Workout, error)
+	Delete(ctx context.Context, userID, id int) error
+}
+
+type serviceImpl struct {
+	repo             Repository
+	exerciseService  ExerciseService
+	simulatorService SimulatorService
+}
+
+func NewService(repo Repository, exerciseService ExerciseService, simulatorService SimulatorService) *serviceImpl {
+	logger.Default().Debug().Msg("Creating workout service...")
+	res := &serviceImpl{repo: repo, exerciseService: exerciseService, simulatorService: simulatorService}
+	logger.Default().Debug().Msg("Created workout service")
+	return res
+}
+
+// checkExercises validates every entry and, for exercises bound to a simulator,
+// makes sure the weight is reachable on its stack. With snapWeights the weight
+// is moved to the nearest reachable one and the original is kept in AdjustedFrom.
+func (s *serviceImpl) checkExercises(ctx context.Context, exercises []WorkoutExercise, snapWeights bool) error {
+	for i := range exercises {
+		entry := &exercises[i]
+		if entry.Weight < 0 {
+			return ErrNegativeWeight
+		}
+		if entry.Sets <= 0 || entry.Repetitions <= 0 {
+			return ErrInvalidVolume
+		}
+
+		found, err := s.exerciseService.GetByID(ctx, entry.ExerciseID)
+		if errors.Is(err, exercise.ErrExerciseNotFound) {
+			return ErrExerciseNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !found.SimulatorID.Valid {
+			continue
+		}
+
+		machine, err := s.simulatorService.GetByID(ctx, int(found.SimulatorID.Int64))
+		if err != nil {
+			return err
+		}
+		if machine.IsReachable(entry.Weight) {
+			continue
+		}
+		if !snapWeights {
+			return fmt.Errorf("%w: %g on %q, nearest is %g", ErrUnreachableWeight, entry.Weight, machine.Name.String, machine.NearestWeight(entry.Weight))
+		}
+
+		logger.Ctx(ctx).Info().Int("exercise_id", entry.ExerciseID).Float64("weight", entry.Weight).Float64("snapped_weight", machine.NearestWeight(entry.Weight)).Msg("Snapping weight to the simulator stack")
+		entry.AdjustedFrom = null.FloatFrom(entry.Weight)
+		entry.Weight = machine.NearestWeight(entry.Weight)
+	}
+	return nil
+}
+
+// getOwned loads a workout and makes sure it belongs to userID.
+func (s *serviceImpl) getOwned(ctx context.Context, userID, id int) (*Workout, error) {
+	workout, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if workout.UserID != userID {
+		return nil, ErrInvalidPermissions
+	}
+	return workout, nil
+}
+
+func (s *serviceImpl) Create(ctx context.Context, userID int, scheduledAt time.Time, exercises []WorkoutExercise, snapWeights bool) (*Workout, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Creating workout")
+
+	if scheduledAt.IsZero() {
+		return nil, errors.Join(ErrMissingField, errors.New("scheduled_at is required"))
+	}
+	if err := s.checkExercises(ctx, exercises, snapWeights); err != nil {
+		return nil, err
+	}
+
+	workout := &Workout{UserID: userID, ScheduledAt: scheduledAt, Exercises: exercises}
+	createdID, err := s.repo.Create(ctx, workout)
+	if err != nil {
+		return nil, err
+	}
+	workout.ID = createdID
+	workoutsLoggedTotal.Inc()
+	logger.Ctx(ctx).Info().Int("workout_id", workout.ID).Int("user_id", userID).Msg("Created workout")
+	return workout, nil
+}
+
+// CreateBatch creates several workouts of userID at once, for scheduling a
+// whole program. Either all of them are created or none.
+func (s *serviceImpl) CreateBatch(ctx context.Context, userID int, workouts []*Workout, snapWeights bool) error {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("count", len(workouts)).Msg("Creating workouts")
+
+	for _, workout := range workouts {
+		if workout.ScheduledAt.IsZero() {
+			return errors.Join(ErrMissingField, errors.New("scheduled_at is required"))
+		}
+		if err := s.checkExercises(ctx, workout.Exercises, snapWeights); err != nil {
+			return err
+		}
+		workout.UserID = userID
+	}
+
+	if err := s.repo.CreateBatch(ctx, workouts); err != nil {
+		return err
+	}
+	workoutsScheduledTotal.Add(float64(len(workouts)))
+	logger.Ctx(ctx).Info().Int("user_id", userID).Int("count", len(workouts)).Msg("Created workouts")
+	return nil
+}
+
+func (s *serviceImpl) GetByID(ctx context.Context, userID, id int) (*Workout, error) {
+	logger.Ctx(ctx).Info().Int("workout_id", id).Msg("Getting workout")
+	workout, err := s.getOwned(ctx, userID, id)
+	logger.Ctx(ctx).Info().Int("workout_id", id).Msg("Got workout")
+	return workout, err
+}
+
+func (s *serviceImpl) List(ctx context.Context, userID int) ([]*Workout, error) {
+	logger.Ctx(ctx).Info().Int("user_id", userID).Msg("Listing workouts")
+	workouts, err := s.repo.ListByUserID(ctx, userID)
+	logger.Ctx(ctx).Info().Int("count", len(workouts)).Int("user_id", userID).Msg("Listed workouts")
+	return workouts, err
+}
+
+func (s *serviceImpl) Update(ctx context.Context, userID int, workout *Workout, snapWeights bool) error {
+	logger.Ctx(ctx).Info().Int("workout_id", workout.ID).Msg("Updating workout")
+
+	if workout.ScheduledAt.IsZero() {
+		return errors.Join(ErrMissingField, errors.New("scheduled_at is required"))
+	}
+	if _, err := s.getOwned(ctx, userID, workout.ID); err != nil {
+		return err
+	}
+	if err := s.checkExercises(ctx, workout.Exercises, snapWeights); err != nil {
+		return err
+	}
+
+	workout.UserID = userID
+	err := s.repo.Update(ctx, workout)
+	logger.Ctx(ctx).Info().Int("workout_id", workout.ID).Msg("Updated workout")
+	return err
+}
+
+func (s *serviceImpl) Reschedule(ctx context.Context, userID, id int, scheduledAt time.Time) (*Workout, error) {
+	logger.Ctx(ctx).Info().Int("workout_id", id).Msg("Rescheduling workout")
+
+	if scheduledAt.IsZero() {
+		return nil, errors.Join(ErrMissingField, errors.New("scheduled_at is required"))
+	}
+	workout, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSchedule(ctx, id, scheduledAt); err != nil {
+		return nil, err
+	}
+	workout.ScheduledAt = scheduledAt
+	logger.Ctx(ctx).Info().Int("workout_id", id).Msg("Rescheduled workout")
+	return workout, nil
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, userID, id int) error {
+	logger.Ctx(ctx).Info().Int("workout_id", id).Msg("Deleting workout")
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	err := s.repo.Delete(ctx, id)
+	logger.Ctx(ctx).Info().Int("workout_id", id).Msg("Deleted workout")
+	return err
+}
@@ -0,0 +1,235 @@
+package workout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workup_fitness/domain/exercise"
+	exerciseMocks "workup_fitness/domain/exercise/mocks"
+	"workup_fitness/domain/simulator"
+	simulatorMocks "workup_fitness/domain/simulator/mocks"
+	"workup_fitness/domain/workout"
+	"workup_fitness/domain/workout/mocks"
+
+	"github.com/guregu/null/v6"
+	"github.com/guregu/null/v6/zero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testService struct {
+	workout.Service
+	repo             *mocks.MockRepository
+	exerciseService  *exerciseMocks.MockService
+	simulatorService *simulatorMocks.MockService
+}
+
+func newTestService(t *testing.T) testService {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	exerciseService := exerciseMocks.NewMockService(ctrl)
+	simulatorService := simulatorMocks.NewMockService(ctrl)
+	return testService{
+		Service:          workout.NewService(repo, exerciseService, simulatorService),
+		repo:             repo,
+		exerciseService:  exerciseService,
+		simulatorService: simulatorService,
+	}
+}
+
+func TestService_Create_Success(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	scheduledAt := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+
+	svc.exerciseService.EXPECT().
+		GetByID(ctx, 1).
+		Return(&exercise.Exercise{ID: 1}, nil)
+	svc.repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(7, nil)
+
+	created, err := svc.Create(ctx, 3, scheduledAt, []workout.WorkoutExercise{
+		{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5},
+	}, false)
+	require.NoError(t, err)
+	require.Equal(t, 7, created.ID)
+	require.Equal(t, 3, created.UserID)
+	require.Equal(t, scheduledAt, created.ScheduledAt)
+}
+
+func TestService_Create_MissingSchedule(t *testing.T) {
+	svc := newTestService(t)
+
+	_, err := svc.Create(context.Background(), 3, time.Time{}, nil, false)
+	require.ErrorIs(t, err, workout.ErrMissingField)
+}
+
+func TestService_Create_InvalidEntries(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	scheduledAt := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+
+	_, err := svc.Create(ctx, 3, scheduledAt, []workout.WorkoutExercise{{ExerciseID: 1, Weight: -5, Sets: 5, Repetitions: 5}}, false)
+	require.ErrorIs(t, err, workout.ErrNegativeWeight)
+
+	_, err = svc.Create(ctx, 3, scheduledAt, []workout.WorkoutExercise{{ExerciseID: 1, Weight: 50, Sets: 0, Repetitions: 5}}, false)
+	require.ErrorIs(t, err, workout.ErrInvalidVolume)
+}
+
+func TestService_Create_ExerciseNotFound(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.exerciseService.EXPECT().
+		GetByID(ctx, 9).
+		Return(nil, exercise.ErrExerciseNotFound)
+
+	_, err := svc.Create(ctx, 3, time.Now(), []workout.WorkoutExercise{{ExerciseID: 9, Weight: 50, Sets: 3, Repetitions: 5}}, false)
+	require.ErrorIs(t, err, workout.ErrExerciseNotFound)
+}
+
+func TestService_GetByID_OtherUser(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 7).
+		Return(&workout.Workout{ID: 7, UserID: 4}, nil)
+
+	found, err := svc.GetByID(ctx, 3, 7)
+	require.ErrorIs(t, err, workout.ErrInvalidPermissions)
+	require.Nil(t, found)
+}
+
+func TestService_Reschedule_Success(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	rescheduled := time.Date(2025, 10, 12, 7, 0, 0, 0, time.UTC)
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 7).
+		Return(&workout.Workout{ID: 7, UserID: 3}, nil)
+	svc.repo.EXPECT().
+		UpdateSchedule(ctx, 7, rescheduled).
+		Return(nil)
+
+	updated, err := svc.Reschedule(ctx, 3, 7, rescheduled)
+	require.NoError(t, err)
+	require.Equal(t, rescheduled, updated.ScheduledAt)
+}
+
+func TestService_Update_OtherUser(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 7).
+		Return(&workout.Workout{ID: 7, UserID: 4}, nil)
+
+	err := svc.Update(ctx, 3, &workout.Workout{ID: 7, ScheduledAt: time.Now()}, false)
+	require.ErrorIs(t, err, workout.ErrInvalidPermissions)
+}
+
+func TestService_Delete_OtherUser(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 7).
+		Return(&workout.Workout{ID: 7, UserID: 4}, nil)
+
+	err := svc.Delete(ctx, 3, 7)
+	require.ErrorIs(t, err, workout.ErrInvalidPermissions)
+}
+
+func TestService_Delete_Success(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	svc.repo.EXPECT().
+		GetByID(ctx, 7).
+		Return(&workout.Workout{ID: 7, UserID: 3}, nil)
+	svc.repo.EXPECT().
+		Delete(ctx, 7).
+		Return(nil)
+
+	require.NoError(t, svc.Delete(ctx, 3, 7))
+}
+
+func expectSimulatorBoundExercise(svc testService, ctx context.Context) {
+	svc.exerciseService.EXPECT().
+		GetByID(ctx, 1).
+		Return(&exercise.Exercise{ID: 1, SimulatorID: null.IntFrom(2)}, nil)
+	svc.simulatorService.EXPECT().
+		GetByID(ctx, 2).
+		Return(&simulator.Simulator{ID: 2, Name: zero.StringFrom("Leg press"), MinWeight: 10, MaxWeight: 200, WeightIncrement: 5}, nil)
+}
+
+func TestService_Create_ReachableWeight(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	expectSimulatorBoundExercise(svc, ctx)
+	svc.repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(7, nil)
+
+	created, err := svc.Create(ctx, 3, time.Now(), []workout.WorkoutExercise{{ExerciseID: 1, Weight: 85, Sets: 3, Repetitions: 10}}, false)
+	require.NoError(t, err)
+	require.Equal(t, 85.0, created.Exercises[0].Weight)
+	require.False(t, created.Exercises[0].AdjustedFrom.Valid)
+}
+
+func TestService_Create_UnreachableWeight(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	expectSimulatorBoundExercise(svc, ctx)
+
+	_, err := svc.Create(ctx, 3, time.Now(), []workout.WorkoutExercise{{ExerciseID: 1, Weight: 87, Sets: 3, Repetitions: 10}}, false)
+	require.ErrorIs(t, err, workout.ErrUnreachableWeight)
+	require.ErrorContains(t, err, "nearest is 85")
+}
+
+func TestService_Create_SnapWeight(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	expectSimulatorBoundExercise(svc, ctx)
+	svc.repo.EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(7, nil)
+
+	created, err := svc.Create(ctx, 3, time.Now(), []workout.WorkoutExercise{{ExerciseID: 1, Weight: 250, Sets: 3, Repetitions: 10}}, true)
+	require.NoError(t, err)
+	require.Equal(t, 200.0, created.Exercises[0].Weight)
+	require.Equal(t, 250.0, created.Exercises[0].AdjustedFrom.Float64)
+}
+
+func TestService_CreateBatch(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+	monday := time.Date(2025, 10, 6, 18, 0, 0, 0, time.UTC)
+
+	svc.exerciseService.EXPECT().
+		GetByID(ctx, 1).
+		Return(&exercise.Exercise{ID: 1}, nil).
+		Times(2)
+	svc.repo.EXPECT().
+		CreateBatch(ctx, gomock.Len(2)).
+		Return(nil)
+
+	workouts := []*workout.Workout{
+		{ScheduledAt: monday, Exercises: []workout.WorkoutExercise{{ExerciseID: 1, Weight: 100, Sets: 5, Repetitions: 5}}},
+		{ScheduledAt: monday.AddDate(0, 0, 2), Exercises: []workout.WorkoutExercise{{ExerciseID: 1, Weight: 105, Sets: 5, Repetitions: 5}}},
+	}
+	require.NoError(t, svc.CreateBatch(ctx, 3, workouts, false))
+	require.Equal(t, 3, workouts[1].UserID)
+
+	err := svc.CreateBatch(ctx, 3, []*workout.Workout{{ScheduledAt: monday}, {}}, false)
+	require.ErrorIs(t, err, workout.ErrMissingField)
+}
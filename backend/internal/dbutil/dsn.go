@@ -0,0 +1,17 @@
+package dbutil
+
+import "strings"
+
+// WithForeignKeys adds _foreign_keys=on to a go-sqlite3 DSN so that every
+// pooled connection enforces foreign keys and runs ON DELETE CASCADE.
+// SQLite leaves them off by default. A DSN that already sets the option is
+// returned unchanged.
+func WithForeignKeys(dsn string) string {
+	if strings.Contains(dsn, "_foreign_keys=") || strings.Contains(dsn, "_fk=") {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&_foreign_keys=on"
+	}
+	return dsn + "?_foreign_keys=on"
+}
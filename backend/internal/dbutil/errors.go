@@ -36,6 +36,20 @@ func ProcessInsertError(err error, alreadyExistsType, missingFieldType error) er
 	return err
 }
 
+// ProcessDeleteError maps a foreign key violation, raised when other rows
+// still reference the deleted one, to inUseType.
+func ProcessDeleteError(err error, inUseType error) error {
+	if err == nil {
+		return nil
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey {
+		return inUseType
+	}
+	return err
+}
+
 func ProcessRowError(err error, notFoundType error) error {
 	if err == nil {
 		return nil
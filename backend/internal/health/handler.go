@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"workup_fitness/internal/migrate"
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+
+	"github.com/pressly/goose/v3/database"
+)
+
+// readyTimeout bounds the database checks so a stuck connection makes the
+// probe fail instead of hang.
+const readyTimeout = 2 * time.Second
+
+type Handler struct {
+	db    *sql.DB
+	store database.Store
+	// latest is the newest migration embedded in the binary.
+	latest int64
+}
+
+func NewHandler(db *sql.DB) (*Handler, error) {
+	store, err := database.NewStore(database.DialectSQLite3, "goose_db_version")
+	if err != nil {
+		return nil, err
+	}
+	latest, err := migrate.Latest(db)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{db: db, store: store, latest: latest}, nil
+}
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status            string `json:"status"`
+	MigrationVersion  int64  `json:"migration_version"`
+	ExpectedMigration int64  `json:"expected_migration"`
+}
+
+// Liveness reports that the process is up and serving requests. It does not
+// touch dependencies, so a slow database never gets the process restarted.
+func (h *Handler) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, LivenessResponse{Status: "ok"})
+}
+
+// Readiness reports whether the database is reachable and migrated at least
+// up to the newest migration the binary embeds.
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		logger.Default().Error().Err(err).Msg("Readiness check failed: database ping")
+		httpx.WriteProblem(w, http.StatusServiceUnavailable, "database_unavailable", "Database is unreachable")
+		return
+	}
+
+	version, err := h.store.GetLatestVersion(ctx, h.db)
+	if err != nil {
+		if !errors.Is(err, database.ErrVersionNotFound) {
+			logger.Default().Error().Err(err).Msg("Readiness check failed: migration version")
+		}
+		httpx.WriteProblem(w, http.StatusServiceUnavailable, "database_not_migrated", "Database schema is not migrated")
+		return
+	}
+	if version < h.latest {
+		logger.Default().Warn().Int64("version", version).Int64("expected", h.latest).Msg("Readiness check failed: pending migrations")
+		httpx.WriteProblem(w, http.StatusServiceUnavailable, "database_behind",
+			fmt.Sprintf("Database schema is at %d, expected %d", version, h.latest))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ReadinessResponse{Status: "ready", MigrationVersion: version, ExpectedMigration: h.latest})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Default().Error().Err(err).Msg("Failed to encode health response")
+	}
+}
@@ -0,0 +1,91 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/internal/health"
+	"workup_fitness/internal/migrate"
+	"workup_fitness/internal/testutil"
+)
+
+func TestLiveness(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	h, err := health.NewHandler(db)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	h.Liveness(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestReadiness_Migrated(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer db.Close()
+
+	h, err := health.NewHandler(db)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	h.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp health.ReadinessResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.Equal(t, "ready", resp.Status)
+	require.Positive(t, resp.MigrationVersion)
+	require.Equal(t, resp.ExpectedMigration, resp.MigrationVersion)
+}
+
+func TestReadiness_PendingMigrations(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer db.Close()
+	_, err := migrate.Down(context.Background(), db)
+	require.NoError(t, err)
+
+	h, err := health.NewHandler(db)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	h.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	require.Contains(t, rr.Body.String(), "database_behind")
+}
+
+func TestReadiness_NotMigrated(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	h, err := health.NewHandler(db)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	h.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestReadiness_DatabaseClosed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	h, err := health.NewHandler(db)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	rr := httptest.NewRecorder()
+	h.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
@@ -0,0 +1,8 @@
+package health
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/healthz", h.Liveness)
+	r.Get("/readyz", h.Readiness)
+}
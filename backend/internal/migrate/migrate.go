@@ -0,0 +1,116 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"workup_fitness/migrations"
+
+	"github.com/pressly/goose/v3"
+)
+
+var ErrUnknownCommand = errors.New("unknown migrate command, expected up, down, status or version")
+
+func newProvider(db *sql.DB) (*goose.Provider, error) {
+	return goose.NewProvider(goose.DialectSQLite3, db, migrations.FS)
+}
+
+// Up applies every pending migration and returns the ones it ran.
+func Up(ctx context.Context, db *sql.DB) ([]*goose.MigrationResult, error) {
+	provider, err := newProvider(db)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Up(ctx)
+}
+
+// Down rolls back the most recently applied migration.
+func Down(ctx context.Context, db *sql.DB) (*goose.MigrationResult, error) {
+	provider, err := newProvider(db)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Down(ctx)
+}
+
+func Status(ctx context.Context, db *sql.DB) ([]*goose.MigrationStatus, error) {
+	provider, err := newProvider(db)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Status(ctx)
+}
+
+func Version(ctx context.Context, db *sql.DB) (int64, error) {
+	provider, err := newProvider(db)
+	if err != nil {
+		return 0, err
+	}
+	return provider.GetDBVersion(ctx)
+}
+
+// Latest returns the version of the newest embedded migration, which is
+// the version a fully migrated database reports.
+func Latest(db *sql.DB) (int64, error) {
+	provider, err := newProvider(db)
+	if err != nil {
+		return 0, err
+	}
+	sources := provider.ListSources()
+	if len(sources) == 0 {
+		return 0, nil
+	}
+	return sources[len(sources)-1].Version, nil
+}
+
+// Run executes a migrate subcommand (up, down, status or version) and prints
+// a human-readable report to w.
+func Run(ctx context.Context, db *sql.DB, args []string, w io.Writer) error {
+	if len(args) != 1 {
+		return ErrUnknownCommand
+	}
+
+	switch args[0] {
+	case "up":
+		results, err := Up(ctx, db)
+		for _, result := range results {
+			fmt.Fprintln(w, result)
+		}
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Fprintln(w, "no pending migrations")
+		}
+	case "down":
+		result, err := Down(ctx, db)
+		if result != nil {
+			fmt.Fprintln(w, result)
+		}
+		return err
+	case "status":
+		statuses, err := Status(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "-"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%-8s %-20s %s\n", status.State, appliedAt, status.Source.Path)
+		}
+	case "version":
+		version, err := Version(ctx, db)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, version)
+	default:
+		return ErrUnknownCommand
+	}
+	return nil
+}
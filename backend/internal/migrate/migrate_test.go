@@ -0,0 +1,69 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/internal/dbutil"
+	"workup_fitness/internal/migrate"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", dbutil.WithForeignKeys(":memory:"))
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUpDownVersion(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	results, err := migrate.Up(ctx, db)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+
+	latest := results[len(results)-1].Source.Version
+	version, err := migrate.Version(ctx, db)
+	require.NoError(t, err)
+	require.Equal(t, latest, version)
+
+	results, err = migrate.Up(ctx, db)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	_, err = migrate.Down(ctx, db)
+	require.NoError(t, err)
+
+	version, err = migrate.Version(ctx, db)
+	require.NoError(t, err)
+	require.Less(t, version, latest)
+}
+
+func TestRun(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	require.NoError(t, migrate.Run(ctx, db, []string{"status"}, &out))
+	require.Contains(t, out.String(), "pending")
+
+	out.Reset()
+	require.NoError(t, migrate.Run(ctx, db, []string{"up"}, &out))
+	require.Contains(t, out.String(), "OK")
+
+	out.Reset()
+	require.NoError(t, migrate.Run(ctx, db, []string{"status"}, &out))
+	require.NotContains(t, out.String(), "pending")
+
+	require.ErrorIs(t, migrate.Run(ctx, db, []string{"sideways"}, &out), migrate.ErrUnknownCommand)
+	require.ErrorIs(t, migrate.Run(ctx, db, nil, &out), migrate.ErrUnknownCommand)
+}
@@ -1,28 +1,27 @@
 package testutil
 
 import (
+	"context"
 	"database/sql"
-	"path/filepath"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
-	"github.com/pressly/goose/v3"
 	"github.com/stretchr/testify/require"
+
+	"workup_fitness/internal/dbutil"
+	"workup_fitness/internal/migrate"
 )
 
 func SetupTestDB(t *testing.T) *sql.DB {
 	t.Helper()
 
-	goose.SetLogger(goose.NopLogger())
-
-	db, err := sql.Open("sqlite3", ":memory:")
-	require.NoError(t, err)
-
-	err = goose.SetDialect("sqlite3")
+	db, err := sql.Open("sqlite3", dbutil.WithForeignKeys(":memory:"))
 	require.NoError(t, err)
+	// Every new connection to :memory: is a fresh empty database, so keep
+	// the pool to the single migrated one.
+	db.SetMaxOpenConns(1)
 
-	migrationsDir := filepath.Join("..", "..", "migrations")
-	err = goose.Up(db, migrationsDir)
+	_, err = migrate.Up(context.Background(), db)
 	require.NoError(t, err)
 
 	return db
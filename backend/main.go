@@ -1,54 +1,304 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	// Profile time zones are validated against the embedded zone database,
+	// so they do not depend on what the host has installed.
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
 	_ "github.com/mattn/go-sqlite3"
-	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
 
 	"workup_fitness/config"
+	"workup_fitness/domain/analytics"
+	"workup_fitness/domain/apikey"
 	"workup_fitness/domain/auth"
+	"workup_fitness/domain/bodymetric"
+	"workup_fitness/domain/exercise"
+	"workup_fitness/domain/program"
+	"workup_fitness/domain/progression"
+	"workup_fitness/domain/session"
 	"workup_fitness/domain/simulator"
 	"workup_fitness/domain/user"
+	"workup_fitness/domain/workout"
+	"workup_fitness/internal/dbutil"
+	"workup_fitness/internal/health"
+	"workup_fitness/internal/migrate"
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
 	"workup_fitness/pkg/logger"
+	"workup_fitness/pkg/mailer"
+	"workup_fitness/pkg/metrics"
 )
 
 func main() {
-	logger.Setup()
-	config.LoadConfig()
+	// Log config errors with the defaults until the configured logger is up.
+	_ = logger.Setup(logger.Options{})
 
-	db, err := sql.Open("sqlite3", "./database.sqlite")
+	cfg, args, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Error().Msg(err.Error())
+		logger.Default().Fatal().Err(err).Msg("Failed to load config")
 	}
-	defer db.Close()
+	if err := logger.Setup(logger.Options{
+		Level:           cfg.LogLevel,
+		Format:          cfg.LogFormat,
+		RedactUsernames: cfg.LogRedactUsernames,
+	}); err != nil {
+		logger.Default().Fatal().Err(err).Msg("Failed to set up logger")
+	}
+	log := logger.Default()
+	log.Info().Str("profile", string(cfg.Profile)).Msg("Loaded config")
 
-	if err := db.Ping(); err != nil {
-		log.Error().Msg(err.Error())
+	db, err := openDB(cfg.DatabaseDSN)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open database")
+	}
+
+	if len(args) > 0 && args[0] == "migrate" {
+		err := migrate.Run(context.Background(), db, args[1:], os.Stdout)
+		db.Close()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Migration failed")
+		}
+		return
+	}
+
+	if cfg.AutoMigrate {
+		results, err := migrate.Up(context.Background(), db)
+		if err != nil {
+			db.Close()
+			log.Fatal().Err(err).Msg("Failed to apply migrations")
+		}
+		for _, result := range results {
+			log.Info().Str("migration", result.Source.Path).Msg("Applied migration")
+		}
+	}
+
+	healthHandler, err := health.NewHandler(db)
+	if err != nil {
+		db.Close()
+		log.Fatal().Err(err).Msg("Failed to create health handler")
 	}
 
 	userRepo := user.NewSQLiteRepository(db)
 	userService := user.NewService(userRepo)
+
+	// There is no API to grant the first admin role, so it is done from
+	// the command line: workup_fitness promote-admin <username>.
+	if len(args) > 0 && args[0] == "promote-admin" {
+		err := promoteAdmin(context.Background(), userService, args[1:])
+		db.Close()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to promote admin")
+		}
+		return
+	}
 	userHandler := user.NewHandler(userService)
 
-	authService := auth.NewService(userService)
-	authHandler := auth.NewHandler(authService, config.JwtSecret)
+	authRepo := auth.NewSQLiteRepository(db)
+	loginLimiter := auth.NewLoginLimiter(auth.LoginLimits{
+		FreeAttempts:    cfg.LoginFreeAttempts,
+		BaseDelay:       cfg.LoginBackoffBase,
+		MaxDelay:        cfg.LoginBackoffMax,
+		MaxFailures:     cfg.LoginMaxFailures,
+		IPMaxFailures:   cfg.LoginIPMaxFailures,
+		LockoutDuration: cfg.LoginLockout,
+	})
+	resetLimiter := auth.NewLoginLimiter(auth.LoginLimits{
+		BaseDelay:       cfg.PasswordResetCooldown,
+		MaxDelay:        cfg.PasswordResetCooldown,
+		MaxFailures:     cfg.PasswordResetMaxRequests,
+		IPMaxFailures:   cfg.PasswordResetIPMaxRequests,
+		LockoutDuration: cfg.PasswordResetWindow,
+	})
+	passwordPolicy := auth.PasswordPolicy{MinLength: cfg.PasswordMinLength}
+	if cfg.BreachedPasswordsFile != "" {
+		passwordPolicy.Breached, err = auth.LoadBreachedPasswords(cfg.BreachedPasswordsFile)
+		if err != nil {
+			db.Close()
+			log.Fatal().Err(err).Msg("Failed to load breached passwords")
+		}
+		log.Info().Int("count", len(passwordPolicy.Breached)).Msg("Loaded breached passwords")
+	}
+	authService := auth.NewService(userService, authRepo, auth.Options{
+		RefreshTokenTTL: cfg.RefreshTokenTTL,
+		LoginLimiter:    loginLimiter,
+		PasswordPolicy:  passwordPolicy,
+		Mailer:          newMailer(cfg),
+		ResetTokenTTL:   cfg.PasswordResetTTL,
+		ResetLimiter:    resetLimiter,
+		ResetURL:        cfg.PasswordResetURL,
+		EmailTokenTTL:   cfg.EmailChangeTTL,
+		EmailURL:        cfg.EmailConfirmURL,
+		TOTPIssuer:      cfg.TOTPIssuer,
+		ChallengeTTL:    cfg.LoginChallengeTTL,
+	})
+	authHandler := auth.NewHandler(authService, cfg.JWTSecret, cfg.AccessTokenTTL, cfg.LoginChallengeTTL)
 
 	simulatorRepo := simulator.NewSQLiteRepository(db)
 	simulatorService := simulator.NewService(simulatorRepo)
 	simulatorHandler := simulator.NewHandler(simulatorService)
 
+	exerciseRepo := exercise.NewSQLiteRepository(db)
+	exerciseService := exercise.NewService(exerciseRepo, simulatorService)
+	exerciseHandler := exercise.NewHandler(exerciseService)
+
+	workoutRepo := workout.NewSQLiteRepository(db)
+	workoutService := workout.NewService(workoutRepo, exerciseService, simulatorService)
+	workoutHandler := workout.NewHandler(workoutService)
+
+	analyticsRepo := analytics.NewSQLiteRepository(db)
+	analyticsService := analytics.NewService(analyticsRepo, exerciseService, userService, analytics.Formula(cfg.E1RMFormula))
+	analyticsHandler := analytics.NewHandler(analyticsService)
+
+	sessionRepo := session.NewSQLiteRepository(db)
+	sessionService := session.NewService(sessionRepo, exerciseService, workoutService, analyticsService)
+	sessionHandler := session.NewHandler(sessionService)
+
+	programRepo := program.NewSQLiteRepository(db)
+	programService := program.NewService(programRepo, exerciseService, workoutService, analyticsService, userService)
+	programHandler := program.NewHandler(programService)
+
+	progressionRepo := progression.NewSQLiteRepository(db)
+	progressionService := progression.NewService(progressionRepo, exerciseService, simulatorService, analyticsService)
+	progressionHandler := progression.NewHandler(progressionService)
+
+	bodyMetricRepo := bodymetric.NewSQLiteRepository(db)
+	bodyMetricService := bodymetric.NewService(bodyMetricRepo, userService)
+	bodyMetricHandler := bodymetric.NewHandler(bodyMetricService)
+
+	apiKeyRepo := apikey.NewSQLiteRepository(db)
+	apiKeyService := apikey.NewService(apiKeyRepo, userService)
+	apiKeyHandler := apikey.NewHandler(apiKeyService)
+
+	authenticate := middleware.Auth(cfg.JWTSecret, apiKeyService)
+
 	r := chi.NewRouter()
-	user.RegisterRoutes(r, userHandler)
-	auth.RegisterRoutes(r, authHandler)
-	simulator.RegisterRoutes(r, simulatorHandler)
+	if cfg.TrustProxyHeaders {
+		r.Use(chimw.RealIP)
+	}
+	r.Use(middleware.RequestID)
+	r.Use(middleware.AccessLog)
+	r.Use(middleware.Metrics)
+	r.Use(middleware.Recoverer)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		httpx.NotFound(w, "Route not found")
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		httpx.MethodNotAllowed(w)
+	})
+	health.RegisterRoutes(r, healthHandler)
+	metrics.Default.RegisterDBStats(db)
+	r.Get("/metrics", metrics.Default.Handler().ServeHTTP)
+	user.RegisterRoutes(r, userHandler, authenticate)
+	auth.RegisterRoutes(r, authHandler, authenticate)
+	simulator.RegisterRoutes(r, simulatorHandler, authenticate)
+	exercise.RegisterRoutes(r, exerciseHandler, authenticate)
+	workout.RegisterRoutes(r, workoutHandler, authenticate)
+	session.RegisterRoutes(r, sessionHandler, authenticate)
+	program.RegisterRoutes(r, programHandler, authenticate)
+	progression.RegisterRoutes(r, progressionHandler, authenticate)
+	analytics.RegisterRoutes(r, analyticsHandler, authenticate)
+	bodymetric.RegisterRoutes(r, bodyMetricHandler, authenticate)
+	apikey.RegisterRoutes(r, apiKeyHandler, authenticate)
 
-	log.Info().Msg("Starting server on port " + config.Port)
-	err = http.ListenAndServe(fmt.Sprintf(":%s", config.Port), r)
+	srv := &http.Server{
+		Addr:         cfg.Addr(),
+		Handler:      r,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	if err := run(srv, cfg.ShutdownTimeout, log); err != nil {
+		log.Error().Err(err).Msg("Server stopped with error")
+	}
+
+	if err := db.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close database")
+	}
+	log.Info().Msg("Server stopped")
+}
+
+func openDB(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", dbutil.WithForeignKeys(dsn))
 	if err != nil {
-		log.Error().Msg(err.Error())
+		return nil, err
 	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// promoteAdmin gives the admin role to the user named in args.
+func promoteAdmin(ctx context.Context, users user.Service, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: promote-admin <username>")
+	}
+	found, err := users.GetByUsername(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("finding %q: %w", args[0], err)
+	}
+	if err := users.UpdateRole(ctx, found.ID, user.RoleAdmin); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().Int("user_id", found.ID).Msg("Promoted user to admin")
+	return nil
+}
+
+func newMailer(cfg *config.Config) mailer.Mailer {
+	switch cfg.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		})
+	case "file":
+		return mailer.NewFileMailer(cfg.MailFile, cfg.MailFrom)
+	default:
+		return mailer.LogMailer{}
+	}
+}
+
+// run serves until SIGINT or SIGTERM arrives, then stops accepting new
+// connections and waits up to shutdownTimeout for in-flight requests.
+func run(srv *http.Server, shutdownTimeout time.Duration, log *zerolog.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Info().Str("addr", srv.Addr).Msg("Starting server")
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.Info().Msg("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
 }
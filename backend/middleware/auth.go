@@ -6,39 +6,90 @@ import (
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"workup_fitness/pkg/httpx"
 )
 
 type contextKey string
 
-const UserIDKey contextKey = "userID"
+const (
+	UserIDKey contextKey = "userID"
+	RoleKey   contextKey = "role"
+	// APIKeyIDKey and ScopesKey are only set for requests authenticated
+	// with an API key.
+	APIKeyIDKey contextKey = "apiKeyID"
+	ScopesKey   contextKey = "scopes"
+)
+
+const APIKeyHeader = "X-API-Key"
 
-func Auth(secret string) func(http.Handler) http.Handler {
+// APIKeyPrincipal is who an API key acts for.
+type APIKeyPrincipal struct {
+	KeyID  int
+	UserID int
+	Role   string
+	Scopes []string
+}
+
+// APIKeyVerifier resolves a raw API key. Errors are written with
+// httpx.Error, so invalid keys should map to 401.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*APIKeyPrincipal, error)
+}
+
+// Auth accepts a Bearer JWT or, when keys is not nil, an X-API-Key header,
+// and puts the user ID and role into the context either way.
+func Auth(secret string, keys APIKeyVerifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" && keys != nil {
+				principal, err := keys.VerifyAPIKey(r.Context(), apiKey)
+				if err != nil {
+					httpx.Error(w, err)
+					return
+				}
+				addLogUserID(r.Context(), principal.UserID)
+				ctx := context.WithValue(r.Context(), UserIDKey, principal.UserID)
+				ctx = context.WithValue(ctx, RoleKey, principal.Role)
+				ctx = context.WithValue(ctx, APIKeyIDKey, principal.KeyID)
+				ctx = context.WithValue(ctx, ScopesKey, principal.Scopes)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-				http.Error(w, "missing auth token", http.StatusUnauthorized)
+				httpx.Unauthorized(w, "missing auth token")
 				return
 			}
 
 			tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 			token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
 				return []byte(secret), nil
-			})
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
 			if err != nil || !token.Valid {
-				http.Error(w, "invalid token", http.StatusUnauthorized)
+				httpx.Unauthorized(w, "invalid token")
 				return
 			}
 
 			claims, ok := token.Claims.(jwt.MapClaims)
 			if !ok {
-				http.Error(w, "invalid token", http.StatusUnauthorized)
+				httpx.Unauthorized(w, "invalid token")
 				return
 			}
 
-			userID := int(claims["userID"].(float64))
+			rawUserID, ok := claims["userID"].(float64)
+			if !ok {
+				httpx.Unauthorized(w, "invalid token")
+				return
+			}
+			userID := int(rawUserID)
+			addLogUserID(r.Context(), userID)
+			// Tokens issued before roles existed carry no role claim.
+			role, _ := claims["role"].(string)
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx = context.WithValue(ctx, RoleKey, role)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
-}
\ No newline at end of file
+}
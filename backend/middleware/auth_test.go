@@ -0,0 +1,133 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/httpx"
+)
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuth(t *testing.T) {
+	handler := middleware.Auth("test-secret", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, 7, r.Context().Value(middleware.UserIDKey))
+		require.Equal(t, "member", r.Context().Value(middleware.RoleKey))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for name, tc := range map[string]struct {
+		header string
+		code   int
+	}{
+		"valid": {
+			header: "Bearer " + signToken(t, jwt.MapClaims{"userID": 7, "role": "member", "exp": time.Now().Add(time.Minute).Unix()}),
+			code:   http.StatusNoContent,
+		},
+		"expired": {
+			header: "Bearer " + signToken(t, jwt.MapClaims{"userID": 7, "role": "member", "exp": time.Now().Add(-time.Minute).Unix()}),
+			code:   http.StatusUnauthorized,
+		},
+		"no expiry": {
+			header: "Bearer " + signToken(t, jwt.MapClaims{"userID": 7, "role": "member"}),
+			code:   http.StatusUnauthorized,
+		},
+		"missing": {
+			header: "",
+			code:   http.StatusUnauthorized,
+		},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, tc.code, rr.Code, name)
+	}
+}
+
+func TestAuth_MalformedUserID(t *testing.T) {
+	handler := middleware.Auth("test-secret", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, jwt.MapClaims{"userID": "7", "exp": time.Now().Add(time.Minute).Unix()}))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+type verifierFunc func(ctx context.Context, key string) (*middleware.APIKeyPrincipal, error)
+
+func (f verifierFunc) VerifyAPIKey(ctx context.Context, key string) (*middleware.APIKeyPrincipal, error) {
+	return f(ctx, key)
+}
+
+var errBadKey = errors.New("bad key")
+
+func init() {
+	httpx.RegisterError(errBadKey, http.StatusUnauthorized, "invalid_api_key")
+}
+
+func TestAuth_APIKey(t *testing.T) {
+	verifier := verifierFunc(func(ctx context.Context, key string) (*middleware.APIKeyPrincipal, error) {
+		if key != "wf_good" {
+			return nil, errBadKey
+		}
+		return &middleware.APIKeyPrincipal{KeyID: 3, UserID: 7, Role: "member", Scopes: []string{"workouts:read"}}, nil
+	})
+	handler := middleware.Auth("test-secret", verifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, 7, r.Context().Value(middleware.UserIDKey))
+		require.Equal(t, "member", r.Context().Value(middleware.RoleKey))
+		require.Equal(t, 3, r.Context().Value(middleware.APIKeyIDKey))
+		require.Equal(t, []string{"workouts:read"}, r.Context().Value(middleware.ScopesKey))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for key, code := range map[string]int{
+		"wf_good": http.StatusNoContent,
+		"wf_bad":  http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/workouts", nil)
+		req.Header.Set(middleware.APIKeyHeader, key)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, code, rr.Code, key)
+	}
+}
+
+func TestAuth_APIKeyDisabled(t *testing.T) {
+	handler := middleware.Auth("test-secret", nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/workouts", nil)
+	req.Header.Set(middleware.APIKeyHeader, "wf_good")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
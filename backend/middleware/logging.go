@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog"
+
+	"workup_fitness/pkg/httpx"
+	"workup_fitness/pkg/logger"
+)
+
+// AccessLog writes one line per request with the method, matched route
+// pattern, status, response size and latency. Mount it after RequestID.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		var event *zerolog.Event
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = logger.Ctx(r.Context()).Error()
+		case status >= http.StatusBadRequest:
+			event = logger.Ctx(r.Context()).Warn()
+		default:
+			event = logger.Ctx(r.Context()).Info()
+		}
+		event.
+			Str("method", r.Method).
+			Str("route", routePattern(r)).
+			Int("status", status).
+			Int("bytes", ww.BytesWritten()).
+			Dur("latency", time.Since(start)).
+			Msg("Request handled")
+	})
+}
+
+// Recoverer turns a handler panic into a 500 problem response and logs the
+// stack instead of letting net/http drop the connection.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww, ok := w.(chimw.WrapResponseWriter)
+		if !ok {
+			ww = chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+		}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			logger.Ctx(r.Context()).Error().
+				Interface("panic", rec).
+				Bytes("stack", debug.Stack()).
+				Msg("Recovered from panic")
+
+			if ww.Status() == 0 {
+				httpx.WriteProblem(ww, http.StatusInternalServerError, "internal_error", "Internal server error")
+			}
+		}()
+
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// routePattern returns the chi pattern such as /workouts/{id} so that log
+// lines group by route rather than by concrete URL.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return "unmatched"
+}
@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/middleware"
+)
+
+// captureLogs points the global logger at a buffer for the duration of the
+// test and returns the decoded JSON lines.
+func captureLogs(t *testing.T) func() []map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = previous })
+
+	return func() []map[string]any {
+		var lines []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			lines = append(lines, entry)
+		}
+		return lines
+	}
+}
+
+func newTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(middleware.AccessLog)
+	r.Use(middleware.Recoverer)
+	r.With(middleware.Auth("test-secret", nil)).Get("/workouts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		log.Ctx(r.Context()).Info().Msg("Getting workout")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	return r
+}
+
+func TestRequestID_GeneratesAndPropagates(t *testing.T) {
+	logs := captureLogs(t)
+	r := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/workouts/3", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, jwt.MapClaims{"userID": 7, "exp": time.Now().Add(time.Minute).Unix()}))
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	requestID := rr.Header().Get(middleware.RequestIDHeader)
+	require.Len(t, requestID, 32)
+
+	lines := logs()
+	require.Len(t, lines, 2)
+	require.Equal(t, "Getting workout", lines[0]["message"])
+	require.Equal(t, requestID, lines[0]["request_id"])
+
+	access := lines[1]
+	require.Equal(t, requestID, access["request_id"])
+	require.Equal(t, "/workouts/{id}", access["route"])
+	require.Equal(t, http.MethodGet, access["method"])
+	require.EqualValues(t, http.StatusNoContent, access["status"])
+	require.EqualValues(t, 7, access["user_id"])
+}
+
+func TestRequestID_ReusesIncomingHeader(t *testing.T) {
+	r := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/workouts/3", nil)
+	req.Header.Set(middleware.RequestIDHeader, "upstream-123")
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, "upstream-123", rr.Header().Get(middleware.RequestIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/workouts/3", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\twith spaces")
+	rr = httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	require.NotEqual(t, "bad id\twith spaces", rr.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestRecoverer(t *testing.T) {
+	logs := captureLogs(t)
+	r := newTestRouter(t)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	require.NotContains(t, rr.Body.String(), "boom")
+
+	lines := logs()
+	require.Len(t, lines, 2)
+	require.Equal(t, "boom", lines[0]["panic"])
+	require.NotEmpty(t, lines[0]["stack"])
+	require.EqualValues(t, http.StatusInternalServerError, lines[1]["status"])
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"workup_fitness/pkg/metrics"
+)
+
+var (
+	httpRequestsTotal = metrics.Default.NewCounterVec(
+		"http_requests_total",
+		"Total number of HTTP requests by method, route pattern and status.",
+		"method", "route", "status",
+	)
+	httpRequestDuration = metrics.Default.NewHistogramVec(
+		"http_request_duration_seconds",
+		"HTTP request latency by method and route pattern.",
+		metrics.DefaultBuckets,
+		"method", "route",
+	)
+)
+
+// Metrics records request counts and latencies labelled by the chi route
+// pattern, so /workouts/1 and /workouts/2 share one series.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		method := methodLabel(r.Method)
+		route := routePattern(r)
+		httpRequestsTotal.Inc(method, route, strconv.Itoa(status))
+		httpRequestDuration.Observe(time.Since(start).Seconds(), method, route)
+	})
+}
+
+// methodLabel folds methods the API does not use into "other", since the
+// method is client input and would otherwise create a series per value.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return method
+	default:
+		return "other"
+	}
+}
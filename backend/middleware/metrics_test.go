@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/middleware"
+	"workup_fitness/pkg/metrics"
+)
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(middleware.Metrics)
+	r.Get("/metrics-test/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, path := range []string{"/metrics-test/1", "/metrics-test/2", "/metrics-missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rr := httptest.NewRecorder()
+	metrics.Default.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rr.Body.String()
+	require.Contains(t, body, `http_requests_total{method="GET",route="/metrics-test/{id}",status="418"} 2`)
+	require.Contains(t, body, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	require.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/metrics-test/{id}"} 2`)
+}
+
+func TestMetrics_FoldsUnknownMethods(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(middleware.Metrics)
+	r.Get("/metrics-methods", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, method := range []string{"BREW", "PROPFIND", "brew"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/metrics-methods", nil))
+	}
+
+	rr := httptest.NewRecorder()
+	metrics.Default.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rr.Body.String()
+	require.Contains(t, body, `http_requests_total{method="other",route="unmatched",status="405"} 3`)
+	require.NotContains(t, body, `method="BREW"`)
+}
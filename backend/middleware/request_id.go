@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"workup_fitness/pkg/logger"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	RequestIDKey    contextKey = "requestID"
+	RequestIDHeader            = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID reuses a well-formed X-Request-ID from the client or a proxy,
+// otherwise generates one. The ID is echoed in the response header, stored
+// in the context and added to the context logger, so every log line written
+// through logger.Ctx(ctx) while serving the request carries it.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		reqLogger := logger.Default().With().Str("request_id", requestID).Logger()
+		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+		ctx = reqLogger.WithContext(ctx)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID keeps client-supplied IDs short and printable so they are
+// safe to echo in headers and log lines.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// addLogUserID attaches the authenticated user to the request logger. The
+// logger is shared by pointer with the middlewares mounted above Auth, so
+// the access log line picks it up as well.
+func addLogUserID(ctx context.Context, userID int) {
+	// Without RequestID in front the context logger is the global one,
+	// which must not be tagged with a single user.
+	if GetRequestID(ctx) == "" {
+		return
+	}
+	zerolog.Ctx(ctx).UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Int("user_id", userID)
+	})
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"workup_fitness/pkg/httpx"
+)
+
+// RequireRole lets the request through only when the role put into the
+// context by Auth is one of roles. It must be mounted after Auth.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(RoleKey).(string)
+			if !slices.Contains(roles, role) {
+				httpx.Forbidden(w, "invalid permissions")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/middleware"
+)
+
+func TestRequireRole(t *testing.T) {
+	handler := middleware.RequireRole("trainer", "admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for role, code := range map[string]int{
+		"admin":   http.StatusNoContent,
+		"trainer": http.StatusNoContent,
+		"member":  http.StatusForbidden,
+		"":        http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/simulators", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.RoleKey, role))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, code, rr.Code, role)
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"workup_fitness/pkg/httpx"
+)
+
+func isAPIKey(r *http.Request) bool {
+	_, ok := r.Context().Value(APIKeyIDKey).(int)
+	return ok
+}
+
+// RequireScope limits API keys to resource:read for GET and HEAD and to
+// resource:write for every other method. Requests with a session token are
+// not restricted. It must be mounted after Auth.
+func RequireScope(resource string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAPIKey(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			scope := resource + ":write"
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				scope = resource + ":read"
+			}
+			scopes, _ := r.Context().Value(ScopesKey).([]string)
+			if !slices.Contains(scopes, scope) {
+				httpx.Forbidden(w, "API key lacks scope "+scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession rejects API keys, for account management such as password
+// changes and key creation that only a logged-in user may do.
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAPIKey(r) {
+			httpx.Forbidden(w, "API keys cannot be used here")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
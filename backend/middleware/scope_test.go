@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/middleware"
+)
+
+func withAPIKey(req *http.Request, scopes ...string) *http.Request {
+	ctx := context.WithValue(req.Context(), middleware.APIKeyIDKey, 1)
+	ctx = context.WithValue(ctx, middleware.ScopesKey, scopes)
+	return req.WithContext(ctx)
+}
+
+func TestRequireScope(t *testing.T) {
+	handler := middleware.RequireScope("workouts")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for name, tc := range map[string]struct {
+		req  *http.Request
+		code int
+	}{
+		"session":          {httptest.NewRequest(http.MethodPost, "/workouts", nil), http.StatusNoContent},
+		"read allowed":     {withAPIKey(httptest.NewRequest(http.MethodGet, "/workouts", nil), "workouts:read"), http.StatusNoContent},
+		"write forbidden":  {withAPIKey(httptest.NewRequest(http.MethodPost, "/workouts", nil), "workouts:read"), http.StatusForbidden},
+		"write allowed":    {withAPIKey(httptest.NewRequest(http.MethodDelete, "/workouts/1", nil), "workouts:write"), http.StatusNoContent},
+		"other resource":   {withAPIKey(httptest.NewRequest(http.MethodGet, "/workouts", nil), "profile:read"), http.StatusForbidden},
+		"write is no read": {withAPIKey(httptest.NewRequest(http.MethodGet, "/workouts", nil), "workouts:write"), http.StatusForbidden},
+	} {
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, tc.req)
+
+		require.Equal(t, tc.code, rr.Code, name)
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	handler := middleware.RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api-keys", nil))
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, withAPIKey(httptest.NewRequest(http.MethodPost, "/api-keys", nil), "profile:write"))
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
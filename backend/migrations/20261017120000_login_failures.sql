@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE login_failures (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL,
+    ip TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_login_failures_username ON login_failures(username, created_at);
+CREATE INDEX idx_login_failures_ip ON login_failures(ip, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_login_failures_ip;
+DROP INDEX IF EXISTS idx_login_failures_username;
+DROP TABLE IF EXISTS login_failures;
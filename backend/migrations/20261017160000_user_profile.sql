@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN display_name TEXT;
+ALTER TABLE users ADD COLUMN birth_date DATE;
+ALTER TABLE users ADD COLUMN sex TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN height_cm REAL;
+ALTER TABLE users ADD COLUMN weight_unit TEXT NOT NULL DEFAULT 'kg';
+ALTER TABLE users ADD COLUMN experience_level TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN time_zone;
+ALTER TABLE users DROP COLUMN experience_level;
+ALTER TABLE users DROP COLUMN weight_unit;
+ALTER TABLE users DROP COLUMN height_cm;
+ALTER TABLE users DROP COLUMN sex;
+ALTER TABLE users DROP COLUMN birth_date;
+ALTER TABLE users DROP COLUMN display_name;
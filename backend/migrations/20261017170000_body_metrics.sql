@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE body_metrics (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    measured_at DATETIME NOT NULL,
+    weight_kg REAL,
+    body_fat_pct REAL,
+    neck_cm REAL,
+    chest_cm REAL,
+    waist_cm REAL,
+    hips_cm REAL,
+    arm_cm REAL,
+    thigh_cm REAL,
+    calf_cm REAL,
+    note TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_body_metrics_user_id ON body_metrics(user_id, measured_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_body_metrics_user_id;
+DROP TABLE IF EXISTS body_metrics;
@@ -0,0 +1,41 @@
+-- +goose Up
+CREATE TABLE workout_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    workout_id INTEGER,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    notes TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_workout_sessions_user_id ON workout_sessions(user_id, started_at);
+-- A user has at most one session in progress.
+CREATE UNIQUE INDEX idx_workout_sessions_active ON workout_sessions(user_id) WHERE finished_at IS NULL;
+
+CREATE TABLE session_sets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    set_index INTEGER NOT NULL,
+    weight REAL NOT NULL,
+    reps INTEGER NOT NULL,
+    rpe REAL,
+    rir INTEGER,
+    tempo TEXT,
+    rest_seconds INTEGER,
+    completed BOOLEAN NOT NULL DEFAULT 1,
+    notes TEXT,
+    performed_at DATETIME NOT NULL,
+    UNIQUE (session_id, exercise_id, set_index),
+    FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS session_sets;
+DROP INDEX IF EXISTS idx_workout_sessions_active;
+DROP INDEX IF EXISTS idx_workout_sessions_user_id;
+DROP TABLE IF EXISTS workout_sessions;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE personal_records (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    set_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    value REAL NOT NULL,
+    weight REAL NOT NULL,
+    reps INTEGER NOT NULL,
+    achieved_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    FOREIGN KEY (set_id) REFERENCES session_sets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_personal_records_user_exercise ON personal_records(user_id, exercise_id, achieved_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_personal_records_user_exercise;
+DROP TABLE IF EXISTS personal_records;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE exercise_muscle_groups (
+    exercise_id INTEGER NOT NULL,
+    muscle_group TEXT NOT NULL,
+    -- primary or secondary
+    role TEXT NOT NULL,
+    PRIMARY KEY (exercise_id, muscle_group),
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_exercise_muscle_groups_muscle_group ON exercise_muscle_groups(muscle_group);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_exercise_muscle_groups_muscle_group;
+DROP TABLE IF EXISTS exercise_muscle_groups;
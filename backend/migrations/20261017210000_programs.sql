@@ -0,0 +1,57 @@
+-- +goose Up
+CREATE TABLE workout_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    notes TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE template_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    template_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    sets INTEGER NOT NULL,
+    rep_min INTEGER NOT NULL,
+    rep_max INTEGER NOT NULL,
+    -- At most one of weight, percent_e1rm and rpe is set.
+    weight REAL,
+    percent_e1rm REAL,
+    rpe REAL,
+    UNIQUE (template_id, position),
+    FOREIGN KEY (template_id) REFERENCES workout_templates(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id)
+);
+
+CREATE TABLE programs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    weeks INTEGER NOT NULL,
+    notes TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE program_days (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    program_id INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    day INTEGER NOT NULL,
+    template_id INTEGER NOT NULL,
+    FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+    FOREIGN KEY (template_id) REFERENCES workout_templates(id)
+);
+
+CREATE INDEX idx_program_days_template_id ON program_days(template_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_program_days_template_id;
+DROP TABLE IF EXISTS program_days;
+DROP TABLE IF EXISTS programs;
+DROP TABLE IF EXISTS template_exercises;
+DROP TABLE IF EXISTS workout_templates;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE progression_plans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    rule TEXT NOT NULL,
+    sets INTEGER NOT NULL,
+    rep_min INTEGER NOT NULL,
+    rep_max INTEGER NOT NULL,
+    increment REAL,
+    start_weight REAL,
+    training_max REAL,
+    -- deload_after is 0 when the plan never deloads.
+    deload_after INTEGER NOT NULL DEFAULT 0,
+    deload_percent REAL NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL,
+    UNIQUE (user_id, exercise_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS progression_plans;
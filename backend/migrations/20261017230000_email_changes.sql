@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE email_change_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    email TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_change_tokens_user_id ON email_change_tokens(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_email_change_tokens_user_id;
+DROP TABLE IF EXISTS email_change_tokens;
@@ -0,0 +1,117 @@
+-- +goose Up
+-- Foreign keys are enforced from now on, so the tables created before the
+-- cascade convention are rebuilt to go away with their user or workout.
+-- Checks are deferred to commit because the children still point at the
+-- table names while they are swapped, and rows orphaned while enforcement
+-- was off are left behind. Dropping a parent runs its ON DELETE actions, so
+-- workout exercises are copied aside and the workout of every session is
+-- saved and restored.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE session_workouts AS
+    SELECT id, workout_id FROM workout_sessions WHERE workout_id IS NOT NULL;
+
+CREATE TEMP TABLE workout_exercises_copy AS SELECT * FROM workout_exercises;
+DROP TABLE workout_exercises;
+
+CREATE TABLE workouts_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    scheduled_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+INSERT INTO workouts_new (id, user_id, scheduled_at)
+    SELECT id, user_id, scheduled_at FROM workouts WHERE user_id IN (SELECT id FROM users);
+DROP TABLE workouts;
+ALTER TABLE workouts_new RENAME TO workouts;
+
+CREATE TABLE workout_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    workout_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    weight REAL NOT NULL,
+    sets INTEGER NOT NULL,
+    repetitions INTEGER NOT NULL,
+    FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id)
+);
+INSERT INTO workout_exercises (id, workout_id, exercise_id, weight, sets, repetitions)
+    SELECT id, workout_id, exercise_id, weight, sets, repetitions FROM workout_exercises_copy
+    WHERE workout_id IN (SELECT id FROM workouts) AND exercise_id IN (SELECT id FROM exercises);
+DROP TABLE workout_exercises_copy;
+
+CREATE TABLE refresh_tokens_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    family_id TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+INSERT INTO refresh_tokens_new (id, user_id, family_id, token_hash, expires_at, revoked_at, created_at)
+    SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, created_at FROM refresh_tokens
+    WHERE user_id IN (SELECT id FROM users);
+DROP TABLE refresh_tokens;
+ALTER TABLE refresh_tokens_new RENAME TO refresh_tokens;
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+UPDATE workout_sessions
+SET workout_id = (SELECT s.workout_id FROM session_workouts s WHERE s.id = workout_sessions.id)
+WHERE id IN (SELECT s.id FROM session_workouts s WHERE s.workout_id IN (SELECT id FROM workouts));
+DROP TABLE session_workouts;
+
+-- +goose Down
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TEMP TABLE session_workouts AS
+    SELECT id, workout_id FROM workout_sessions WHERE workout_id IS NOT NULL;
+
+CREATE TEMP TABLE workout_exercises_copy AS SELECT * FROM workout_exercises;
+DROP TABLE workout_exercises;
+
+CREATE TABLE workouts_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    scheduled_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+INSERT INTO workouts_old (id, user_id, scheduled_at) SELECT id, user_id, scheduled_at FROM workouts;
+DROP TABLE workouts;
+ALTER TABLE workouts_old RENAME TO workouts;
+
+CREATE TABLE workout_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    workout_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    weight REAL NOT NULL,
+    sets INTEGER NOT NULL,
+    repetitions INTEGER NOT NULL,
+    FOREIGN KEY (workout_id) REFERENCES workouts(id),
+    FOREIGN KEY (exercise_id) REFERENCES exercises(id)
+);
+INSERT INTO workout_exercises (id, workout_id, exercise_id, weight, sets, repetitions)
+    SELECT id, workout_id, exercise_id, weight, sets, repetitions FROM workout_exercises_copy;
+DROP TABLE workout_exercises_copy;
+
+CREATE TABLE refresh_tokens_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    family_id TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+INSERT INTO refresh_tokens_old (id, user_id, family_id, token_hash, expires_at, revoked_at, created_at)
+    SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, created_at FROM refresh_tokens;
+DROP TABLE refresh_tokens;
+ALTER TABLE refresh_tokens_old RENAME TO refresh_tokens;
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+UPDATE workout_sessions
+SET workout_id = (SELECT s.workout_id FROM session_workouts s WHERE s.id = workout_sessions.id)
+WHERE id IN (SELECT id FROM session_workouts);
+DROP TABLE session_workouts;
@@ -1,21 +1,116 @@
 package httpx
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"workup_fitness/pkg/logger"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Code is a stable,
+// machine-readable identifier clients can switch on instead of Detail.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code"`
+}
+
+func WriteProblem(w http.ResponseWriter, status int, code, detail string) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	})
+}
+
+type mappedError struct {
+	target error
+	status int
+	code   string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []mappedError
 )
 
+// RegisterError maps a sentinel error to the status and code Error responds
+// with. Domain packages register their errors from init.
+func RegisterError(target error, status int, code string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, mappedError{target: target, status: status, code: code})
+}
+
+func lookupError(err error) (mappedError, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, m := range registry {
+		if errors.Is(err, m.target) {
+			return m, true
+		}
+	}
+	return mappedError{}, false
+}
+
+// Error writes the problem registered for err, falling back to a 500 that
+// does not expose the error text. Errors with a RetryAfter method also set
+// the Retry-After header.
+func Error(w http.ResponseWriter, err error) {
+	var retry interface{ RetryAfter() time.Duration }
+	if errors.As(err, &retry) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.RetryAfter().Seconds()))))
+	}
+	if m, ok := lookupError(err); ok {
+		WriteProblem(w, m.status, m.code, err.Error())
+		return
+	}
+	InternalServerError(w, err)
+}
+
 func MethodNotAllowed(w http.ResponseWriter) {
-	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	WriteProblem(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
 }
 
 func BadRequest(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusBadRequest)
+	WriteProblem(w, http.StatusBadRequest, "bad_request", msg)
 }
 
 func Unauthorized(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusUnauthorized)
+	WriteProblem(w, http.StatusUnauthorized, "unauthorized", msg)
 }
 
 func InternalServerError(w http.ResponseWriter, err error) {
-	http.Error(w, "Internal server error: "+err.Error(), http.StatusInternalServerError)
+	logger.Default().Error().Err(err).Msg("Internal server error")
+	WriteProblem(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+}
+
+func NotFound(w http.ResponseWriter, msg string) {
+	WriteProblem(w, http.StatusNotFound, "not_found", msg)
+}
+
+func Conflict(w http.ResponseWriter, msg string) {
+	WriteProblem(w, http.StatusConflict, "conflict", msg)
+}
+
+func Forbidden(w http.ResponseWriter, msg string) {
+	WriteProblem(w, http.StatusForbidden, "forbidden", msg)
+}
+
+func UnprocessableEntity(w http.ResponseWriter, msg string) {
+	WriteProblem(w, http.StatusUnprocessableEntity, "unprocessable_entity", msg)
 }
@@ -0,0 +1,65 @@
+package httpx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/pkg/httpx"
+)
+
+var errTestNotFound = errors.New("thing not found")
+
+func init() {
+	httpx.RegisterError(errTestNotFound, http.StatusNotFound, "thing_not_found")
+}
+
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) httpx.Problem {
+	t.Helper()
+
+	require.Equal(t, httpx.ProblemContentType, rr.Header().Get("Content-Type"))
+	var problem httpx.Problem
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+	return problem
+}
+
+func TestWriteProblem(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	httpx.BadRequest(rr, "Invalid JSON")
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	problem := decodeProblem(t, rr)
+	require.Equal(t, "about:blank", problem.Type)
+	require.Equal(t, "Bad Request", problem.Title)
+	require.Equal(t, http.StatusBadRequest, problem.Status)
+	require.Equal(t, "Invalid JSON", problem.Detail)
+	require.Equal(t, "bad_request", problem.Code)
+}
+
+func TestError_RegisteredSentinel(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	httpx.Error(rr, fmt.Errorf("%w: id 7", errTestNotFound))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	problem := decodeProblem(t, rr)
+	require.Equal(t, "thing_not_found", problem.Code)
+	require.Equal(t, "thing not found: id 7", problem.Detail)
+}
+
+func TestError_UnknownDoesNotLeak(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	httpx.Error(rr, errors.New("sql: connection refused on /var/db"))
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	problem := decodeProblem(t, rr)
+	require.Equal(t, "internal_error", problem.Code)
+	require.NotContains(t, problem.Detail, "sql")
+}
@@ -1,17 +1,78 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
-func Setup() {
-	output := zerolog.ConsoleWriter{
-		Out:        os.Stderr,
-		TimeFormat: "15:04:05",
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+type Options struct {
+	// Level is a zerolog level name such as debug, info or warn.
+	Level string
+	// Format is FormatConsole for humans or FormatJSON for log shippers.
+	Format string
+	// RedactUsernames adds the username field to the redacted set.
+	RedactUsernames bool
+	// Output defaults to stderr.
+	Output io.Writer
+}
+
+// Setup replaces the base logger. Every line goes through the redaction
+// layer before it reaches the console or JSON output.
+func Setup(opts Options) error {
+	level := zerolog.InfoLevel
+	if opts.Level != "" {
+		parsed, err := zerolog.ParseLevel(opts.Level)
+		if err != nil {
+			return fmt.Errorf("invalid log level %q: %w", opts.Level, err)
+		}
+		level = parsed
+	}
+
+	out := opts.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	switch opts.Format {
+	case "", FormatConsole:
+		out = zerolog.ConsoleWriter{Out: out, TimeFormat: "15:04:05"}
+	case FormatJSON:
+	default:
+		return fmt.Errorf("invalid log format %q, expected %s or %s", opts.Format, FormatConsole, FormatJSON)
+	}
+
+	fields := DefaultRedactedFields
+	if opts.RedactUsernames {
+		fields = append(append([]string{}, fields...), "username")
 	}
-	logger := zerolog.New(output).With().Timestamp().Logger()
-	log.Logger = logger
+
+	log.Logger = zerolog.New(NewRedactingWriter(out, fields...)).Level(level).With().Timestamp().Logger()
+	// Ctx falls back to the base logger for contexts that did not pass
+	// through the request middleware, such as startup code and tests.
+	zerolog.DefaultContextLogger = &log.Logger
+	return nil
+}
+
+// Default returns the base logger for code that runs outside a request.
+func Default() *zerolog.Logger {
+	return &log.Logger
+}
+
+// Ctx returns the logger carried by ctx, or the base logger.
+func Ctx(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l zerolog.Logger) context.Context {
+	return l.WithContext(ctx)
 }
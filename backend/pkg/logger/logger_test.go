@@ -0,0 +1,92 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/pkg/logger"
+)
+
+func setupJSON(t *testing.T, opts logger.Options) *bytes.Buffer {
+	t.Helper()
+
+	previous := log.Logger
+	t.Cleanup(func() { log.Logger = previous })
+
+	var buf bytes.Buffer
+	opts.Format = logger.FormatJSON
+	opts.Output = &buf
+	require.NoError(t, logger.Setup(opts))
+	return &buf
+}
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	buf.Reset()
+	return entry
+}
+
+func TestSetup_Level(t *testing.T) {
+	buf := setupJSON(t, logger.Options{Level: "warn"})
+
+	logger.Default().Info().Msg("hidden")
+	require.Empty(t, buf.String())
+
+	logger.Default().Warn().Msg("shown")
+	require.Equal(t, "shown", decodeLine(t, buf)["message"])
+}
+
+func TestSetup_Invalid(t *testing.T) {
+	require.Error(t, logger.Setup(logger.Options{Level: "loud"}))
+	require.Error(t, logger.Setup(logger.Options{Format: "xml"}))
+}
+
+func TestRedaction(t *testing.T) {
+	buf := setupJSON(t, logger.Options{})
+
+	logger.Default().Info().
+		Str("password", "hunter2").
+		Str("Refresh_Token", "abc").
+		Str("username", "alice").
+		Int("user_id", 7).
+		Msg("Logged in user")
+
+	entry := decodeLine(t, buf)
+	require.Equal(t, "[REDACTED]", entry["password"])
+	require.Equal(t, "[REDACTED]", entry["Refresh_Token"])
+	require.Equal(t, "alice", entry["username"])
+	require.EqualValues(t, 7, entry["user_id"])
+	require.Equal(t, "Logged in user", entry["message"])
+}
+
+func TestRedaction_Usernames(t *testing.T) {
+	buf := setupJSON(t, logger.Options{RedactUsernames: true})
+
+	logger.Default().Info().Str("username", "alice").Msg("Logging in user")
+
+	require.Equal(t, "[REDACTED]", decodeLine(t, buf)["username"])
+}
+
+func TestCtx(t *testing.T) {
+	buf := setupJSON(t, logger.Options{})
+
+	logger.Ctx(context.Background()).Info().Msg("fallback")
+	require.Equal(t, "fallback", decodeLine(t, buf)["message"])
+
+	reqLogger := logger.Default().With().Str("request_id", "r-1").Logger()
+	ctx := logger.WithContext(context.Background(), reqLogger)
+	logger.Ctx(ctx).Info().Msg("scoped")
+
+	entry := decodeLine(t, buf)
+	require.Equal(t, "r-1", entry["request_id"])
+	require.Equal(t, zerolog.InfoLevel.String(), entry["level"])
+}
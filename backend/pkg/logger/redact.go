@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+const redactedValue = `"[REDACTED]"`
+
+// DefaultRedactedFields are never written to the log output as is.
+var DefaultRedactedFields = []string{
+	"password",
+	"password_hash",
+	"token",
+	"access_token",
+	"refresh_token",
+	"secret",
+	"authorization",
+	"api_key",
+}
+
+type redactingWriter struct {
+	out    io.Writer
+	fields map[string]struct{}
+}
+
+// NewRedactingWriter rewrites top-level fields of each JSON log line whose
+// key matches one of fields (case-insensitively) before passing it on.
+func NewRedactingWriter(out io.Writer, fields ...string) io.Writer {
+	w := &redactingWriter{out: out, fields: make(map[string]struct{}, len(fields))}
+	for _, field := range fields {
+		w.fields[strings.ToLower(field)] = struct{}{}
+	}
+	return w
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	redacted, ok := w.redact(p)
+	if !ok {
+		return w.out.Write(p)
+	}
+	if _, err := w.out.Write(redacted); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// redact returns the rewritten line and whether anything was replaced. Lines
+// that are not a JSON object are passed through untouched.
+func (w *redactingWriter) redact(p []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	changed := false
+	for i := 0; dec.More(); i++ {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, _ := json.Marshal(key)
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		if _, sensitive := w.fields[strings.ToLower(key)]; sensitive {
+			buf.WriteString(redactedValue)
+			changed = true
+		} else {
+			buf.Write(value)
+		}
+	}
+	if !changed {
+		return nil, false
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), true
+}
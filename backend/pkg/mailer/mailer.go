@@ -0,0 +1,101 @@
+// Package mailer sends plain-text email through SMTP, or records it in a
+// file or the log for local development and tests.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"workup_fitness/pkg/logger"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+var ErrInvalidMessage = errors.New("invalid message")
+
+func (m Message) validate() error {
+	if m.To == "" {
+		return fmt.Errorf("%w: no recipient", ErrInvalidMessage)
+	}
+	if strings.ContainsAny(m.To+m.Subject, "\r\n") {
+		return fmt.Errorf("%w: line break in header", ErrInvalidMessage)
+	}
+	return nil
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// format renders msg as an RFC 5322 message with CRLF line endings.
+func format(from string, msg Message, now time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// FileMailer appends every message to a file, separated by blank lines.
+type FileMailer struct {
+	path string
+	from string
+	mu   sync.Mutex
+}
+
+func NewFileMailer(path, from string) *FileMailer {
+	return &FileMailer{path: path, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(format(m.from, msg, time.Now()), "\r\n"...)); err != nil {
+		file.Close()
+		return err
+	}
+	logger.Ctx(ctx).Info().Str("path", m.path).Str("subject", msg.Subject).Msg("Wrote email to file")
+	return file.Close()
+}
+
+// LogMailer writes messages to the request logger at info level. The body
+// is logged too, so it must only be used where the log is private.
+type LogMailer struct{}
+
+func (LogMailer) Send(ctx context.Context, msg Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	logger.Ctx(ctx).Info().
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Body).
+		Msg("Email")
+	return nil
+}
@@ -0,0 +1,112 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/pkg/mailer"
+)
+
+func TestFileMailer_AppendsMessages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := mailer.NewFileMailer(path, "no-reply@example.com")
+
+	require.NoError(t, m.Send(context.Background(), mailer.Message{To: "alice@example.com", Subject: "First", Body: "one\ntwo"}))
+	require.NoError(t, m.Send(context.Background(), mailer.Message{To: "bob@example.com", Subject: "Second", Body: "three"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	require.Contains(t, content, "From: no-reply@example.com\r\nTo: alice@example.com\r\nSubject: First\r\n")
+	require.Contains(t, content, "\r\n\r\none\r\ntwo\r\n")
+	require.Contains(t, content, "To: bob@example.com\r\n")
+}
+
+func TestMailers_RejectHeaderInjection(t *testing.T) {
+	msg := mailer.Message{To: "alice@example.com\r\nBcc: eve@example.com", Subject: "Hi"}
+
+	for name, m := range map[string]mailer.Mailer{
+		"file": mailer.NewFileMailer(filepath.Join(t.TempDir(), "mail.log"), "no-reply@example.com"),
+		"log":  mailer.LogMailer{},
+		"smtp": mailer.NewSMTPMailer(mailer.SMTPConfig{Host: "localhost", Port: 25, From: "no-reply@example.com"}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, m.Send(context.Background(), msg), mailer.ErrInvalidMessage)
+		})
+	}
+}
+
+// serveSMTP accepts one connection and speaks just enough SMTP for a
+// message without TLS or authentication. It returns the DATA section.
+func serveSMTP(t *testing.T, ln net.Listener) <-chan string {
+	t.Helper()
+
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		var body strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					data <- body.String()
+					reply("250 OK")
+					continue
+				}
+				body.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				inData = true
+				reply("354 Go ahead")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return data
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	data := serveSMTP(t, ln)
+
+	addr := ln.Addr().(*net.TCPAddr)
+	m := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "no-reply@example.com"})
+
+	err = m.Send(context.Background(), mailer.Message{To: "alice@example.com", Subject: "Reset", Body: "token"})
+	require.NoError(t, err)
+
+	body := <-data
+	require.Contains(t, body, "To: alice@example.com\r\n")
+	require.Contains(t, body, "Subject: Reset\r\n")
+	require.True(t, strings.HasSuffix(body, "\r\ntoken\r\n"))
+}
@@ -0,0 +1,80 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/pkg/metrics"
+)
+
+func scrape(t *testing.T, reg *metrics.Registry) string {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, metrics.ContentType, rr.Header().Get("Content-Type"))
+	body, err := io.ReadAll(rr.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCounterVec(t *testing.T) {
+	reg := metrics.NewRegistry()
+	logins := reg.NewCounterVec("logins_total", "Logins by result.", "result")
+
+	logins.Inc("success")
+	logins.Inc("success")
+	logins.Add(3, `fail"ure`)
+
+	require.Equal(t, 2.0, logins.Value("success"))
+	require.Equal(t, `# HELP logins_total Logins by result.
+# TYPE logins_total counter
+logins_total{result="fail\"ure"} 3
+logins_total{result="success"} 2
+`, scrape(t, reg))
+
+	require.Panics(t, func() { logins.Inc() })
+	require.Panics(t, func() { logins.Add(-1, "success") })
+	require.Panics(t, func() { reg.NewCounterVec("logins_total", "again") })
+}
+
+func TestHistogramVec(t *testing.T) {
+	reg := metrics.NewRegistry()
+	latency := reg.NewHistogramVec("latency_seconds", "Latency.", []float64{0.5, 0.1}, "route")
+
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.3, "/a")
+	latency.Observe(2, "/a")
+
+	require.Equal(t, `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/a",le="0.1"} 1
+latency_seconds_bucket{route="/a",le="0.5"} 2
+latency_seconds_bucket{route="/a",le="+Inf"} 3
+latency_seconds_sum{route="/a"} 2.35
+latency_seconds_count{route="/a"} 3
+`, scrape(t, reg))
+}
+
+func TestDBStats(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(4)
+	require.NoError(t, db.Ping())
+
+	reg := metrics.NewRegistry()
+	reg.RegisterDBStats(db)
+
+	body := scrape(t, reg)
+	require.Contains(t, body, "db_max_open_connections 4\n")
+	require.Contains(t, body, "db_open_connections 1\n")
+	require.Contains(t, body, "# TYPE db_wait_count_total counter\n")
+}
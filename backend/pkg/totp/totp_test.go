@@ -0,0 +1,58 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"workup_fitness/pkg/totp"
+)
+
+// rfcSecret is the SHA1 seed from RFC 6238 appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeAt_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; the last six digits are the 6-digit ones.
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := totp.CodeAt(rfcSecret, totp.Counter(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, want, code, unix)
+	}
+}
+
+func TestValidate_AllowsOneStepOfSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	previous, err := totp.CodeAt(rfcSecret, totp.Counter(now)-1)
+	require.NoError(t, err)
+
+	counter, ok := totp.Validate(rfcSecret, previous, now)
+	require.True(t, ok)
+	require.Equal(t, totp.Counter(now)-1, counter)
+
+	_, ok = totp.Validate(rfcSecret, previous, now.Add(2*totp.Period))
+	require.False(t, ok)
+
+	_, ok = totp.Validate(rfcSecret, "12345", now)
+	require.False(t, ok)
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	uri := totp.URI("Workup Fitness", "alice", secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Workup%20Fitness:alice?"), uri)
+	require.Contains(t, uri, "secret="+secret)
+	require.Contains(t, uri, "issuer=Workup+Fitness")
+}